@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/fake_wms"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/warehouses_management_system"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+)
+
+// Фейковый WMS для локальной разработки.
+//
+// Переменные окружения:
+//
+//	GRPC_PORT         - адрес gRPC сервера, по умолчанию ":8092"
+//	WMS_SEED_FILE     - YAML/JSON файл с начальными стоками, по умолчанию "cmd/fake_wms/stocks.yaml"
+//	WMS_LATENCY       - задержка каждого ответа ("50ms")
+//	WMS_JITTER        - случайная добавка к задержке ("20ms")
+//	WMS_FAILURE_RATE  - доля запросов, на которые отвечаем codes.Unavailable ("0.25")
+func main() {
+	ctx := context.Background()
+
+	logger.SetLevel(zapcore.InfoLevel)
+
+	seedFile := getenv("WMS_SEED_FILE", "cmd/fake_wms/stocks.yaml")
+	seed, err := fake_wms.LoadSeed(seedFile)
+	if err != nil {
+		logger.Fatal(ctx, err)
+	}
+
+	cfg := fake_wms.Config{
+		Latency:     mustDuration(ctx, "WMS_LATENCY"),
+		Jitter:      mustDuration(ctx, "WMS_JITTER"),
+		FailureRate: mustFloat(ctx, "WMS_FAILURE_RATE"),
+	}
+	srv := fake_wms.NewServer(cfg, fake_wms.NewLedger(seed))
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterWarehousesManagementSystemServiceServer(grpcServer, srv)
+	reflection.Register(grpcServer)
+
+	lis, err := net.Listen("tcp", getenv("GRPC_PORT", ":8092"))
+	if err != nil {
+		logger.Fatalf(ctx, "failed to listen: %v", err)
+	}
+
+	// graceful shutdown
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+
+		grpcServer.GracefulStop()
+	}()
+
+	logger.InfoKV(ctx, "start serve", "addr", lis.Addr().String(), "seed", seedFile,
+		"latency", cfg.Latency.String(), "jitter", cfg.Jitter.String(), "failure_rate", cfg.FailureRate)
+	if err := grpcServer.Serve(lis); err != nil {
+		logger.Errorf(ctx, "serve: %v", err)
+	}
+}
+
+func getenv(key, def string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return def
+}
+
+func mustDuration(ctx context.Context, key string) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		logger.Fatalf(ctx, "%s: %v", key, err)
+	}
+	return d
+}
+
+func mustFloat(ctx context.Context, key string) float64 {
+	v := os.Getenv(key)
+	if v == "" {
+		return 0
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		logger.Fatalf(ctx, "%s: %v", key, err)
+	}
+	return f
+}
@@ -2,24 +2,42 @@ package main
 
 import (
 	"context"
+	_ "embed"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	grpcprom "github.com/grpc-ecosystem/go-grpc-middleware/providers/prometheus"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
 	grpc_opentracing "github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/allocation"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/repository/checkout_storage"
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/repository/orders_storage"
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/server"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/services/payments"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/services/pricing"
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/services/warehouses_management_system"
 	transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/workers/order_cancellation"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/workers/orders_projection"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/workers/outbox_relay"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/workers/reservation_expiry"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/workers/saga_recovery"
+	middleware_actor "github.com/moguchev/microservices_courcse/orders_management_system/internal/middleware/actor"
 	middleware_errors "github.com/moguchev/microservices_courcse/orders_management_system/internal/middleware/errors"
 	middleware_logging "github.com/moguchev/microservices_courcse/orders_management_system/internal/middleware/logging"
 	middleware_metrics "github.com/moguchev/microservices_courcse/orders_management_system/internal/middleware/metrics"
 	middleware_recovery "github.com/moguchev/microservices_courcse/orders_management_system/internal/middleware/recovery"
 	middleware_tracing "github.com/moguchev/microservices_courcse/orders_management_system/internal/middleware/tracing"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/closer"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/kafka"
 	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
 	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/postgres"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/resilience"
 	jaeger_tracing "github.com/moguchev/microservices_courcse/orders_management_system/pkg/tracing"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
@@ -31,6 +49,17 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// defaultPriceList - прайс-лист, если PRICES_FILE не задан (образ собирается FROM scratch, файлов рядом нет)
+//
+//go:embed prices.yaml
+var defaultPriceList []byte
+
+// ORDERS_PERSISTENCE - режим хранения заказов
+const (
+	persistenceState        = "state"
+	persistenceEventSourced = "event_sourced"
+)
+
 func main() {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -56,19 +85,191 @@ func main() {
 
 	txManager := transaction_manager.New(pool)
 
-	storage := orders_storage.New(txManager)
+	var storageOpts []orders_storage.Option
+	if v := os.Getenv("SNAPSHOT_EVERY"); v != "" { // "20"
+		snapshotEvery, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			logger.Fatalf(ctx, "invalid SNAPSHOT_EVERY: %v", err)
+		}
+		storageOpts = append(storageOpts, orders_storage.WithSnapshotEvery(snapshotEvery))
+	}
+	storage := orders_storage.New(txManager, storageOpts...)
+	checkoutStorage := checkout_storage.New(txManager)
+
+	// хранение заказов: state - текущее состояние в orders, event_sourced - поток событий в order_events,
+	// orders и order_items ведет проекция
+	persistence := os.Getenv("ORDERS_PERSISTENCE")
+	if persistence == "" {
+		persistence = persistenceState
+	}
+	var ordersStorage orders_management_system.OrdersStorage
+	switch persistence {
+	case persistenceState:
+		ordersStorage = storage
+	case persistenceEventSourced:
+		ordersStorage = orders_storage.NewEventSourced(storage)
+	default:
+		logger.Fatalf(ctx, "invalid ORDERS_PERSISTENCE: %q", persistence)
+	}
 
 	// services
 
-	wmsClient := warehouses_management_system.NewClient()
+	wmsConn, err := warehouses_management_system.Dial(os.Getenv("WMS_ADDR")) // "localhost:8092"
+	if err != nil {
+		logger.Fatalf(ctx, "failed to create wms connection: %v", err)
+	}
+	wmsClient := warehouses_management_system.NewResilient(
+		warehouses_management_system.NewClient(wmsConn,
+			warehouses_management_system.WithCallTimeout(time.Second),
+		),
+		resilience.WithMaxAttempts(3),
+		resilience.WithBackoff(resilience.DefaultBackoff),
+		resilience.WithRetryBudget(10, 0.1),
+		resilience.WithCircuitBreaker(resilience.BreakerConfig{
+			FailureThreshold: 5,
+			OpenTimeout:      5 * time.Second,
+			HalfOpenProbes:   1,
+		}),
+	)
+
+	// склады для позиций без склада: fewest_splits, closest или cheapest
+	allocationStrategyName := os.Getenv("ALLOCATION_STRATEGY")
+	if allocationStrategyName == "" {
+		allocationStrategyName = allocation.FewestSplitsName
+	}
+	allocationStrategy, err := allocation.New(allocationStrategyName)
+	if err != nil {
+		logger.Fatalf(ctx, "invalid ALLOCATION_STRATEGY: %v", err)
+	}
+
+	// цены для снимка в заказе: in-memory прайс-лист из файла PRICES_FILE, по умолчанию - вшитый в бинарник
+	var priceList pricing.PriceList
+	if priceListFile := os.Getenv("PRICES_FILE"); priceListFile != "" {
+		priceList, err = pricing.LoadPriceList(priceListFile)
+	} else {
+		priceList, err = pricing.ParsePriceList(defaultPriceList, ".yaml")
+	}
+	if err != nil {
+		logger.Fatalf(ctx, "failed to load price list: %v", err)
+	}
+
+	// платежный провайдер: fake в памяти, уведомления об авторизации доставляются прямо в usecase
+	// и подписаны ключом PAYMENTS_CALLBACK_SECRET (не задан - ключ случайный, внешние уведомления не принимаются)
+	var paymentsOpts []payments.Option
+	if secret := os.Getenv("PAYMENTS_CALLBACK_SECRET"); secret != "" {
+		paymentsOpts = append(paymentsOpts, payments.WithCallbackSecret([]byte(secret)))
+	}
+	paymentsProvider := payments.NewFake(paymentsOpts...)
 
 	// usecases
 
 	omsUsecase := orders_management_system.NewUsecase(orders_management_system.Deps{ // Dependency injection
 		WarehouseManagementSystem: wmsClient,
-		OrdersStorage:             storage,
+		Inventory:                 wmsClient,
+		AllocationStrategy:        allocationStrategy,
+		Pricing:                   pricing.NewInMemory(priceList),
+		PromoStorage:              storage,
+		Payments:                  paymentsProvider,
+		PaymentsStorage:           storage,
+		ReturnsStorage:            storage,
+		CancellationsStorage:      storage,
+		OrdersStorage:             ordersStorage,
+		CheckoutStorage:           checkoutStorage,
+		SagaStorage:               storage,
 		TransactionManager:        txManager,
 	})
+	paymentsProvider.SetCallbackHandler(omsUsecase.HandlePaymentCallback)
+
+	// workers
+
+	var (
+		publisher      outbox_relay.Publisher = outbox_relay.LoggerPublisher{}
+		closePublisher                        = func() error { return nil }
+	)
+	if brokers := os.Getenv("KAFKA_BROKERS"); brokers != "" { // "localhost:9092,localhost:9093"
+		producer, err := kafka.NewSyncProducer(strings.Split(brokers, ","),
+			kafka.WithProducerClientID("orders-management-system"),
+		)
+		if err != nil {
+			logger.Fatalf(ctx, "failed to create kafka producer: %v", err)
+		}
+		closePublisher = producer.Close
+
+		topic := os.Getenv("KAFKA_ORDERS_TOPIC")
+		if topic == "" {
+			topic = "orders.events"
+		}
+		publisher = outbox_relay.NewKafkaPublisher(producer, topic)
+	}
+
+	outboxRelay := outbox_relay.New(outbox_relay.Config{
+		BatchSize:    100,
+		PollInterval: time.Second,
+	}, outbox_relay.Deps{
+		TransactionManager: txManager,
+		OutboxStorage:      storage,
+		Publisher:          publisher,
+	})
+	outboxRelay.Start(ctx)
+	closer.Add(func(ctx context.Context) error {
+		// closer вызывает функции параллельно: producer закрываем только после остановки relay
+		if err := outboxRelay.Stop(ctx); err != nil {
+			return err
+		}
+		return closePublisher()
+	})
+
+	if persistence == persistenceEventSourced {
+		ordersProjection := orders_projection.New(orders_projection.Config{
+			BatchSize:    100,
+			PollInterval: time.Second,
+		}, orders_projection.Deps{
+			TransactionManager: txManager,
+			ProjectionStorage:  storage,
+		})
+		ordersProjection.Start(ctx)
+		closer.Add(ordersProjection.Stop)
+	}
+
+	sagaRecovery := saga_recovery.New(saga_recovery.Config{
+		BatchSize:    100,
+		PollInterval: 30 * time.Second,
+		StuckAfter:   5 * time.Minute,
+	}, omsUsecase)
+	sagaRecovery.Start(ctx)
+	reservationTTL := 30 * time.Minute
+	if v := os.Getenv("RESERVATION_TTL"); v != "" { // "30m"
+		if reservationTTL, err = time.ParseDuration(v); err != nil {
+			logger.Fatalf(ctx, "invalid RESERVATION_TTL: %v", err)
+		}
+	}
+	reservationExpiry := reservation_expiry.New(reservation_expiry.Config{
+		BatchSize:    100,
+		PollInterval: time.Minute,
+		TTL:          reservationTTL,
+	}, omsUsecase)
+	reservationExpiry.Start(ctx)
+
+	// снятие резерва и вызовы платежного провайдера по отмененным заказам - после коммита отмены
+	orderCancellation := order_cancellation.New(order_cancellation.Config{
+		BatchSize:    100,
+		PollInterval: time.Second,
+	}, omsUsecase)
+	orderCancellation.Start(ctx)
+
+	closer.Add(func(ctx context.Context) error {
+		// компенсации саг и снятие резервов ходят в WMS: соединение закрываем только после остановки workers
+		if err := sagaRecovery.Stop(ctx); err != nil {
+			return err
+		}
+		if err := reservationExpiry.Stop(ctx); err != nil {
+			return err
+		}
+		if err := orderCancellation.Stop(ctx); err != nil {
+			return err
+		}
+		return wmsConn.Close()
+	})
 
 	// Setup metrics.
 	srvMetrics := grpcprom.NewServerMetrics(
@@ -129,6 +330,7 @@ func main() {
 		},
 		UnaryInterceptors: []grpc.UnaryServerInterceptor{
 			middleware_errors.ErrorsUnaryInterceptor(), // далее наши остальные middleware
+			middleware_actor.ActorUnaryInterceptor(),   // инициатор запроса для журнала событий заказа
 		},
 	}
 
@@ -139,6 +341,20 @@ func main() {
 		logger.Fatalf(ctx, "failed to create server: %v", err)
 	}
 
+	// graceful shutdown: по сигналу закрываем все, что зарегистрировано в closer
+	go func() {
+		sig := make(chan os.Signal, 1)
+		signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+		<-sig
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer shutdownCancel()
+
+		if err := closer.CloseAll(shutdownCtx); err != nil {
+			logger.Errorf(ctx, "close: %v", err)
+		}
+	}()
+
 	if err = srv.Run(ctx); err != nil {
 		logger.Errorf(ctx, "run: %v", err)
 	}
@@ -0,0 +1,204 @@
+package allocation
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
+
+// Strategy - выбор складов для позиций заказа без склада (Item.WarehouseID == 0)
+type Strategy interface {
+	// Allocate - позиции с выбранными складами. Позицию, которую целиком не собирает ни один склад,
+	// стратегия делит на несколько позиций одного SKU с разных складов.
+	// Позиции с уже выбранным складом не меняются.
+	//
+	// @errors: models.ErrOutOfStock - стоков на всех складах не хватает
+	Allocate(items []models.Item, stocks []models.WarehouseStocks) ([]models.Item, error)
+}
+
+// Имена стратегий
+const (
+	FewestSplitsName = "fewest_splits"
+	ClosestName      = "closest"
+	CheapestName     = "cheapest"
+)
+
+// New - стратегия по имени
+func New(name string) (Strategy, error) {
+	switch name {
+	case FewestSplitsName:
+		return FewestSplits{}, nil
+	case ClosestName:
+		return Closest{}, nil
+	case CheapestName:
+		return Cheapest{}, nil
+	default:
+		return nil, fmt.Errorf("allocation: unknown strategy %q", name)
+	}
+}
+
+// warehouse - склад в процессе распределения
+type warehouse struct {
+	models.WarehouseStocks
+	left map[models.SKUID]uint32 // остаток за вычетом распределенных позиций
+	used bool                    // с этого склада уже что-то отправляется
+}
+
+// covers - сколько позиций pending склад соберет целиком
+func (w *warehouse) covers(items []models.Item, pending []int) int {
+	var (
+		covered int
+		taken   = make(map[models.SKUID]uint32)
+	)
+	for _, i := range pending {
+		sku, quantity := items[i].SKU.ID, items[i].Quantity
+		if w.left[sku]-taken[sku] >= quantity { // taken не больше left: берем только то, что помещается
+			taken[sku] += quantity
+			covered++
+		}
+	}
+	return covered
+}
+
+// take - списывает quantity SKU с остатка склада (не больше, чем есть), возвращает списанное
+func (w *warehouse) take(sku models.SKUID, quantity uint32) uint32 {
+	quantity = min(quantity, w.left[sku])
+	w.left[sku] -= quantity
+	return quantity
+}
+
+// rules - правила стратегии
+type rules struct {
+	// score - оценка склада, который целиком собирает covered позиций: берется склад с наименьшей
+	score func(w *warehouse, covered int) float64
+	// less - порядок складов, между которыми делится позиция sku
+	less func(a, b *warehouse, sku models.SKUID) bool
+}
+
+// allocate - жадное распределение:
+//  1. пока есть склады, которые целиком собирают хотя бы одну позицию, берем лучший по score
+//     и отдаем ему все позиции, которые он собирает целиком;
+//  2. оставшиеся позиции делим между складами в порядке less.
+func allocate(items []models.Item, stocks []models.WarehouseStocks, r rules) ([]models.Item, error) {
+	warehouses := make([]*warehouse, 0, len(stocks))
+	byID := make(map[models.WarehouseID]*warehouse, len(stocks))
+	for _, s := range stocks {
+		w := &warehouse{WarehouseStocks: s, left: make(map[models.SKUID]uint32, len(s.Stocks))}
+		for sku, quantity := range s.Stocks {
+			w.left[sku] = quantity
+		}
+		warehouses = append(warehouses, w)
+		byID[s.WarehouseID] = w
+	}
+	// детерминированный выбор среди равных
+	slices.SortFunc(warehouses, func(a, b *warehouse) int {
+		return cmp.Compare(a.WarehouseID, b.WarehouseID)
+	})
+
+	var (
+		allocated = make([][]models.Item, len(items))
+		pending   []int
+	)
+	for i, item := range items {
+		if item.WarehouseID != 0 {
+			// склад выбрал клиент: стоки под позицию заняты, отправка с этого склада уже есть
+			allocated[i] = []models.Item{item}
+			if w := byID[item.WarehouseID]; w != nil {
+				w.take(item.SKU.ID, item.Quantity)
+				w.used = true
+			}
+			continue
+		}
+		pending = append(pending, i)
+	}
+
+	// 1. позиции целиком с одного склада
+	for len(pending) > 0 {
+		var (
+			best      *warehouse
+			bestScore float64
+		)
+		for _, w := range warehouses {
+			covered := w.covers(items, pending)
+			if covered == 0 {
+				continue
+			}
+			if score := r.score(w, covered); best == nil || score < bestScore {
+				best, bestScore = w, score
+			}
+		}
+		if best == nil {
+			break
+		}
+
+		rest := make([]int, 0, len(pending))
+		for _, i := range pending {
+			item := items[i]
+			if best.left[item.SKU.ID] < item.Quantity {
+				rest = append(rest, i)
+				continue
+			}
+			best.take(item.SKU.ID, item.Quantity)
+			item.WarehouseID = best.WarehouseID
+			allocated[i] = []models.Item{item}
+		}
+		best.used = true
+		pending = rest
+	}
+
+	// 2. делим позиции между складами
+	for _, i := range pending {
+		item := items[i]
+		order := slices.Clone(warehouses)
+		slices.SortStableFunc(order, func(a, b *warehouse) int {
+			switch {
+			case r.less(a, b, item.SKU.ID):
+				return -1
+			case r.less(b, a, item.SKU.ID):
+				return 1
+			default:
+				return 0
+			}
+		})
+
+		need := item.Quantity
+		for _, w := range order {
+			if need == 0 {
+				break
+			}
+			if taken := w.take(item.SKU.ID, need); taken > 0 {
+				need -= taken
+				w.used = true
+				allocated[i] = append(allocated[i], models.Item{SKU: item.SKU, Quantity: taken, WarehouseID: w.WarehouseID})
+			}
+		}
+		if need > 0 {
+			return nil, fmt.Errorf("sku %d: not enough %d on all warehouses: %w", item.SKU.ID, need, models.ErrOutOfStock)
+		}
+	}
+
+	res := make([]models.Item, 0, len(items))
+	for _, a := range allocated {
+		res = append(res, a...)
+	}
+	return res, nil
+}
+
+// distance - расстояние для сравнения: неизвестное - дальше всех
+func distance(w *warehouse) float64 {
+	if w.DistanceKm == 0 {
+		return math.MaxUint32
+	}
+	return float64(w.DistanceKm)
+}
+
+// shippingCost - стоимость добавить склад в заказ: отправка с уже используемого склада бесплатна
+func shippingCost(w *warehouse) float64 {
+	if w.used {
+		return 0
+	}
+	return float64(w.ShippingCost)
+}
@@ -0,0 +1,94 @@
+//go:build test
+
+package allocation
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStrategies(t *testing.T) {
+	var (
+		stocks = []models.WarehouseStocks{
+			{WarehouseID: 2, Stocks: map[models.SKUID]uint32{1: 10, 2: 10, 3: 10}, DistanceKm: 100, ShippingCost: 500},
+			{WarehouseID: 1, Stocks: map[models.SKUID]uint32{1: 10, 2: 5}, DistanceKm: 50, ShippingCost: 300},
+			{WarehouseID: 3, Stocks: map[models.SKUID]uint32{3: 10}, DistanceKm: 10, ShippingCost: 100},
+		}
+		item = func(sku models.SKUID, quantity uint32, warehouseID models.WarehouseID) models.Item {
+			return models.Item{SKU: models.SKU{ID: sku}, Quantity: quantity, WarehouseID: warehouseID}
+		}
+		order = []models.Item{item(1, 5, 0), item(2, 5, 0), item(3, 5, 0)}
+	)
+
+	tests := []struct {
+		name     string
+		strategy string
+		items    []models.Item
+		want     []models.Item
+		wantErr  error
+	}{
+		{
+			name:     "Test 1. Positive. Fewest splits takes the whole order from one warehouse.",
+			strategy: FewestSplitsName,
+			items:    order,
+			want:     []models.Item{item(1, 5, 2), item(2, 5, 2), item(3, 5, 2)},
+		},
+		{
+			name:     "Test 2. Positive. Closest prefers nearest warehouses.",
+			strategy: ClosestName,
+			items:    order,
+			want:     []models.Item{item(1, 5, 1), item(2, 5, 1), item(3, 5, 3)},
+		},
+		{
+			name:     "Test 3. Positive. Cheapest minimizes total shipping cost.",
+			strategy: CheapestName,
+			items:    order,
+			want:     []models.Item{item(1, 5, 1), item(2, 5, 1), item(3, 5, 3)},
+		},
+		{
+			name:     "Test 4. Positive. Item is split when no warehouse has enough.",
+			strategy: FewestSplitsName,
+			items:    []models.Item{item(1, 15, 0)},
+			want:     []models.Item{item(1, 10, 1), item(1, 5, 2)},
+		},
+		{
+			name:     "Test 5. Positive. Items with chosen warehouse are kept and take stock.",
+			strategy: FewestSplitsName,
+			items:    []models.Item{item(2, 5, 1), item(2, 3, 0)},
+			want:     []models.Item{item(2, 5, 1), item(2, 3, 2)},
+		},
+		{
+			name:     "Test 6. Negative. Not enough stock on all warehouses.",
+			strategy: CheapestName,
+			items:    []models.Item{item(3, 25, 0)},
+			wantErr:  models.ErrOutOfStock,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			strategy, err := New(tt.strategy)
+			assert.NoError(t, err)
+
+			// act
+			got, err := strategy.Allocate(tt.items, stocks)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNew_UnknownStrategy(t *testing.T) {
+	_, err := New("random")
+	assert.Error(t, err)
+}
@@ -0,0 +1,53 @@
+package allocation
+
+import "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+
+// FewestSplits - заказ собирается с как можно меньшего числа складов
+type FewestSplits struct{}
+
+var fewestSplitsRules = rules{
+	score: func(_ *warehouse, covered int) float64 {
+		return -float64(covered)
+	},
+	less: func(a, b *warehouse, sku models.SKUID) bool {
+		return a.left[sku] > b.left[sku]
+	},
+}
+
+func (FewestSplits) Allocate(items []models.Item, stocks []models.WarehouseStocks) ([]models.Item, error) {
+	return allocate(items, stocks, fewestSplitsRules)
+}
+
+// Closest - позиции собираются с ближайших к способу доставки складов
+type Closest struct{}
+
+var closestRules = rules{
+	score: func(w *warehouse, covered int) float64 {
+		// среди равноудаленных - тот, что соберет больше позиций
+		return distance(w) + 1/float64(covered+1)
+	},
+	less: func(a, b *warehouse, _ models.SKUID) bool {
+		return distance(a) < distance(b)
+	},
+}
+
+func (Closest) Allocate(items []models.Item, stocks []models.WarehouseStocks) ([]models.Item, error) {
+	return allocate(items, stocks, closestRules)
+}
+
+// Cheapest - минимальная суммарная стоимость отправки со складов
+type Cheapest struct{}
+
+var cheapestRules = rules{
+	score: func(w *warehouse, covered int) float64 {
+		// стоимость в пересчете на собранную позицию
+		return shippingCost(w) / float64(covered)
+	},
+	less: func(a, b *warehouse, _ models.SKUID) bool {
+		return shippingCost(a) < shippingCost(b)
+	},
+}
+
+func (Cheapest) Allocate(items []models.Item, stocks []models.WarehouseStocks) ([]models.Item, error) {
+	return allocate(items, stocks, cheapestRules)
+}
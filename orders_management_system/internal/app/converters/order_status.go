@@ -0,0 +1,40 @@
+package converters
+
+import (
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+)
+
+// Соответствие статусов заказа и pb.OrderStatus - одно на API и события
+var (
+	pbOrderStatusByModelsOrderStatus = map[models.OrderStatus]pb.OrderStatus{
+		models.OrderStatusNew:             pb.OrderStatus_ORDER_STATUS_NEW,
+		models.OrderStatusReserved:        pb.OrderStatus_ORDER_STATUS_RESERVED,
+		models.OrderStatusAwaitingPayment: pb.OrderStatus_ORDER_STATUS_AWAITING_PAYMENT,
+		models.OrderStatusPaymentFailed:   pb.OrderStatus_ORDER_STATUS_PAYMENT_FAILED,
+		models.OrderStatusPaid:            pb.OrderStatus_ORDER_STATUS_PAID,
+		models.OrderStatusShipped:         pb.OrderStatus_ORDER_STATUS_SHIPPED,
+		models.OrderStatusDelivered:       pb.OrderStatus_ORDER_STATUS_DELIVERED,
+		models.OrderStatusCancelled:       pb.OrderStatus_ORDER_STATUS_CANCELLED,
+		models.OrderStatusFailed:          pb.OrderStatus_ORDER_STATUS_FAILED,
+	}
+
+	modelsOrderStatusByPbOrderStatus = func() map[pb.OrderStatus]models.OrderStatus {
+		m := make(map[pb.OrderStatus]models.OrderStatus, len(pbOrderStatusByModelsOrderStatus))
+		for k, v := range pbOrderStatusByModelsOrderStatus {
+			m[v] = k
+		}
+		return m
+	}()
+)
+
+// NewPbOrderStatusFromModelsOrderStatus - pb.OrderStatus статуса заказа
+func NewPbOrderStatusFromModelsOrderStatus(status models.OrderStatus) pb.OrderStatus {
+	return pbOrderStatusByModelsOrderStatus[status] // ORDER_STATUS_UNSPECIFIED для неизвестных
+}
+
+// NewModelsOrderStatusFromPbOrderStatus - статус заказа по pb.OrderStatus (false для неизвестных)
+func NewModelsOrderStatusFromPbOrderStatus(status pb.OrderStatus) (models.OrderStatus, bool) {
+	s, ok := modelsOrderStatusByPbOrderStatus[status]
+	return s, ok
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ActorType - кто изменил заказ
+type ActorType string
+
+const (
+	ActorSystem          ActorType = "system"           // сам сервис (фоновые процессы)
+	ActorCustomer        ActorType = "customer"         // покупатель
+	ActorSupport         ActorType = "support"          // сотрудник поддержки
+	ActorPaymentProvider ActorType = "payment_provider" // платежный провайдер (уведомление о платеже)
+)
+
+// IsValid - известный ли тип
+func (t ActorType) IsValid() bool {
+	switch t {
+	case ActorSystem, ActorCustomer, ActorSupport, ActorPaymentProvider:
+		return true
+	default:
+		return false
+	}
+}
+
+// Actor - инициатор изменения заказа
+type Actor struct {
+	Type ActorType // Кто изменил заказ
+	ID   string    // ID покупателя или сотрудника (пустой - неизвестен)
+}
+
+// OrderTimelineEntry - запись журнала событий заказа (order_events)
+type OrderTimelineEntry struct {
+	EventID    uuid.UUID      // ID события
+	OrderID    OrderID        // ID заказа
+	Type       OrderEventType // Тип события
+	Status     OrderStatus    // Статус заказа после события (пустой - неизвестен)
+	Actor      Actor          // Кто изменил заказ
+	Reason     string         // Причина изменения
+	OccurredAt time.Time      // Время события
+}
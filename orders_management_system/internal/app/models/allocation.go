@@ -0,0 +1,19 @@
+package models
+
+// WarehouseStocks - доступные стоки склада и параметры отправки с него
+type WarehouseStocks struct {
+	WarehouseID  WarehouseID
+	Stocks       map[SKUID]uint32 // доступное (не зарезервированное) количество
+	DistanceKm   uint32           // расстояние до способа доставки заказа, 0 - неизвестно
+	ShippingCost uint64           // стоимость отправки со склада, копейки
+}
+
+// NeedsAllocation - есть ли позиции без склада
+func NeedsAllocation(items []Item) bool {
+	for _, item := range items {
+		if item.WarehouseID == 0 {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Basket - корзина пользователя
+type Basket struct {
+	UserID UserID       // ID пользователя (чья корзина)
+	Items  []BasketItem // Позиции корзины
+}
+
+// BasketItem - позиция корзины
+type BasketItem struct {
+	Item                // Товар, количество и склад
+	UpdatedAt time.Time // Время последнего изменения позиции
+}
+
+// OrderItems - состав заказа из позиций корзины
+func (b *Basket) OrderItems() []Item {
+	items := make([]Item, len(b.Items))
+	for i := range b.Items {
+		items[i] = b.Items[i].Item
+	}
+	return items
+}
@@ -5,6 +5,40 @@ import "errors"
 var (
 	// ErrAlreadyExists - error already exists
 	ErrAlreadyExists = errors.New("already exists")
+	// ErrNotFound - error not found
+	ErrNotFound = errors.New("not found")
+	// ErrInvalidArgument - error invalid argument
+	ErrInvalidArgument = errors.New("invalid argument")
+	// ErrInvalidStatusTransition - error illegal order status transition
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	// ErrIdempotencyKeyReused - error idempotency key reused with another request
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with different request")
+	// ErrBasketChanged - error basket changed while order was being created
+	ErrBasketChanged = errors.New("basket changed")
+	// ErrOutOfStock - error not enough stock on warehouse
+	ErrOutOfStock = errors.New("out of stock")
+	// ErrUnknownWarehouse - error warehouse does not exist
+	ErrUnknownWarehouse = errors.New("unknown warehouse")
+	// ErrUnknownReservation - error stock reservation does not exist (expired or released)
+	ErrUnknownReservation = errors.New("unknown reservation")
+	// ErrPriceNotFound - error no price for SKU or delivery variant
+	ErrPriceNotFound = errors.New("price not found")
+	// ErrCurrencyMismatch - error operation on money in different currencies
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	// ErrInvalidPromoCode - error promo code is unknown, disabled or expired
+	ErrInvalidPromoCode = errors.New("invalid promo code")
+	// ErrPromoCodeExhausted - error promo code usage limit reached
+	ErrPromoCodeExhausted = errors.New("promo code usage limit reached")
+	// ErrInvalidReturn - error order or items can not be returned
+	ErrInvalidReturn = errors.New("invalid return")
+	// ErrInvalidItemsCancellation - error order items can not be cancelled
+	ErrInvalidItemsCancellation = errors.New("invalid items cancellation")
+	// ErrConcurrentModification - error order was changed concurrently (stream version conflict)
+	ErrConcurrentModification = errors.New("concurrent modification")
+	// ErrUnauthenticated - error request signature or credentials are invalid
+	ErrUnauthenticated = errors.New("unauthenticated")
+	// ErrServiceUnavailable - error external service temporarily unavailable
+	ErrServiceUnavailable = errors.New("service unavailable")
 	// ErrUnimplemented - error unimplemented
 	ErrUnimplemented = errors.New("unimplemented")
 )
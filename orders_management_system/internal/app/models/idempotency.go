@@ -0,0 +1,9 @@
+package models
+
+// IdempotencyKey - ключ идемпотентности запроса создания заказа
+type IdempotencyKey struct {
+	UserID      UserID  // ID пользователя (ключи уникальны в рамках пользователя)
+	Key         string  // Ключ идемпотентности от клиента
+	RequestHash string  // Отпечаток тела запроса, с которым ключ был использован впервые
+	OrderID     OrderID // ID заказа, созданного по этому ключу
+}
@@ -0,0 +1,161 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Currency - код валюты ISO 4217
+type Currency string
+
+// CurrencyRUB - российский рубль
+const CurrencyRUB Currency = "RUB"
+
+// minorUnits - число знаков после запятой у валют, где их не 2 (ISO 4217)
+var minorUnits = map[Currency]int{
+	"JPY": 0,
+	"KRW": 0,
+	"BHD": 3,
+	"KWD": 3,
+}
+
+// MinorUnits - число знаков после запятой у валюты (по умолчанию 2)
+func (c Currency) MinorUnits() int {
+	if n, ok := minorUnits[c]; ok {
+		return n
+	}
+	return 2
+}
+
+// Money - денежная сумма в минорных единицах валюты (копейки для RUB).
+// Арифметика только целочисленная - без ошибок округления float.
+// Нулевое значение (0 без валюты) складывается с суммой в любой валюте
+type Money struct {
+	Amount   int64    // сумма в минорных единицах
+	Currency Currency // код валюты ISO 4217
+}
+
+// NewMoney - сумма amount в минорных единицах валюты currency
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// IsZero - нулевая ли сумма
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+// Add - m + other
+//
+// @errors: ErrCurrencyMismatch, ErrInvalidArgument (переполнение)
+func (m Money) Add(other Money) (Money, error) {
+	currency, err := m.commonCurrency(other)
+	if err != nil {
+		return Money{}, err
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) ||
+		(other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, fmt.Errorf("%w: money overflow", ErrInvalidArgument)
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: currency}, nil
+}
+
+// Sub - m - other
+//
+// @errors: ErrCurrencyMismatch, ErrInvalidArgument (переполнение)
+func (m Money) Sub(other Money) (Money, error) {
+	if other.Amount == math.MinInt64 {
+		return Money{}, fmt.Errorf("%w: money overflow", ErrInvalidArgument)
+	}
+	return m.Add(Money{Amount: -other.Amount, Currency: other.Currency})
+}
+
+// Mul - m * n
+//
+// @errors: ErrInvalidArgument (переполнение)
+func (m Money) Mul(n uint32) (Money, error) {
+	if n != 0 && (m.Amount > math.MaxInt64/int64(n) || m.Amount < math.MinInt64/int64(n)) {
+		return Money{}, fmt.Errorf("%w: money overflow", ErrInvalidArgument)
+	}
+	return Money{Amount: m.Amount * int64(n), Currency: m.Currency}, nil
+}
+
+// String - сумма в основных единицах валюты: "1234.50 RUB"
+func (m Money) String() string {
+	var (
+		units  = m.Currency.MinorUnits()
+		amount = m.Amount
+		sign   = ""
+	)
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	s := fmt.Sprintf("%0*d", units+1, uint64(amount))
+	if units > 0 {
+		s = s[:len(s)-units] + "." + s[len(s)-units:]
+	}
+	return strings.TrimSpace(sign + s + " " + string(m.Currency))
+}
+
+func (m Money) commonCurrency(other Money) (Currency, error) {
+	switch {
+	case m.Currency == other.Currency:
+		return m.Currency, nil
+	case m.Currency == "" && m.Amount == 0:
+		return other.Currency, nil
+	case other.Currency == "" && other.Amount == 0:
+		return m.Currency, nil
+	default:
+		return "", fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+}
+
+// OrderTotals - стоимость заказа
+type OrderTotals struct {
+	Subtotal Money // сумма позиций по ценам на момент заказа
+	Discount Money // скидка
+	Delivery Money // стоимость доставки
+	Total    Money // к оплате: Subtotal - Discount + Delivery
+}
+
+// CalculateTotals - стоимость заказа из цен позиций (Item.Price), доставки и скидки.
+// Скидка больше стоимости заказа ограничивается стоимостью заказа (Subtotal + Delivery)
+//
+// @errors: ErrCurrencyMismatch, ErrInvalidArgument
+func CalculateTotals(items []Item, delivery, discount Money) (OrderTotals, error) {
+	var subtotal Money
+	for _, item := range items {
+		line, err := item.Price.Mul(item.Quantity)
+		if err != nil {
+			return OrderTotals{}, err
+		}
+		if subtotal, err = subtotal.Add(line); err != nil {
+			return OrderTotals{}, err
+		}
+	}
+
+	if discount.Amount < 0 {
+		return OrderTotals{}, fmt.Errorf("%w: negative discount", ErrInvalidArgument)
+	}
+	beforeDiscount, err := subtotal.Add(delivery)
+	if err != nil {
+		return OrderTotals{}, err
+	}
+	if discount.Amount > beforeDiscount.Amount {
+		discount.Amount = beforeDiscount.Amount
+	}
+
+	total, err := beforeDiscount.Sub(discount)
+	if err != nil {
+		return OrderTotals{}, err
+	}
+
+	return OrderTotals{
+		Subtotal: subtotal,
+		Discount: discount,
+		Delivery: delivery,
+		Total:    total,
+	}, nil
+}
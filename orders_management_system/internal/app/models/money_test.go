@@ -0,0 +1,94 @@
+//go:build test
+
+package models
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMoney_String(t *testing.T) {
+	assert.Equal(t, "1234.50 RUB", NewMoney(123450, CurrencyRUB).String())
+	assert.Equal(t, "-0.05 RUB", NewMoney(-5, CurrencyRUB).String())
+	assert.Equal(t, "100 JPY", NewMoney(100, "JPY").String())
+	assert.Equal(t, "0.00", Money{}.String())
+}
+
+func TestMoney_Add(t *testing.T) {
+	tests := []struct {
+		name    string
+		a, b    Money
+		want    Money
+		wantErr error
+	}{
+		{name: "Test 1. Positive. Same currency.", a: NewMoney(100, CurrencyRUB), b: NewMoney(250, CurrencyRUB), want: NewMoney(350, CurrencyRUB)},
+		{name: "Test 2. Positive. Zero value takes currency.", a: Money{}, b: NewMoney(250, CurrencyRUB), want: NewMoney(250, CurrencyRUB)},
+		{name: "Test 3. Negative. Different currencies.", a: NewMoney(100, CurrencyRUB), b: NewMoney(1, "USD"), wantErr: ErrCurrencyMismatch},
+		{name: "Test 4. Negative. Overflow.", a: NewMoney(math.MaxInt64, CurrencyRUB), b: NewMoney(1, CurrencyRUB), wantErr: ErrInvalidArgument},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.a.Add(tt.b)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCalculateTotals(t *testing.T) {
+	var (
+		rub   = func(amount int64) Money { return NewMoney(amount, CurrencyRUB) }
+		items = []Item{
+			{SKU: SKU{ID: 1}, Quantity: 3, Price: rub(1000)},
+			{SKU: SKU{ID: 2}, Quantity: 1, Price: rub(550)},
+		}
+	)
+
+	tests := []struct {
+		name     string
+		items    []Item
+		delivery Money
+		discount Money
+		want     OrderTotals
+		wantErr  error
+	}{
+		{
+			name:     "Test 1. Positive. Subtotal, discount and delivery.",
+			items:    items,
+			delivery: rub(300),
+			discount: rub(50),
+			want:     OrderTotals{Subtotal: rub(3550), Discount: rub(50), Delivery: rub(300), Total: rub(3800)},
+		},
+		{
+			name:     "Test 2. Positive. Discount is capped by order cost.",
+			items:    items,
+			delivery: rub(300),
+			discount: rub(10000),
+			want:     OrderTotals{Subtotal: rub(3550), Discount: rub(3850), Delivery: rub(300), Total: rub(0)},
+		},
+		{
+			name:     "Test 3. Negative. Delivery in another currency.",
+			items:    items,
+			delivery: NewMoney(300, "USD"),
+			wantErr:  ErrCurrencyMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CalculateTotals(tt.items, tt.delivery, tt.discount)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -6,10 +6,17 @@ import (
 
 // Order - заказ
 type Order struct {
-	ID                OrderID // ID заказа
-	UserID            UserID  // ID пользователя (чей заказ)
-	Items             []Item  // Информация о составе заказа
-	DeliveryOrderInfo         // Информация о доставке
+	ID                OrderID       // ID заказа
+	UserID            UserID        // ID пользователя (чей заказ)
+	Items             []Item        // Информация о составе заказа
+	DeliveryOrderInfo               // Информация о доставке
+	Totals            OrderTotals   // Стоимость заказа (по ценам на момент заказа)
+	PromoCode         string        // Примененный промокод (пустой - без промокода)
+	Status            OrderStatus   // Статус заказа
+	ReservationID     ReservationID // ID резерва стоков на складах (пустой - резерва нет)
+	StatusChangedAt   time.Time     // Время последней смены статуса
+	CreatedAt         time.Time     // Время создания заказа
+	Version           uint64        // Версия потока событий заказа: номер последнего события (0 - событий еще нет)
 	/* ... */
 }
 
@@ -24,4 +31,20 @@ type Item struct {
 	SKU         SKU         // SKU
 	Quantity    uint32      // количество SKU
 	WarehouseID WarehouseID // с какого склада будет браться сток
+	Price       Money       // цена за единицу на момент заказа
+}
+
+// UniqueSKUs - SKU позиций без повторов, в порядке первого появления
+func UniqueSKUs(items []Item) []SKUID {
+	var (
+		skus = make([]SKUID, 0, len(items))
+		seen = make(map[SKUID]struct{}, len(items))
+	)
+	for _, item := range items {
+		if _, ok := seen[item.SKU.ID]; !ok {
+			seen[item.SKU.ID] = struct{}{}
+			skus = append(skus, item.SKU.ID)
+		}
+	}
+	return skus
 }
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"fmt"
+)
+
+// Apply - применяет к заказу следующее событие его потока. Состояние заказа в режиме event sourcing -
+// свертка событий потока (от снимка или от OrderCreated), поэтому Apply не проверяет бизнес-правила
+// повторно: событие уже произошло, меняется только состояние
+//
+// @errors: ErrConcurrentModification (событие не следующее в потоке), ErrInvalidStatusTransition,
+// ErrInvalidItemsCancellation, ErrInvalidArgument
+func (o *Order) Apply(event OrderEvent) error {
+	if event.StreamVersion != o.Version+1 {
+		return fmt.Errorf("%w: event %d after version %d", ErrConcurrentModification, event.StreamVersion, o.Version)
+	}
+
+	switch event.Type {
+	case OrderEventCreated:
+		if o.Version != 0 {
+			return fmt.Errorf("%w: %s in existing stream", ErrInvalidArgument, event.Type)
+		}
+		// состояние заказа при создании - целиком в событии
+		*o = event.Order
+	case OrderEventCancelled, OrderEventStatusChanged, OrderEventPaid, OrderEventPaymentFailed:
+		if event.Transition == nil {
+			return fmt.Errorf("%w: %s event without transition", ErrInvalidArgument, event.Type)
+		}
+		if o.Status != event.Transition.From {
+			return fmt.Errorf("%s -> %s from %s: %w", event.Transition.From, event.Transition.To, o.Status, ErrInvalidStatusTransition)
+		}
+		o.Status = event.Transition.To
+		o.StatusChangedAt = event.Transition.ChangedAt
+	case OrderEventItemsCancelled:
+		// отмененные позиции - с конкретным складом, поэтому повторная отмена снимает те же позиции
+		// и так же пересчитывает стоимость
+		if _, _, err := o.CancelItems(event.Items, event.OccurredAt); err != nil {
+			return err
+		}
+	case OrderEventReturnRequested, OrderEventReturnApproved:
+		// заявки на возврат живут отдельно от заказа: меняется только версия
+	default:
+		return fmt.Errorf("%w: unknown event type %q", ErrInvalidArgument, event.Type)
+	}
+
+	o.Version = event.StreamVersion
+
+	return nil
+}
+
+// RebuildOrder - восстанавливает заказ из снимка snapshot (nil - с начала потока) и событий потока после него
+//
+// @errors: ErrNotFound (нет ни снимка, ни событий), ошибки Apply
+func RebuildOrder(snapshot *Order, events []OrderEvent) (*Order, error) {
+	if snapshot == nil && len(events) == 0 {
+		return nil, ErrNotFound
+	}
+
+	var order Order
+	if snapshot != nil {
+		order = *snapshot
+		order.Items = append([]Item(nil), snapshot.Items...)
+	}
+	for _, event := range events {
+		if err := order.Apply(event); err != nil {
+			return nil, fmt.Errorf("apply %s (version %d): %w", event.Type, event.StreamVersion, err)
+		}
+	}
+
+	return &order, nil
+}
@@ -0,0 +1,119 @@
+//go:build test
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRebuildOrder(t *testing.T) {
+	var (
+		now       = time.Now()
+		createdAt = now.Add(-time.Hour) // заказ создан раньше последующих изменений
+		orderID   = OrderID(uuid.New())
+		rub       = func(amount int64) Money { return NewMoney(amount, CurrencyRUB) }
+		created   = Order{
+			ID:     orderID,
+			Status: OrderStatusNew,
+			Items: []Item{
+				{SKU: SKU{ID: 1}, Quantity: 2, WarehouseID: 10, Price: rub(1000)},
+				{SKU: SKU{ID: 2}, Quantity: 1, WarehouseID: 10, Price: rub(500)},
+			},
+			Totals:          OrderTotals{Subtotal: rub(2500), Discount: rub(0), Delivery: rub(0), Total: rub(2500)},
+			StatusChangedAt: createdAt,
+			CreatedAt:       createdAt,
+			Version:         1,
+		}
+		transition = func(version uint64, from, to OrderStatus) OrderEvent {
+			return OrderEvent{
+				Type:          OrderEventStatusChanged,
+				StreamVersion: version,
+				Order:         Order{ID: orderID},
+				Transition:    &OrderStatusTransition{OrderID: orderID, From: from, To: to, ChangedAt: now},
+			}
+		}
+		createdEvent = OrderEvent{Type: OrderEventCreated, StreamVersion: 1, Order: created}
+	)
+
+	tests := []struct {
+		name     string
+		snapshot *Order
+		events   []OrderEvent
+		want     *Order
+		wantErr  error
+	}{
+		{
+			name: "Test 1. Positive. Order is folded from the beginning of the stream.",
+			events: []OrderEvent{
+				createdEvent,
+				transition(2, OrderStatusNew, OrderStatusReserved),
+				{
+					Type:          OrderEventItemsCancelled,
+					StreamVersion: 3,
+					Order:         Order{ID: orderID},
+					Items:         []Item{{SKU: SKU{ID: 2}, Quantity: 1, WarehouseID: 10, Price: rub(500)}},
+					OccurredAt:    now,
+				},
+			},
+			want: &Order{
+				ID:              orderID,
+				Status:          OrderStatusReserved,
+				Items:           []Item{{SKU: SKU{ID: 1}, Quantity: 2, WarehouseID: 10, Price: rub(1000)}},
+				Totals:          OrderTotals{Subtotal: rub(2000), Discount: rub(0), Delivery: rub(0), Total: rub(2000)},
+				StatusChangedAt: now,
+				CreatedAt:       createdAt,
+				Version:         3,
+			},
+		},
+		{
+			name: "Test 2. Positive. Only events after snapshot are applied.",
+			snapshot: &Order{
+				ID: orderID, Status: OrderStatusReserved, Items: created.Items, Totals: created.Totals,
+				StatusChangedAt: now, CreatedAt: createdAt, Version: 2,
+			},
+			events: []OrderEvent{transition(3, OrderStatusReserved, OrderStatusAwaitingPayment)},
+			want: &Order{
+				ID: orderID, Status: OrderStatusAwaitingPayment, Items: created.Items, Totals: created.Totals,
+				StatusChangedAt: now, CreatedAt: createdAt, Version: 3,
+			},
+		},
+		{
+			name:   "Test 3. Positive. Creation time is kept after status changes.",
+			events: []OrderEvent{createdEvent, transition(2, OrderStatusNew, OrderStatusReserved)},
+			want: &Order{
+				ID: orderID, Status: OrderStatusReserved, Items: created.Items, Totals: created.Totals,
+				StatusChangedAt: now, CreatedAt: createdAt, Version: 2,
+			},
+		},
+		{
+			name:    "Test 4. Negative. Gap in stream.",
+			events:  []OrderEvent{createdEvent, transition(3, OrderStatusNew, OrderStatusReserved)},
+			wantErr: ErrConcurrentModification,
+		},
+		{
+			name:    "Test 5. Negative. Transition from another status.",
+			events:  []OrderEvent{createdEvent, transition(2, OrderStatusReserved, OrderStatusAwaitingPayment)},
+			wantErr: ErrInvalidStatusTransition,
+		},
+		{
+			name:    "Test 6. Negative. Neither snapshot nor events.",
+			wantErr: ErrNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RebuildOrder(tt.snapshot, tt.events)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
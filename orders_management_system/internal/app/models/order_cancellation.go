@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// OrderCancellation - внешние действия отмены заказа: снятие резерва в WMS, отмена авторизации
+// и возврат денег у провайдера. Записывается в транзакции отмены, а выполняется после коммита
+// (transactional outbox): действия повторяются, пока все не пройдут, поэтому каждое идемпотентно
+type OrderCancellation struct {
+	OrderID       OrderID         // ID отмененного заказа
+	ReservationID ReservationID   // Резерв заказа (пустой - снимать нечего)
+	Warehouses    []WarehouseID   // Склады резерва
+	Voids         []string        // ID платежей у провайдера, авторизацию которых нужно отменить
+	Refunds       []PaymentRefund // Возвраты списанных денег
+	Attempts      uint32          // Сколько раз выполнить действия не удалось
+	CreatedAt     time.Time       // Время отмены заказа
+}
+
+// PaymentRefund - возврат списанных денег по платежу у провайдера
+type PaymentRefund struct {
+	ProviderPaymentID string // ID платежа у провайдера
+	Amount            Money  // Сумма возврата
+}
+
+// IsEmpty - нет ни одного внешнего действия
+func (c OrderCancellation) IsEmpty() bool {
+	return len(c.Warehouses) == 0 && len(c.Voids) == 0 && len(c.Refunds) == 0
+}
+
+// RefundID - ключ идемпотентности возвратов отмены: заказ отменяется один раз
+func (c OrderCancellation) RefundID() string {
+	return "cancel-" + c.OrderID.String()
+}
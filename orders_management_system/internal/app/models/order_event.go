@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OrderEventType - тип доменного события заказа
+type OrderEventType string
+
+const (
+	OrderEventCreated       OrderEventType = "OrderCreated"       // заказ создан
+	OrderEventCancelled     OrderEventType = "OrderCancelled"     // заказ отменен
+	OrderEventStatusChanged OrderEventType = "OrderStatusChanged" // сменился статус заказа
+	OrderEventPaid          OrderEventType = "OrderPaid"          // заказ оплачен
+	OrderEventPaymentFailed OrderEventType = "OrderPaymentFailed" // оплата заказа не прошла
+
+	OrderEventItemsCancelled  OrderEventType = "OrderItemsCancelled"  // отменена часть позиций заказа
+	OrderEventReturnRequested OrderEventType = "OrderReturnRequested" // оформлен возврат товаров заказа
+	OrderEventReturnApproved  OrderEventType = "OrderReturnApproved"  // возврат принят: товары можно вернуть на склад
+)
+
+// OrderEventVersion - текущая версия схемы событий
+const OrderEventVersion uint32 = 1
+
+// OrderEvent - доменное событие заказа, публикуется через outbox
+type OrderEvent struct {
+	ID            uuid.UUID              // ID события
+	Type          OrderEventType         // Тип события
+	StreamVersion uint64                 // Номер события в потоке заказа (с 1): версия заказа после события
+	Seq           int64                  // Позиция события в журнале order_events (заполняется при чтении журнала)
+	Version       uint32                 // Версия схемы события
+	OccurredAt    time.Time              // Время события
+	Order         Order                  // Снимок заказа на момент события
+	Transition    *OrderStatusTransition // Смена статуса (для OrderCancelled, OrderStatusChanged, OrderPaid и OrderPaymentFailed)
+	Payment       *Payment               // Платеж (для OrderPaid и OrderPaymentFailed)
+	Return        *Return                // Заявка на возврат (для OrderReturnRequested и OrderReturnApproved)
+	Items         []Item                 // Отмененные позиции (для OrderItemsCancelled)
+	Actor         Actor                  // Кто изменил заказ
+	Reason        string                 // Причина изменения (отмены, отказа в оплате, возврата)
+}
+
+// RefundID - ключ идемпотентности возврата денег по событию: в отличие от ID события
+// номер события в потоке заказа не меняется при повторе после отката транзакции
+func (e OrderEvent) RefundID() string {
+	return fmt.Sprintf("%s-%d", e.Order.ID, e.StreamVersion)
+}
+
+// NewOrderCreatedEvent - событие создания заказа
+func NewOrderCreatedEvent(order *Order) OrderEvent {
+	return newOrderEvent(OrderEventCreated, order, nil, order.StatusChangedAt)
+}
+
+// NewOrderCancelledEvent - событие отмены заказа по причине reason
+func NewOrderCancelledEvent(order *Order, transition OrderStatusTransition, reason string) OrderEvent {
+	event := newOrderEvent(OrderEventCancelled, order, &transition, transition.ChangedAt)
+	event.Reason = reason
+	return event
+}
+
+// NewOrderStatusChangedEvent - событие смены статуса заказа
+func NewOrderStatusChangedEvent(order *Order, transition OrderStatusTransition) OrderEvent {
+	return newOrderEvent(OrderEventStatusChanged, order, &transition, transition.ChangedAt)
+}
+
+// NewOrderPaidEvent - событие оплаты заказа
+func NewOrderPaidEvent(order *Order, transition OrderStatusTransition, payment Payment) OrderEvent {
+	event := newOrderEvent(OrderEventPaid, order, &transition, transition.ChangedAt)
+	event.Payment = &payment
+	return event
+}
+
+// NewOrderPaymentFailedEvent - событие неудачной оплаты заказа
+func NewOrderPaymentFailedEvent(order *Order, transition OrderStatusTransition, payment Payment) OrderEvent {
+	event := newOrderEvent(OrderEventPaymentFailed, order, &transition, transition.ChangedAt)
+	event.Payment = &payment
+	event.Reason = payment.FailureReason
+	return event
+}
+
+// NewOrderItemsCancelledEvent - событие отмены части позиций заказа
+func NewOrderItemsCancelledEvent(order *Order, cancelled []Item, at time.Time) OrderEvent {
+	event := newOrderEvent(OrderEventItemsCancelled, order, nil, at)
+	event.Items = cancelled
+	return event
+}
+
+// NewOrderReturnRequestedEvent - событие оформления возврата
+func NewOrderReturnRequestedEvent(order *Order, ret Return) OrderEvent {
+	event := newOrderEvent(OrderEventReturnRequested, order, nil, ret.CreatedAt)
+	event.Return = &ret
+	event.Reason = ret.Reason
+	return event
+}
+
+// NewOrderReturnApprovedEvent - событие одобрения возврата
+func NewOrderReturnApprovedEvent(order *Order, ret Return) OrderEvent {
+	event := newOrderEvent(OrderEventReturnApproved, order, nil, ret.UpdatedAt)
+	event.Return = &ret
+	event.Reason = ret.Reason
+	return event
+}
+
+// newOrderEvent - следующее событие потока заказа order: снимок заказа - уже с версией после события
+func newOrderEvent(typ OrderEventType, order *Order, transition *OrderStatusTransition, at time.Time) OrderEvent {
+	snapshot := *order
+	snapshot.Version = order.Version + 1
+
+	return OrderEvent{
+		ID:            uuid.New(),
+		Type:          typ,
+		StreamVersion: snapshot.Version,
+		Version:       OrderEventVersion,
+		OccurredAt:    at,
+		Order:         snapshot,
+		Transition:    transition,
+	}
+}
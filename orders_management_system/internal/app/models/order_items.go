@@ -0,0 +1,155 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// OrderItemChangeReason - причина изменения позиции заказа
+type OrderItemChangeReason string
+
+const (
+	OrderItemCreated   OrderItemChangeReason = "created"   // позиция заказана при создании заказа
+	OrderItemCancelled OrderItemChangeReason = "cancelled" // покупатель отменил часть позиции
+)
+
+// OrderItemChange - запись истории позиций заказа (SKU на складе). История только дополняется:
+// исходный состав заказа - записи OrderItemCreated, текущий - Order.Items
+type OrderItemChange struct {
+	OrderID      OrderID               // ID заказа
+	SKUID        SKUID                 // SKU
+	WarehouseID  WarehouseID           // Склад позиции
+	Price        Money                 // Цена за единицу на момент заказа
+	FromQuantity uint32                // Количество до изменения (0 при создании заказа)
+	ToQuantity   uint32                // Количество после изменения (0 - позиция отменена целиком)
+	Reason       OrderItemChangeReason // Причина изменения
+	ChangedAt    time.Time             // Время изменения
+}
+
+// NewOrderItemsCreated - записи истории с исходным составом заказа
+func NewOrderItemsCreated(order *Order, at time.Time) []OrderItemChange {
+	changes := make([]OrderItemChange, 0, len(order.Items))
+	for _, item := range order.Items {
+		changes = append(changes, OrderItemChange{
+			OrderID:     order.ID,
+			SKUID:       item.SKU.ID,
+			WarehouseID: item.WarehouseID,
+			Price:       item.Price,
+			ToQuantity:  item.Quantity,
+			Reason:      OrderItemCreated,
+			ChangedAt:   at,
+		})
+	}
+	return changes
+}
+
+// CancelItems - отмена части позиций заказа: количество SKU из requested снимается с позиций заказа
+// (если в requested указан склад - только с позиций этого склада). Стоимость заказа пересчитывается,
+// скидка за отмененные товары снимается так же, как при возврате (см. RefundAmount).
+// Отменить все позиции нельзя - для этого заказ отменяется целиком.
+// Возвращает отмененные позиции и записи истории позиций
+//
+// @errors: ErrInvalidItemsCancellation, ErrCurrencyMismatch, ErrInvalidArgument
+func (o *Order) CancelItems(requested []Item, at time.Time) ([]Item, []OrderItemChange, error) {
+	items := make([]Item, len(o.Items))
+	copy(items, o.Items)
+
+	var cancelled []Item
+	for _, req := range requested {
+		if req.Quantity == 0 {
+			return nil, nil, fmt.Errorf("%w: sku %d: zero quantity", ErrInvalidItemsCancellation, req.SKU.ID)
+		}
+		left := req.Quantity
+		for i := range items {
+			if left == 0 {
+				break
+			}
+			if items[i].SKU.ID != req.SKU.ID || items[i].Quantity == 0 ||
+				(req.WarehouseID != 0 && items[i].WarehouseID != req.WarehouseID) {
+				continue
+			}
+			quantity := min(items[i].Quantity, left)
+			items[i].Quantity -= quantity
+			left -= quantity
+
+			item := items[i]
+			item.Quantity = quantity
+			cancelled = append(cancelled, item)
+		}
+		if left > 0 {
+			return nil, nil, fmt.Errorf("%w: sku %d: %d more than ordered", ErrInvalidItemsCancellation, req.SKU.ID, left)
+		}
+	}
+
+	var (
+		remaining []Item
+		changes   []OrderItemChange
+	)
+	for i, item := range items {
+		if item.Quantity > 0 {
+			remaining = append(remaining, item)
+		}
+		if item.Quantity == o.Items[i].Quantity {
+			continue
+		}
+		changes = append(changes, OrderItemChange{
+			OrderID:      o.ID,
+			SKUID:        item.SKU.ID,
+			WarehouseID:  item.WarehouseID,
+			Price:        item.Price,
+			FromQuantity: o.Items[i].Quantity,
+			ToQuantity:   item.Quantity,
+			Reason:       OrderItemCancelled,
+			ChangedAt:    at,
+		})
+	}
+	if len(remaining) == 0 {
+		return nil, nil, fmt.Errorf("%w: all items are cancelled, cancel the order", ErrInvalidItemsCancellation)
+	}
+
+	totals, err := totalsWithoutItems(o.Totals, cancelled)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	o.Items = remaining
+	o.Totals = totals
+
+	return cancelled, changes, nil
+}
+
+// totalsWithoutItems - стоимость заказа без позиций items: к оплате меньше на сумму возврата
+// за эти позиции (RefundAmount), разница с их стоимостью - снятая часть скидки
+func totalsWithoutItems(totals OrderTotals, items []Item) (OrderTotals, error) {
+	refund, err := RefundAmount(totals, items)
+	if err != nil {
+		return OrderTotals{}, err
+	}
+
+	var amount Money
+	for _, item := range items {
+		line, err := item.Price.Mul(item.Quantity)
+		if err != nil {
+			return OrderTotals{}, err
+		}
+		if amount, err = amount.Add(line); err != nil {
+			return OrderTotals{}, err
+		}
+	}
+	discountShare, err := amount.Sub(refund)
+	if err != nil {
+		return OrderTotals{}, err
+	}
+
+	res := totals
+	if res.Subtotal, err = totals.Subtotal.Sub(amount); err != nil {
+		return OrderTotals{}, err
+	}
+	if res.Discount, err = totals.Discount.Sub(discountShare); err != nil {
+		return OrderTotals{}, err
+	}
+	if res.Total, err = totals.Total.Sub(refund); err != nil {
+		return OrderTotals{}, err
+	}
+	return res, nil
+}
@@ -0,0 +1,103 @@
+//go:build test
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_CancelItems(t *testing.T) {
+	var (
+		now   = time.Now()
+		rub   = func(amount int64) Money { return NewMoney(amount, CurrencyRUB) }
+		order = func() *Order {
+			return &Order{
+				Items: []Item{
+					{SKU: SKU{ID: 1}, Quantity: 3, WarehouseID: 10, Price: rub(1000)},
+					{SKU: SKU{ID: 1}, Quantity: 2, WarehouseID: 20, Price: rub(1000)},
+					{SKU: SKU{ID: 2}, Quantity: 1, WarehouseID: 10, Price: rub(500)},
+				},
+				Totals: OrderTotals{Subtotal: rub(5500), Discount: rub(600), Delivery: rub(500), Total: rub(5400)},
+			}
+		}
+	)
+
+	tests := []struct {
+		name          string
+		requested     []Item
+		wantCancelled []Item
+		wantItems     []Item
+		wantTotals    OrderTotals
+		wantChanges   []OrderItemChange
+		wantErr       error
+	}{
+		{
+			name:      "Test 1. Positive. Quantity is taken from order lines in order, discount share is removed.",
+			requested: []Item{{SKU: SKU{ID: 1}, Quantity: 4}},
+			wantCancelled: []Item{
+				{SKU: SKU{ID: 1}, Quantity: 3, WarehouseID: 10, Price: rub(1000)},
+				{SKU: SKU{ID: 1}, Quantity: 1, WarehouseID: 20, Price: rub(1000)},
+			},
+			wantItems: []Item{
+				{SKU: SKU{ID: 1}, Quantity: 1, WarehouseID: 20, Price: rub(1000)},
+				{SKU: SKU{ID: 2}, Quantity: 1, WarehouseID: 10, Price: rub(500)},
+			},
+			// доля скидки: 600 * 4000 / 6000 = 400, к оплате меньше на 3600
+			wantTotals: OrderTotals{Subtotal: rub(1500), Discount: rub(200), Delivery: rub(500), Total: rub(1800)},
+			wantChanges: []OrderItemChange{
+				{SKUID: 1, WarehouseID: 10, Price: rub(1000), FromQuantity: 3, ToQuantity: 0, Reason: OrderItemCancelled, ChangedAt: now},
+				{SKUID: 1, WarehouseID: 20, Price: rub(1000), FromQuantity: 2, ToQuantity: 1, Reason: OrderItemCancelled, ChangedAt: now},
+			},
+		},
+		{
+			name:          "Test 2. Positive. Only from requested warehouse.",
+			requested:     []Item{{SKU: SKU{ID: 1}, Quantity: 1, WarehouseID: 20}},
+			wantCancelled: []Item{{SKU: SKU{ID: 1}, Quantity: 1, WarehouseID: 20, Price: rub(1000)}},
+			wantItems: []Item{
+				{SKU: SKU{ID: 1}, Quantity: 3, WarehouseID: 10, Price: rub(1000)},
+				{SKU: SKU{ID: 1}, Quantity: 1, WarehouseID: 20, Price: rub(1000)},
+				{SKU: SKU{ID: 2}, Quantity: 1, WarehouseID: 10, Price: rub(500)},
+			},
+			wantTotals: OrderTotals{Subtotal: rub(4500), Discount: rub(500), Delivery: rub(500), Total: rub(4500)},
+			wantChanges: []OrderItemChange{
+				{SKUID: 1, WarehouseID: 20, Price: rub(1000), FromQuantity: 2, ToQuantity: 1, Reason: OrderItemCancelled, ChangedAt: now},
+			},
+		},
+		{
+			name:      "Test 3. Negative. More than ordered on warehouse.",
+			requested: []Item{{SKU: SKU{ID: 1}, Quantity: 3, WarehouseID: 20}},
+			wantErr:   ErrInvalidItemsCancellation,
+		},
+		{
+			name:      "Test 4. Negative. All items.",
+			requested: []Item{{SKU: SKU{ID: 1}, Quantity: 5}, {SKU: SKU{ID: 2}, Quantity: 1}},
+			wantErr:   ErrInvalidItemsCancellation,
+		},
+		{
+			name:      "Test 5. Negative. Zero quantity.",
+			requested: []Item{{SKU: SKU{ID: 2}}},
+			wantErr:   ErrInvalidItemsCancellation,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := order()
+			cancelled, changes, err := o.CancelItems(tt.requested, now)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				// заказ не меняется
+				assert.Equal(t, order(), o)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantCancelled, cancelled)
+			assert.Equal(t, tt.wantChanges, changes)
+			assert.Equal(t, tt.wantItems, o.Items)
+			assert.Equal(t, tt.wantTotals, o.Totals)
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// OrderStatus - статус заказа
+type OrderStatus string
+
+// Статусы заказа
+const (
+	OrderStatusNew             OrderStatus = "new"              // Заказ создан
+	OrderStatusReserved        OrderStatus = "reserved"         // Стоки зарезервированы на складах
+	OrderStatusAwaitingPayment OrderStatus = "awaiting_payment" // Ожидает оплаты
+	OrderStatusPaymentFailed   OrderStatus = "payment_failed"   // Оплата не прошла (можно оплатить повторно)
+	OrderStatusPaid            OrderStatus = "paid"             // Оплачен
+	OrderStatusShipped         OrderStatus = "shipped"          // Передан в доставку
+	OrderStatusDelivered       OrderStatus = "delivered"        // Доставлен
+	OrderStatusCancelled       OrderStatus = "cancelled"        // Отменен
+	OrderStatusFailed          OrderStatus = "failed"           // Не удалось оформить
+)
+
+// orderStatusTransitions - таблица допустимых переходов между статусами заказа
+var orderStatusTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusNew:             {OrderStatusReserved, OrderStatusCancelled, OrderStatusFailed},
+	OrderStatusReserved:        {OrderStatusAwaitingPayment, OrderStatusCancelled, OrderStatusFailed},
+	OrderStatusAwaitingPayment: {OrderStatusPaid, OrderStatusPaymentFailed, OrderStatusCancelled, OrderStatusFailed},
+	OrderStatusPaymentFailed:   {OrderStatusAwaitingPayment, OrderStatusCancelled, OrderStatusFailed},
+	OrderStatusPaid:            {OrderStatusShipped, OrderStatusCancelled},
+	OrderStatusShipped:         {OrderStatusDelivered},
+	OrderStatusDelivered:       {},
+	OrderStatusCancelled:       {},
+	OrderStatusFailed:          {},
+}
+
+// IsValid - известен ли статус
+func (s OrderStatus) IsValid() bool {
+	_, ok := orderStatusTransitions[s]
+	return ok
+}
+
+// IsFinal - конечный ли статус (из него нет переходов)
+func (s OrderStatus) IsFinal() bool {
+	return len(orderStatusTransitions[s]) == 0
+}
+
+// CanTransitionTo - допустим ли переход из статуса s в статус to
+func (s OrderStatus) CanTransitionTo(to OrderStatus) bool {
+	for _, next := range orderStatusTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderStatusTransition - запись о смене статуса заказа
+type OrderStatusTransition struct {
+	OrderID   OrderID     // ID заказа
+	From      OrderStatus // Предыдущий статус (пустой при создании заказа)
+	To        OrderStatus // Новый статус
+	ChangedAt time.Time   // Время смены статуса
+}
+
+// SetStatus - переводит заказ в статус to, если переход допустим
+//
+// @errors: ErrInvalidStatusTransition
+func (o *Order) SetStatus(to OrderStatus, at time.Time) (*OrderStatusTransition, error) {
+	if !o.Status.CanTransitionTo(to) {
+		return nil, fmt.Errorf("%s -> %s: %w", o.Status, to, ErrInvalidStatusTransition)
+	}
+
+	transition := &OrderStatusTransition{
+		OrderID:   o.ID,
+		From:      o.Status,
+		To:        to,
+		ChangedAt: at,
+	}
+
+	o.Status = to
+	o.StatusChangedAt = at
+
+	return transition, nil
+}
@@ -0,0 +1,49 @@
+//go:build test
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_SetStatus(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		from    OrderStatus
+		to      OrderStatus
+		wantErr error
+	}{
+		{name: "Test 1. Positive. new -> reserved.", from: OrderStatusNew, to: OrderStatusReserved},
+		{name: "Test 2. Positive. paid -> cancelled.", from: OrderStatusPaid, to: OrderStatusCancelled},
+		{name: "Test 3. Positive. shipped -> delivered.", from: OrderStatusShipped, to: OrderStatusDelivered},
+		{name: "Test 4. Negative. shipped -> cancelled.", from: OrderStatusShipped, to: OrderStatusCancelled, wantErr: ErrInvalidStatusTransition},
+		{name: "Test 5. Negative. new -> paid.", from: OrderStatusNew, to: OrderStatusPaid, wantErr: ErrInvalidStatusTransition},
+		{name: "Test 6. Negative. cancelled is final.", from: OrderStatusCancelled, to: OrderStatusNew, wantErr: ErrInvalidStatusTransition},
+		{name: "Test 7. Positive. payment_failed -> awaiting_payment (retry).", from: OrderStatusPaymentFailed, to: OrderStatusAwaitingPayment},
+		{name: "Test 8. Negative. payment_failed -> paid.", from: OrderStatusPaymentFailed, to: OrderStatusPaid, wantErr: ErrInvalidStatusTransition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := &Order{Status: tt.from}
+
+			transition, err := order.SetStatus(tt.to, now)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Nil(t, transition)
+				assert.Equal(t, tt.from, order.Status)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, &OrderStatusTransition{From: tt.from, To: tt.to, ChangedAt: now}, transition)
+			assert.Equal(t, tt.to, order.Status)
+			assert.Equal(t, now, order.StatusChangedAt)
+		})
+	}
+}
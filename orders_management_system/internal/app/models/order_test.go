@@ -0,0 +1,21 @@
+//go:build test
+
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUniqueSKUs(t *testing.T) {
+	// одна SKU может прийти с разных складов: запрос цен и стоков - по SKU один раз
+	items := []Item{
+		{SKU: SKU{ID: 2}, Quantity: 1, WarehouseID: 10},
+		{SKU: SKU{ID: 1}, Quantity: 2, WarehouseID: 10},
+		{SKU: SKU{ID: 2}, Quantity: 3, WarehouseID: 20},
+	}
+
+	assert.Equal(t, []SKUID{2, 1}, UniqueSKUs(items))
+	assert.Empty(t, UniqueSKUs(nil))
+}
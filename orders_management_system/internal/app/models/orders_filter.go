@@ -0,0 +1,19 @@
+package models
+
+import "time"
+
+// OrdersCursor - позиция в списке заказов для keyset пагинации (created_at, id)
+type OrdersCursor struct {
+	CreatedAt time.Time
+	ID        OrderID
+}
+
+// OrdersFilter - фильтр списка заказов пользователя
+type OrdersFilter struct {
+	UserID           UserID        // ID пользователя (чьи заказы)
+	Statuses         []OrderStatus // Статусы заказов, пустой - любой статус
+	DeliveryDateFrom time.Time     // Срок доставки не раньше (включительно), zero - без ограничения
+	DeliveryDateTo   time.Time     // Срок доставки не позже (включительно), zero - без ограничения
+	After            *OrdersCursor // Заказы строго после курсора, nil - с начала списка
+	Limit            uint64        // Максимальное количество заказов
+}
@@ -0,0 +1,9 @@
+package models
+
+// OutboxMessage - сообщение из outbox таблицы, которое нужно опубликовать
+type OutboxMessage struct {
+	ID        int64          // ID сообщения (порядок публикации)
+	OrderID   OrderID        // ID заказа
+	EventType OrderEventType // Тип события из журнала order_events (пустой у сообщений, записанных до появления событий)
+	Payload   []byte         // Сериализованный конверт события (pb.EventEnvelope)
+}
@@ -0,0 +1,134 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PaymentID - UUID платежа (передается провайдеру как ссылка на платеж OMS)
+type PaymentID uuid.UUID
+
+// String - represent PaymentID as string
+func (v PaymentID) String() string {
+	return uuid.UUID(v).String()
+}
+
+// PaymentStatus - статус платежа
+type PaymentStatus string
+
+// Статусы платежа
+const (
+	PaymentStatusPending    PaymentStatus = "pending"    // Ждем от провайдера результат авторизации
+	PaymentStatusAuthorized PaymentStatus = "authorized" // Деньги заблокированы на счете покупателя
+	PaymentStatusCaptured   PaymentStatus = "captured"   // Деньги списаны
+	PaymentStatusFailed     PaymentStatus = "failed"     // Авторизация не прошла
+	PaymentStatusVoided     PaymentStatus = "voided"     // Авторизация отменена, деньги разблокированы
+	PaymentStatusRefunded   PaymentStatus = "refunded"   // Списанные деньги возвращены
+)
+
+// paymentStatusTransitions - таблица допустимых переходов между статусами платежа
+var paymentStatusTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentStatusPending:    {PaymentStatusAuthorized, PaymentStatusFailed, PaymentStatusVoided},
+	PaymentStatusAuthorized: {PaymentStatusCaptured, PaymentStatusVoided},
+	PaymentStatusCaptured:   {PaymentStatusRefunded},
+	PaymentStatusFailed:     {},
+	PaymentStatusVoided:     {},
+	PaymentStatusRefunded:   {},
+}
+
+// IsFinal - конечный ли статус платежа
+func (s PaymentStatus) IsFinal() bool {
+	return len(paymentStatusTransitions[s]) == 0
+}
+
+// CanTransitionTo - допустим ли переход из статуса s в статус to
+func (s PaymentStatus) CanTransitionTo(to PaymentStatus) bool {
+	for _, next := range paymentStatusTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Payment - платеж по заказу. У заказа может быть несколько платежей (повтор после неудачной оплаты),
+// но не больше одного незавершенного
+type Payment struct {
+	ID                PaymentID     // ID платежа
+	OrderID           OrderID       // ID заказа
+	Amount            Money         // Сумма платежа (Order.Totals.Total на момент оплаты)
+	Refunded          Money         // Сколько из списанного уже возвращено
+	Method            string        // Способ оплаты (токен карты, кошелек и т.п.)
+	Status            PaymentStatus // Статус платежа
+	ProviderPaymentID string        // ID платежа у провайдера (пустой - провайдер еще не ответил)
+	FailureReason     string        // Причина отказа в авторизации
+	CreatedAt         time.Time     // Время создания платежа
+	UpdatedAt         time.Time     // Время последней смены статуса
+}
+
+// SetStatus - переводит платеж в статус to, если переход допустим
+//
+// @errors: ErrInvalidStatusTransition
+func (p *Payment) SetStatus(to PaymentStatus, at time.Time) error {
+	if !p.Status.CanTransitionTo(to) {
+		return fmt.Errorf("payment %s -> %s: %w", p.Status, to, ErrInvalidStatusTransition)
+	}
+
+	p.Status = to
+	p.UpdatedAt = at
+
+	return nil
+}
+
+// Refund - возврат amount из списанных денег. Платеж переходит в refunded, когда возвращено все
+//
+// @errors: ErrInvalidStatusTransition, ErrInvalidArgument, ErrCurrencyMismatch
+func (p *Payment) Refund(amount Money, at time.Time) error {
+	if p.Status != PaymentStatusCaptured {
+		return fmt.Errorf("refund %s payment: %w", p.Status, ErrInvalidStatusTransition)
+	}
+
+	refunded, err := p.Refunded.Add(amount)
+	if err != nil {
+		return err
+	}
+	if amount.Amount <= 0 || refunded.Amount > p.Amount.Amount {
+		return fmt.Errorf("%w: refund %s of %s (already refunded %s)", ErrInvalidArgument, amount, p.Amount, p.Refunded)
+	}
+
+	p.Refunded = refunded
+	p.UpdatedAt = at
+	if refunded.Amount == p.Amount.Amount {
+		p.Status = PaymentStatusRefunded
+	}
+
+	return nil
+}
+
+// Refundable - сколько из списанных денег еще можно вернуть
+func (p *Payment) Refundable() Money {
+	if p.Status != PaymentStatusCaptured {
+		return Money{}
+	}
+	return NewMoney(p.Amount.Amount-p.Refunded.Amount, p.Amount.Currency)
+}
+
+// PaymentResult - результат авторизации платежа
+type PaymentResult string
+
+const (
+	PaymentResultAuthorized PaymentResult = "authorized" // деньги заблокированы
+	PaymentResultFailed     PaymentResult = "failed"     // отказ
+)
+
+// PaymentCallback - уведомление провайдера о результате авторизации платежа (webhook).
+// Провайдер повторяет уведомление, пока OMS не ответит успехом: обработка идемпотентна
+type PaymentCallback struct {
+	PaymentID         PaymentID     // ID платежа OMS, переданный провайдеру при авторизации
+	ProviderPaymentID string        // ID платежа у провайдера
+	Result            PaymentResult // Результат авторизации
+	FailureReason     string        // Причина отказа (для PaymentResultFailed)
+	Signature         string        // Подпись уведомления провайдером: без нее уведомление не принимается
+}
@@ -0,0 +1,67 @@
+//go:build test
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayment_SetStatus(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name    string
+		from    PaymentStatus
+		to      PaymentStatus
+		wantErr error
+	}{
+		{name: "Test 1. Positive. pending -> authorized.", from: PaymentStatusPending, to: PaymentStatusAuthorized},
+		{name: "Test 2. Positive. authorized -> captured.", from: PaymentStatusAuthorized, to: PaymentStatusCaptured},
+		{name: "Test 3. Positive. captured -> refunded.", from: PaymentStatusCaptured, to: PaymentStatusRefunded},
+		{name: "Test 4. Negative. pending -> captured.", from: PaymentStatusPending, to: PaymentStatusCaptured, wantErr: ErrInvalidStatusTransition},
+		{name: "Test 5. Negative. captured -> voided.", from: PaymentStatusCaptured, to: PaymentStatusVoided, wantErr: ErrInvalidStatusTransition},
+		{name: "Test 6. Negative. failed is final.", from: PaymentStatusFailed, to: PaymentStatusAuthorized, wantErr: ErrInvalidStatusTransition},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payment := &Payment{Status: tt.from}
+
+			err := payment.SetStatus(tt.to, now)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				assert.Equal(t, tt.from, payment.Status)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, payment.Status)
+			assert.Equal(t, now, payment.UpdatedAt)
+		})
+	}
+}
+
+func TestPayment_Refund(t *testing.T) {
+	var (
+		now     = time.Now()
+		rub     = func(amount int64) Money { return NewMoney(amount, CurrencyRUB) }
+		payment = &Payment{Amount: rub(1000), Status: PaymentStatusCaptured}
+	)
+
+	assert.NoError(t, payment.Refund(rub(400), now))
+	assert.Equal(t, rub(600), payment.Refundable())
+	assert.Equal(t, PaymentStatusCaptured, payment.Status)
+
+	err := payment.Refund(rub(700), now)
+	assert.True(t, errors.Is(err, ErrInvalidArgument), "got error: %v", err)
+
+	assert.NoError(t, payment.Refund(rub(600), now))
+	assert.Equal(t, PaymentStatusRefunded, payment.Status)
+	assert.True(t, payment.Refundable().IsZero())
+
+	err = payment.Refund(rub(1), now)
+	assert.True(t, errors.Is(err, ErrInvalidStatusTransition), "got error: %v", err)
+}
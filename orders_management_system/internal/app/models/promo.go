@@ -0,0 +1,111 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// DiscountType - тип правила скидки
+type DiscountType string
+
+const (
+	DiscountPercentOff   DiscountType = "percent_off"   // процент от суммы товаров
+	DiscountFixedOff     DiscountType = "fixed_off"     // фиксированная сумма от суммы товаров
+	DiscountFreeDelivery DiscountType = "free_delivery" // бесплатная доставка
+	DiscountNForM        DiscountType = "n_for_m"       // N единиц SKU по цене M
+)
+
+// DiscountRule - правило скидки промокода
+type DiscountRule struct {
+	Type        DiscountType
+	PercentOff  uint32 // percent_off: процент скидки (1-100)
+	AmountOff   Money  // fixed_off: сумма скидки
+	SKUID       SKUID  // n_for_m: SKU, на который действует акция
+	BuyQuantity uint32 // n_for_m: N - сколько единиц берешь
+	PayQuantity uint32 // n_for_m: M - за сколько платишь (M < N)
+}
+
+// Discount - скидка по правилу на позиции items (с ценами) и доставку delivery.
+// Скидка на товары не больше суммы товаров
+//
+// @errors: ErrCurrencyMismatch, ErrInvalidArgument
+func (r DiscountRule) Discount(items []Item, delivery Money) (Money, error) {
+	totals, err := CalculateTotals(items, delivery, Money{})
+	if err != nil {
+		return Money{}, err
+	}
+	subtotal := totals.Subtotal
+
+	switch r.Type {
+	case DiscountPercentOff:
+		percent := int64(min(r.PercentOff, 100))
+		// без переполнения: subtotal * percent / 100
+		amount := subtotal.Amount/100*percent + subtotal.Amount%100*percent/100
+		return NewMoney(amount, subtotal.Currency), nil
+	case DiscountFixedOff:
+		if _, err := subtotal.Sub(r.AmountOff); err != nil {
+			return Money{}, err
+		}
+		return NewMoney(min(r.AmountOff.Amount, subtotal.Amount), subtotal.Currency), nil
+	case DiscountFreeDelivery:
+		return delivery, nil
+	case DiscountNForM:
+		if r.BuyQuantity == 0 || r.PayQuantity >= r.BuyQuantity {
+			return Money{}, fmt.Errorf("%w: n_for_m rule %d for %d", ErrInvalidArgument, r.BuyQuantity, r.PayQuantity)
+		}
+		var (
+			quantity uint32
+			price    Money
+		)
+		for _, item := range items {
+			if item.SKU.ID == r.SKUID {
+				quantity += item.Quantity
+				price = item.Price
+			}
+		}
+		return price.Mul(quantity / r.BuyQuantity * (r.BuyQuantity - r.PayQuantity))
+	default:
+		return Money{}, fmt.Errorf("%w: unknown discount type %q", ErrInvalidArgument, r.Type)
+	}
+}
+
+// PromoCode - промокод
+type PromoCode struct {
+	Code         string
+	Rule         DiscountRule // правило скидки
+	PerUserLimit uint32       // сколько раз один пользователь может применить промокод (0 - без ограничения)
+	GlobalLimit  uint32       // сколько раз промокод можно применить всего (0 - без ограничения)
+	Redemptions  uint32       // сколько раз промокод уже применен
+	ValidFrom    time.Time    // начало действия (zero - без ограничения)
+	ValidTo      time.Time    // окончание действия (zero - без ограничения)
+	Active       bool         // выключенный промокод не применяется
+}
+
+// Check - можно ли применить промокод в момент now пользователю,
+// который уже применил его userRedemptions раз
+//
+// @errors: ErrInvalidPromoCode, ErrPromoCodeExhausted
+func (p *PromoCode) Check(userRedemptions uint32, now time.Time) error {
+	switch {
+	case !p.Active:
+		return fmt.Errorf("%w: %q is disabled", ErrInvalidPromoCode, p.Code)
+	case !p.ValidFrom.IsZero() && now.Before(p.ValidFrom):
+		return fmt.Errorf("%w: %q is not active yet", ErrInvalidPromoCode, p.Code)
+	case !p.ValidTo.IsZero() && !now.Before(p.ValidTo):
+		return fmt.Errorf("%w: %q has expired", ErrInvalidPromoCode, p.Code)
+	case p.GlobalLimit > 0 && p.Redemptions >= p.GlobalLimit:
+		return fmt.Errorf("%w: %q", ErrPromoCodeExhausted, p.Code)
+	case p.PerUserLimit > 0 && userRedemptions >= p.PerUserLimit:
+		return fmt.Errorf("%w: %q already used %d times", ErrPromoCodeExhausted, p.Code, userRedemptions)
+	}
+	return nil
+}
+
+// PromoRedemption - применение промокода к заказу
+type PromoRedemption struct {
+	Code       string
+	UserID     UserID
+	OrderID    OrderID
+	Discount   Money
+	RedeemedAt time.Time
+}
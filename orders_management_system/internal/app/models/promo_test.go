@@ -0,0 +1,94 @@
+//go:build test
+
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscountRule_Discount(t *testing.T) {
+	var (
+		rub   = func(amount int64) Money { return NewMoney(amount, CurrencyRUB) }
+		items = []Item{
+			{SKU: SKU{ID: 1}, Quantity: 5, Price: rub(1000)},
+			{SKU: SKU{ID: 2}, Quantity: 1, Price: rub(555)},
+		}
+		delivery = rub(300)
+	)
+
+	tests := []struct {
+		name    string
+		rule    DiscountRule
+		want    Money
+		wantErr error
+	}{
+		{
+			name: "Test 1. Positive. Percent off is rounded down.",
+			rule: DiscountRule{Type: DiscountPercentOff, PercentOff: 10},
+			want: rub(555),
+		},
+		{
+			name: "Test 2. Positive. Fixed off is capped by subtotal.",
+			rule: DiscountRule{Type: DiscountFixedOff, AmountOff: rub(100000)},
+			want: rub(5555),
+		},
+		{
+			name: "Test 3. Positive. Free delivery.",
+			rule: DiscountRule{Type: DiscountFreeDelivery},
+			want: delivery,
+		},
+		{
+			name: "Test 4. Positive. 2 for 1 on SKU.",
+			rule: DiscountRule{Type: DiscountNForM, SKUID: 1, BuyQuantity: 2, PayQuantity: 1},
+			want: rub(2000),
+		},
+		{
+			name:    "Test 5. Negative. Fixed off in another currency.",
+			rule:    DiscountRule{Type: DiscountFixedOff, AmountOff: NewMoney(100, "USD")},
+			wantErr: ErrCurrencyMismatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.rule.Discount(items, delivery)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestPromoCode_Check(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name            string
+		promo           PromoCode
+		userRedemptions uint32
+		wantErr         error
+	}{
+		{name: "Test 1. Positive. No limits.", promo: PromoCode{Active: true}},
+		{name: "Test 2. Positive. Limits not reached.", promo: PromoCode{Active: true, PerUserLimit: 2, GlobalLimit: 10, Redemptions: 9}, userRedemptions: 1},
+		{name: "Test 3. Negative. Disabled.", promo: PromoCode{}, wantErr: ErrInvalidPromoCode},
+		{name: "Test 4. Negative. Expired.", promo: PromoCode{Active: true, ValidTo: now}, wantErr: ErrInvalidPromoCode},
+		{name: "Test 5. Negative. Global limit reached.", promo: PromoCode{Active: true, GlobalLimit: 10, Redemptions: 10}, wantErr: ErrPromoCodeExhausted},
+		{name: "Test 6. Negative. Per user limit reached.", promo: PromoCode{Active: true, PerUserLimit: 1}, userRedemptions: 1, wantErr: ErrPromoCodeExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.promo.Check(tt.userRedemptions, now)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ForWarehouse - ID резерва на складе warehouseID. Резерв заказа делается отдельным
+// запросом в WMS на каждый склад; ID выводится из ID резерва заказа детерминированно,
+// чтобы снять резерв можно было по одному ReservationID заказа
+func (v ReservationID) ForWarehouse(warehouseID WarehouseID) ReservationID {
+	var name [8]byte
+	binary.BigEndian.PutUint64(name[:], uint64(warehouseID))
+	return ReservationID(uuid.NewSHA1(uuid.UUID(v), name[:]))
+}
+
+// GroupItemsByWarehouse - позиции заказа по складам (в порядке первого появления склада)
+func GroupItemsByWarehouse(items []Item) ([]WarehouseID, map[WarehouseID][]Item) {
+	var (
+		warehouses []WarehouseID
+		groups     = make(map[WarehouseID][]Item)
+	)
+	for _, item := range items {
+		if _, ok := groups[item.WarehouseID]; !ok {
+			warehouses = append(warehouses, item.WarehouseID)
+		}
+		groups[item.WarehouseID] = append(groups[item.WarehouseID], item)
+	}
+	return warehouses, groups
+}
+
+// ReservationFailure - неудавшийся резерв стоков на складе
+type ReservationFailure struct {
+	WarehouseID WarehouseID
+	SKUs        []SKUID // SKU склада, которые не удалось зарезервировать
+	Err         error
+}
+
+// ReservationError - резерв не удался на части складов (резервы остальных складов сняты).
+// errors.Is проверяет причины по всем складам
+type ReservationError struct {
+	Failures []ReservationFailure
+}
+
+func (e *ReservationError) Error() string {
+	msgs := make([]string, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		msgs = append(msgs, fmt.Sprintf("warehouse %d (skus %v): %v", f.WarehouseID, f.SKUs, f.Err))
+	}
+	return "reserve stocks: " + strings.Join(msgs, "; ")
+}
+
+func (e *ReservationError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+	return errs
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReturnID - UUID заявки на возврат
+type ReturnID uuid.UUID
+
+// String - represent ReturnID as string
+func (v ReturnID) String() string {
+	return uuid.UUID(v).String()
+}
+
+// ReturnStatus - статус заявки на возврат
+type ReturnStatus string
+
+// Статусы заявки на возврат
+const (
+	ReturnStatusRequested ReturnStatus = "requested" // Покупатель оформил возврат
+	ReturnStatusApproved  ReturnStatus = "approved"  // Возврат принят, деньги возвращены
+	ReturnStatusRejected  ReturnStatus = "rejected"  // В возврате отказано
+)
+
+// returnStatusTransitions - таблица допустимых переходов между статусами заявки на возврат
+var returnStatusTransitions = map[ReturnStatus][]ReturnStatus{
+	ReturnStatusRequested: {ReturnStatusApproved, ReturnStatusRejected},
+	ReturnStatusApproved:  {},
+	ReturnStatusRejected:  {},
+}
+
+// CanTransitionTo - допустим ли переход из статуса s в статус to
+func (s ReturnStatus) CanTransitionTo(to ReturnStatus) bool {
+	for _, next := range returnStatusTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// Return - заявка на возврат части товаров доставленного заказа
+type Return struct {
+	ID        ReturnID     // ID заявки
+	OrderID   OrderID      // ID заказа
+	UserID    UserID       // ID пользователя
+	Items     []Item       // Возвращаемые товары: склад и цена из позиций заказа
+	Reason    string       // Причина возврата со слов покупателя
+	Refund    Money        // Сумма к возврату
+	Status    ReturnStatus // Статус заявки
+	CreatedAt time.Time    // Время создания заявки
+	UpdatedAt time.Time    // Время последней смены статуса
+}
+
+// SetStatus - переводит заявку в статус to, если переход допустим
+//
+// @errors: ErrInvalidStatusTransition
+func (r *Return) SetStatus(to ReturnStatus, at time.Time) error {
+	if !r.Status.CanTransitionTo(to) {
+		return fmt.Errorf("return %s -> %s: %w", r.Status, to, ErrInvalidStatusTransition)
+	}
+
+	r.Status = to
+	r.UpdatedAt = at
+
+	return nil
+}
+
+// ReturnItems - позиции заказа order к возврату: количество SKU из requested раскладывается
+// по позициям заказа (склады) с учетом уже оформленных возвратов returns (кроме отклоненных)
+//
+// @errors: ErrInvalidReturn
+func ReturnItems(order *Order, returns []*Return, requested []Item) ([]Item, error) {
+	// сколько каждой позиции заказа уже вернули
+	type key struct {
+		sku       SKUID
+		warehouse WarehouseID
+	}
+	returned := make(map[key]uint32)
+	for _, r := range returns {
+		if r.Status == ReturnStatusRejected {
+			continue
+		}
+		for _, item := range r.Items {
+			returned[key{item.SKU.ID, item.WarehouseID}] += item.Quantity
+		}
+	}
+
+	var items []Item
+	for _, req := range requested {
+		left := req.Quantity
+		for _, item := range order.Items {
+			if left == 0 {
+				break
+			}
+			if item.SKU.ID != req.SKU.ID {
+				continue
+			}
+			k := key{item.SKU.ID, item.WarehouseID}
+			available := item.Quantity - min(item.Quantity, returned[k])
+			if available == 0 {
+				continue
+			}
+			quantity := min(available, left)
+			returned[k] += quantity
+			left -= quantity
+
+			item.Quantity = quantity
+			items = append(items, item)
+		}
+		if left > 0 {
+			return nil, fmt.Errorf("%w: sku %d: %d more than can be returned", ErrInvalidReturn, req.SKU.ID, left)
+		}
+	}
+
+	return items, nil
+}
+
+// RefundAmount - сумма возврата за позиции items заказа с ценами на момент заказа.
+// Скидка заказа распределяется пропорционально стоимости по товарам и доставке,
+// доставка не возвращается: сумма возвратов по всем товарам не больше Totals.Total
+//
+// @errors: ErrCurrencyMismatch, ErrInvalidArgument
+func RefundAmount(totals OrderTotals, items []Item) (Money, error) {
+	var amount Money
+	for _, item := range items {
+		line, err := item.Price.Mul(item.Quantity)
+		if err != nil {
+			return Money{}, err
+		}
+		if amount, err = amount.Add(line); err != nil {
+			return Money{}, err
+		}
+	}
+
+	base, err := totals.Subtotal.Add(totals.Delivery)
+	if err != nil {
+		return Money{}, err
+	}
+	if totals.Discount.IsZero() || base.IsZero() {
+		return amount, nil
+	}
+
+	// доля скидки: discount * amount / base (округляем в пользу покупателя)
+	share := new(big.Int).Mul(big.NewInt(totals.Discount.Amount), big.NewInt(amount.Amount))
+	share.Quo(share, big.NewInt(base.Amount))
+
+	return amount.Sub(NewMoney(share.Int64(), amount.Currency))
+}
@@ -0,0 +1,107 @@
+//go:build test
+
+package models
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReturnItems(t *testing.T) {
+	var (
+		rub   = func(amount int64) Money { return NewMoney(amount, CurrencyRUB) }
+		order = &Order{Items: []Item{
+			{SKU: SKU{ID: 1}, Quantity: 2, WarehouseID: 10, Price: rub(1000)},
+			{SKU: SKU{ID: 1}, Quantity: 3, WarehouseID: 20, Price: rub(1000)},
+			{SKU: SKU{ID: 2}, Quantity: 1, WarehouseID: 10, Price: rub(550)},
+		}}
+	)
+
+	tests := []struct {
+		name      string
+		returns   []*Return
+		requested []Item
+		want      []Item
+		wantErr   error
+	}{
+		{
+			name:      "Test 1. Positive. Quantity is split by warehouses.",
+			requested: []Item{{SKU: SKU{ID: 1}, Quantity: 3}},
+			want: []Item{
+				{SKU: SKU{ID: 1}, Quantity: 2, WarehouseID: 10, Price: rub(1000)},
+				{SKU: SKU{ID: 1}, Quantity: 1, WarehouseID: 20, Price: rub(1000)},
+			},
+		},
+		{
+			name: "Test 2. Positive. Previous returns are subtracted, rejected are not.",
+			returns: []*Return{
+				{Status: ReturnStatusApproved, Items: []Item{{SKU: SKU{ID: 1}, Quantity: 2, WarehouseID: 10}}},
+				{Status: ReturnStatusRejected, Items: []Item{{SKU: SKU{ID: 1}, Quantity: 3, WarehouseID: 20}}},
+			},
+			requested: []Item{{SKU: SKU{ID: 1}, Quantity: 3}},
+			want:      []Item{{SKU: SKU{ID: 1}, Quantity: 3, WarehouseID: 20, Price: rub(1000)}},
+		},
+		{
+			name: "Test 3. Negative. More than left after previous returns.",
+			returns: []*Return{
+				{Status: ReturnStatusRequested, Items: []Item{{SKU: SKU{ID: 2}, Quantity: 1, WarehouseID: 10}}},
+			},
+			requested: []Item{{SKU: SKU{ID: 2}, Quantity: 1}},
+			wantErr:   ErrInvalidReturn,
+		},
+		{
+			name:      "Test 4. Negative. SKU is not in order.",
+			requested: []Item{{SKU: SKU{ID: 3}, Quantity: 1}},
+			wantErr:   ErrInvalidReturn,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReturnItems(order, tt.returns, tt.requested)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestRefundAmount(t *testing.T) {
+	var (
+		rub   = func(amount int64) Money { return NewMoney(amount, CurrencyRUB) }
+		items = []Item{{SKU: SKU{ID: 1}, Quantity: 2, Price: rub(1000)}}
+	)
+
+	tests := []struct {
+		name   string
+		totals OrderTotals
+		want   Money
+	}{
+		{
+			name:   "Test 1. Positive. Without discount - price snapshot.",
+			totals: OrderTotals{Subtotal: rub(5000), Delivery: rub(300), Total: rub(5300)},
+			want:   rub(2000),
+		},
+		{
+			name:   "Test 2. Positive. Discount is spread over goods and delivery.",
+			totals: OrderTotals{Subtotal: rub(3700), Discount: rub(400), Delivery: rub(300), Total: rub(3600)},
+			want:   rub(1800),
+		},
+		{
+			name:   "Test 3. Positive. Discount share is rounded down.",
+			totals: OrderTotals{Subtotal: rub(2000), Discount: rub(100), Delivery: rub(1000), Total: rub(2900)},
+			want:   rub(1934),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RefundAmount(tt.totals, items)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
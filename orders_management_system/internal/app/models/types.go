@@ -10,6 +10,19 @@ func (v OrderID) String() string {
 	return uuid.UUID(v).String()
 }
 
+// ReservationID - UUID резерва стоков на складах
+type ReservationID uuid.UUID
+
+// String - represent ReservationID as string
+func (v ReservationID) String() string {
+	return uuid.UUID(v).String()
+}
+
+// IsZero - пустой ли ID (резерва нет)
+func (v ReservationID) IsZero() bool {
+	return v == ReservationID{}
+}
+
 // UserID - тип id пользователя
 type UserID uint64
 
@@ -0,0 +1,29 @@
+package checkout_storage
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// AddItem - добавление товара в корзину. Если SKU уже в корзине - количество суммируется, склад заменяется
+func (r *CheckoutStorage) AddItem(ctx context.Context, userID models.UserID, item models.Item) error {
+	const api = "checkout_storage.AddItem"
+
+	query := squirrel.Insert(tableBasketName).
+		Columns("user_id", "sku_id", "quantity", "warehouse_id").
+		Values(int64(userID), int64(item.SKU.ID), int32(item.Quantity), int64(item.WarehouseID)).
+		Suffix(`ON CONFLICT (user_id, sku_id) DO UPDATE SET
+			quantity = basket.quantity + EXCLUDED.quantity,
+			warehouse_id = EXCLUDED.warehouse_id,
+			updated_at = now()`).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package checkout_storage
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// DeleteItems - удаление товаров из корзины пользователя.
+// Вызывается в транзакции создания заказа (GetQueryEngine берет транзакцию из ctx)
+func (r *CheckoutStorage) DeleteItems(ctx context.Context, userID models.UserID, items []models.Item) error {
+	const api = "checkout_storage.DeleteItems"
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	skuIDs := make([]int64, len(items))
+	for i := range items {
+		skuIDs[i] = int64(items[i].SKU.ID)
+	}
+
+	query := squirrel.Delete(tableBasketName).
+		Where(squirrel.Eq{
+			"user_id": int64(userID),
+			"sku_id":  skuIDs,
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package checkout_storage
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// GetItems - содержимое корзины пользователя
+func (r *CheckoutStorage) GetItems(ctx context.Context, userID models.UserID) ([]models.BasketItem, error) {
+	const api = "checkout_storage.GetItems"
+
+	items, err := r.selectItems(ctx, basketItemsQuery(userID))
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return items, nil
+}
+
+// LockItems - содержимое корзины пользователя с блокировкой строк до конца транзакции
+func (r *CheckoutStorage) LockItems(ctx context.Context, userID models.UserID) ([]models.BasketItem, error) {
+	const api = "checkout_storage.LockItems"
+
+	items, err := r.selectItems(ctx, basketItemsQuery(userID).Suffix("FOR UPDATE"))
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return items, nil
+}
+
+func basketItemsQuery(userID models.UserID) squirrel.SelectBuilder {
+	return squirrel.Select(basketColumns...).
+		From(tableBasketName).
+		Where(squirrel.Eq{"user_id": int64(userID)}).
+		OrderBy("created_at", "sku_id").
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+func (r *CheckoutStorage) selectItems(ctx context.Context, query squirrel.SelectBuilder) ([]models.BasketItem, error) {
+	var rows []basketRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, err
+	}
+
+	items := make([]models.BasketItem, len(rows))
+	for i := range rows {
+		items[i] = rows[i].ToModelsBasketItem()
+	}
+
+	return items, nil
+}
@@ -0,0 +1,35 @@
+package checkout_storage
+
+import (
+	"time"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
+
+// колонки таблицы basket, которые читаем в basketRow
+var basketColumns = []string{
+	"user_id",
+	"sku_id",
+	"quantity",
+	"warehouse_id",
+	"updated_at",
+}
+
+type basketRow struct {
+	UserID      int64     `db:"user_id"`
+	SKUID       int64     `db:"sku_id"`
+	Quantity    int32     `db:"quantity"`
+	WarehouseID int64     `db:"warehouse_id"`
+	UpdatedAt   time.Time `db:"updated_at"`
+}
+
+func (r *basketRow) ToModelsBasketItem() models.BasketItem {
+	return models.BasketItem{
+		Item: models.Item{
+			SKU:         models.SKU{ID: models.SKUID(r.SKUID)},
+			Quantity:    uint32(r.Quantity),
+			WarehouseID: models.WarehouseID(r.WarehouseID),
+		},
+		UpdatedAt: r.UpdatedAt,
+	}
+}
@@ -0,0 +1,27 @@
+package checkout_storage
+
+import (
+	transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	oms "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+)
+
+// Check that we implemet contract for usecase
+var (
+	_ oms.CheckoutStorage = (*CheckoutStorage)(nil)
+)
+
+// CheckoutStorage - корзина пользователя
+type CheckoutStorage struct {
+	driver transaction_manager.QueryEngineProvider
+}
+
+// New - returns CheckoutStorage
+func New(driver transaction_manager.QueryEngineProvider) *CheckoutStorage {
+	return &CheckoutStorage{
+		driver: driver,
+	}
+}
+
+const (
+	tableBasketName = "basket"
+)
@@ -0,0 +1,34 @@
+package checkout_storage
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// UpdateItem - изменение количества и склада товара, который уже лежит в корзине
+func (r *CheckoutStorage) UpdateItem(ctx context.Context, userID models.UserID, item models.Item) error {
+	const api = "checkout_storage.UpdateItem"
+
+	query := squirrel.Update(tableBasketName).
+		Set("quantity", int32(item.Quantity)).
+		Set("warehouse_id", int64(item.WarehouseID)).
+		Set("updated_at", squirrel.Expr("now()")).
+		Where(squirrel.Eq{
+			"user_id": int64(userID),
+			"sku_id":  int64(item.SKU.ID),
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	tag, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query)
+	if err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pkgerrors.Wrap(api, models.ErrNotFound)
+	}
+
+	return nil
+}
@@ -5,8 +5,8 @@ import (
 	"errors"
 
 	"github.com/Masterminds/squirrel"
-	"github.com/jackc/pgconn"
 	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
 	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
 )
@@ -14,17 +14,23 @@ import (
 func (r *OrdersStorage) CreateOrder(ctx context.Context, order *models.Order) error {
 	const api = "orders_storage.CreateOrder"
 
-	row, err := newOrderRowFromModelsOrder(order)
-	if err != nil {
-		return pkgerrors.Wrap(api, err)
-	}
+	row := newOrderRowFromModelsOrder(order)
 
 	columns := []string{
 		"id",                  // uuid
 		"user_id",             // int8
-		"items",               // json
 		"delivery_variant_id", // int8
 		"delivery_date",       // int8
+		"currency",            // text
+		"subtotal_amount",     // int8
+		"discount_amount",     // int8
+		"delivery_amount",     // int8
+		"total_amount",        // int8
+		"promo_code",          // text
+		"status",              // text
+		"reservation_id",      // uuid
+		"status_changed_at",   // timestamptz
+		"created_at",          // timestamptz
 	}
 
 	// вариант 1
@@ -50,5 +56,24 @@ func (r *OrdersStorage) CreateOrder(ctx context.Context, order *models.Order) er
 		return pkgerrors.Wrap(api, err)
 	}
 
+	// Позиции заказа - отдельными строками order_items
+	if err := insertOrderItems(ctx, engine, order); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	// Фиксируем начальный статус в истории статусов заказа
+	if err := insertOrderStatusTransition(ctx, engine, models.OrderStatusTransition{
+		OrderID:   order.ID,
+		To:        order.Status,
+		ChangedAt: order.StatusChangedAt,
+	}); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	// Фиксируем исходный состав в истории позиций заказа
+	if err := insertOrderItemChanges(ctx, engine, models.NewOrderItemsCreated(order, order.StatusChangedAt)); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
 	return nil
 }
@@ -0,0 +1,100 @@
+package orders_storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// orderEventsAppendLockKey - ключ advisory lock записи в журнал order_events: записи берут его разделяемым,
+// проекция - эксклюзивным, чтобы увидеть все события с seq меньше прочитанного (см. OrderEventsWatermark)
+const orderEventsAppendLockKey int64 = 0x6f6d735f65767473 // "oms_evts"
+
+// EventSourcedOrdersStorage - OrdersStorage в режиме event sourcing: источник правды о заказе - поток его событий
+// в order_events, заказ восстанавливается из последнего снимка и событий после него.
+// Таблицы orders и order_items - read model: строку заказа создает CreateOrder (на нее ссылаются платежи,
+// возвраты и история), дальше ее ведет проекция (воркер orders_projection). Списки заказов читаются из read model
+type EventSourcedOrdersStorage struct {
+	*OrdersStorage
+}
+
+// NewEventSourced - returns EventSourcedOrdersStorage
+func NewEventSourced(storage *OrdersStorage) *EventSourcedOrdersStorage {
+	return &EventSourcedOrdersStorage{OrdersStorage: storage}
+}
+
+func (r *EventSourcedOrdersStorage) GetOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error) {
+	const api = "orders_storage.EventSourcedOrdersStorage.GetOrder"
+
+	order, err := r.loadOrder(ctx, orderID, time.Time{})
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+	return order, nil
+}
+
+// LockOrder - заказ без блокировки: параллельное изменение отсекает версия потока при записи события
+func (r *EventSourcedOrdersStorage) LockOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error) {
+	const api = "orders_storage.EventSourcedOrdersStorage.LockOrder"
+
+	order, err := r.loadOrder(ctx, orderID, time.Time{})
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+	return order, nil
+}
+
+// UpdateOrderStatus - только история статусов: статус в read model сменит проекция
+func (r *EventSourcedOrdersStorage) UpdateOrderStatus(ctx context.Context, transition models.OrderStatusTransition) error {
+	const api = "orders_storage.EventSourcedOrdersStorage.UpdateOrderStatus"
+
+	if err := insertOrderStatusTransition(ctx, r.driver.GetQueryEngine(ctx), transition); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+	return nil
+}
+
+// UpdateOrderItems - только история позиций: состав и стоимость в read model обновит проекция
+func (r *EventSourcedOrdersStorage) UpdateOrderItems(ctx context.Context, _ *models.Order, changes []models.OrderItemChange) error {
+	const api = "orders_storage.EventSourcedOrdersStorage.UpdateOrderItems"
+
+	if err := insertOrderItemChanges(ctx, r.driver.GetQueryEngine(ctx), changes); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+	return nil
+}
+
+// AppendOrderEvent - запись события в поток заказа. Событие с тем же номером уже есть - ErrConcurrentModification
+func (r *EventSourcedOrdersStorage) AppendOrderEvent(ctx context.Context, event models.OrderEvent) error {
+	const api = "orders_storage.EventSourcedOrdersStorage.AppendOrderEvent"
+
+	engine := r.driver.GetQueryEngine(ctx)
+
+	if err := lockOrderEventsAppend(ctx, engine, false); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+	if err := r.insertOrderEvent(ctx, engine, event); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+// lockOrderEventsAppend - advisory lock записи в журнал до конца транзакции
+func lockOrderEventsAppend(ctx context.Context, engine transaction_manager.QueryEngine, exclusive bool) error {
+	function := "pg_advisory_xact_lock_shared(?)"
+	if exclusive {
+		function = "pg_advisory_xact_lock(?)"
+	}
+
+	query := squirrel.Select().
+		Column(function, orderEventsAppendLockKey).
+		PlaceholderFormat(squirrel.Dollar)
+
+	_, err := engine.Execx(ctx, query)
+	return err
+}
@@ -0,0 +1,56 @@
+package orders_storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+func (r *OrdersStorage) GetOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error) {
+	const api = "orders_storage.GetOrder"
+
+	order, err := r.getOrder(ctx, orderQuery(orderID))
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+	return order, nil
+}
+
+func (r *OrdersStorage) LockOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error) {
+	const api = "orders_storage.LockOrder"
+
+	order, err := r.getOrder(ctx, orderQuery(orderID).Suffix("FOR UPDATE"))
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+	return order, nil
+}
+
+func orderQuery(orderID models.OrderID) squirrel.SelectBuilder {
+	return squirrel.Select(orderColumns...).
+		From(tableOrdersName).
+		Where(squirrel.Eq{"id": uuid.UUID(orderID)}).
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+func (r *OrdersStorage) getOrder(ctx context.Context, query squirrel.SelectBuilder) (*models.Order, error) {
+	var row orderRow
+	if err := r.driver.GetQueryEngine(ctx).Getx(ctx, &row, query); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+
+	order := row.ToModelsOrder()
+	if err := r.selectOrderItems(ctx, order); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
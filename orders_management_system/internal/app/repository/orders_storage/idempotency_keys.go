@@ -0,0 +1,67 @@
+package orders_storage
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+type idempotencyKeyRow struct {
+	UserID      int64     `db:"user_id"`
+	Key         string    `db:"idempotency_key"`
+	RequestHash string    `db:"request_hash"`
+	OrderID     uuid.UUID `db:"order_id"`
+}
+
+func (r *OrdersStorage) GetIdempotencyKey(ctx context.Context, userID models.UserID, key string) (*models.IdempotencyKey, error) {
+	const api = "orders_storage.GetIdempotencyKey"
+
+	query := squirrel.Select("user_id", "idempotency_key", "request_hash", "order_id").
+		From(tableOrdersIdempotencyKeysName).
+		Where(squirrel.Eq{
+			"user_id":         int64(userID),
+			"idempotency_key": key,
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	var row idempotencyKeyRow
+	if err := r.driver.GetQueryEngine(ctx).Getx(ctx, &row, query); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkgerrors.Wrap(api, models.ErrNotFound)
+		}
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return &models.IdempotencyKey{
+		UserID:      models.UserID(row.UserID),
+		Key:         row.Key,
+		RequestHash: row.RequestHash,
+		OrderID:     models.OrderID(row.OrderID),
+	}, nil
+}
+
+func (r *OrdersStorage) CreateIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error {
+	const api = "orders_storage.CreateIdempotencyKey"
+
+	query := squirrel.Insert(tableOrdersIdempotencyKeysName).
+		Columns("user_id", "idempotency_key", "request_hash", "order_id").
+		Values(int64(key.UserID), key.Key, key.RequestHash, uuid.UUID(key.OrderID)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) && pgError.Code == pgerrcode.UniqueViolation {
+			return pkgerrors.Wrap(api, models.ErrAlreadyExists)
+		}
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package orders_storage
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+func (r *OrdersStorage) ListOrders(ctx context.Context, filter models.OrdersFilter) ([]*models.Order, error) {
+	const api = "orders_storage.ListOrders"
+
+	query := squirrel.Select(orderColumns...).
+		From(tableOrdersName).
+		Where(squirrel.Eq{"user_id": int64(filter.UserID)}).
+		OrderBy("created_at DESC", "id DESC").
+		Limit(filter.Limit).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if len(filter.Statuses) > 0 {
+		statuses := make([]string, len(filter.Statuses))
+		for i := range filter.Statuses {
+			statuses[i] = string(filter.Statuses[i])
+		}
+		query = query.Where(squirrel.Eq{"status": statuses})
+	}
+	if !filter.DeliveryDateFrom.IsZero() {
+		query = query.Where(squirrel.GtOrEq{"delivery_date": filter.DeliveryDateFrom})
+	}
+	if !filter.DeliveryDateTo.IsZero() {
+		query = query.Where(squirrel.LtOrEq{"delivery_date": filter.DeliveryDateTo})
+	}
+	// keyset пагинация: берем строки строго после последней отданной (OFFSET не используем)
+	if filter.After != nil {
+		query = query.Where("(created_at, id) < (?, ?)", filter.After.CreatedAt, uuid.UUID(filter.After.ID))
+	}
+
+	var rows []orderRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	orders := make([]*models.Order, 0, len(rows))
+	for i := range rows {
+		orders = append(orders, rows[i].ToModelsOrder())
+	}
+	if err := r.selectOrderItems(ctx, orders...); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return orders, nil
+}
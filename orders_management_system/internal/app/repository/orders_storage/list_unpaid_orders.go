@@ -0,0 +1,70 @@
+package orders_storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+// ListUnpaidOrders - заказы с резервом стоков, которые не оплачены и созданы раньше createdBefore.
+// Заказы, которые не удалось отменить после attemptedBefore, пропускаются, остальные неудачные - в конце.
+// Строки не блокируются: от гонки с оплатой защищает UpdateOrderStatus (WHERE status = transition.From)
+func (r *OrdersStorage) ListUnpaidOrders(ctx context.Context, createdBefore, attemptedBefore time.Time, limit uint64) ([]*models.Order, error) {
+	const api = "orders_storage.ListUnpaidOrders"
+
+	query := squirrel.Select(orderColumns...).
+		From(tableOrdersName).
+		LeftJoin(tableOrderExpiryAttemptsName+" a ON a.order_id = "+tableOrdersName+".id").
+		Where(squirrel.Eq{"status": []string{
+			string(models.OrderStatusReserved),
+			string(models.OrderStatusAwaitingPayment),
+			string(models.OrderStatusPaymentFailed),
+		}}).
+		Where(squirrel.Lt{"created_at": createdBefore}).
+		Where(squirrel.Or{
+			squirrel.Eq{"a.last_attempt_at": nil},
+			squirrel.Lt{"a.last_attempt_at": attemptedBefore},
+		}).
+		OrderBy("a.last_attempt_at NULLS FIRST", "created_at").
+		Limit(limit).
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []orderRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	orders := make([]*models.Order, 0, len(rows))
+	for i := range rows {
+		orders = append(orders, rows[i].ToModelsOrder())
+	}
+	if err := r.selectOrderItems(ctx, orders...); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return orders, nil
+}
+
+// PostponeOrderExpiry - запись неудачной попытки отменить неоплаченный заказ
+func (r *OrdersStorage) PostponeOrderExpiry(ctx context.Context, orderID models.OrderID, attemptedAt time.Time, lastError string) error {
+	const api = "orders_storage.PostponeOrderExpiry"
+
+	query := squirrel.Insert(tableOrderExpiryAttemptsName).
+		Columns("order_id", "attempts", "last_attempt_at", "last_error").
+		Values(uuid.UUID(orderID), 1, attemptedAt, lastError).
+		Suffix(`ON CONFLICT (order_id) DO UPDATE SET
+			attempts = order_expiry_attempts.attempts + 1,
+			last_attempt_at = EXCLUDED.last_attempt_at,
+			last_error = EXCLUDED.last_error`).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
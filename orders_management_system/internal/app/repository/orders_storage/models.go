@@ -2,47 +2,104 @@ package orders_storage
 
 import (
 	"database/sql"
-	"encoding/json"
+	"time"
 
+	googleuuid "github.com/google/uuid"
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
-	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
 	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
 )
 
-// модель представления данных в БД (храним в json)
+// модель представления позиций в json (позиции заявок на возврат)
 type orderItem struct {
 	SKUID       int64 `json:"sku_id"`       // id товара
 	Quantity    int32 `json:"quantity"`     // количество SKU
 	WarehouseID int64 `json:"warehouse_id"` // с какого склада будет браться сток
+	// цена за единицу на момент заказа (в заказах до появления цен - пустая)
+	PriceAmount   int64  `json:"price_amount,omitempty"`
+	PriceCurrency string `json:"price_currency,omitempty"`
 }
 
-func getOrderItems(order *models.Order) []orderItem {
-	items := make([]orderItem, len(order.Items))
-	for i := range order.Items {
-		items[i] = orderItem{
-			SKUID:       int64(order.Items[i].SKU.ID),
-			Quantity:    int32(order.Items[i].Quantity),
-			WarehouseID: int64(order.Items[i].WarehouseID),
+func getOrderItems(items []models.Item) []orderItem {
+	res := make([]orderItem, len(items))
+	for i := range items {
+		res[i] = orderItem{
+			SKUID:         int64(items[i].SKU.ID),
+			Quantity:      int32(items[i].Quantity),
+			WarehouseID:   int64(items[i].WarehouseID),
+			PriceAmount:   items[i].Price.Amount,
+			PriceCurrency: string(items[i].Price.Currency),
 		}
 	}
-	return items
+	return res
+}
+
+func newModelsItems(items []orderItem) []models.Item {
+	res := make([]models.Item, len(items))
+	for i := range items {
+		res[i] = models.Item{
+			SKU:         models.SKU{ID: models.SKUID(items[i].SKUID)},
+			Quantity:    uint32(items[i].Quantity),
+			WarehouseID: models.WarehouseID(items[i].WarehouseID),
+			Price:       models.NewMoney(items[i].PriceAmount, models.Currency(items[i].PriceCurrency)),
+		}
+	}
+	return res
+}
+
+// колонки таблицы orders, которые читаем в orderRow (позиции заказа - в таблице order_items)
+var orderColumns = []string{
+	"id",
+	"user_id",
+	"delivery_variant_id",
+	"delivery_date",
+	"currency",
+	"subtotal_amount",
+	"discount_amount",
+	"delivery_amount",
+	"total_amount",
+	"promo_code",
+	"status",
+	"reservation_id",
+	"status_changed_at",
+	"created_at",
+	"version",
 }
 
 type orderRow struct {
-	ID                uuid.UUID     `db:"id"`
-	UserID            int64         `db:"user_id"`
-	Items             []byte        `db:"items"`
-	DeliveryVariantID sql.NullInt64 `db:"delivery_variant_id"`
-	DeliveryDate      sql.NullTime  `db:"delivery_date"`
+	ID                uuid.UUID      `db:"id"`
+	UserID            int64          `db:"user_id"`
+	DeliveryVariantID sql.NullInt64  `db:"delivery_variant_id"`
+	DeliveryDate      sql.NullTime   `db:"delivery_date"`
+	Currency          string         `db:"currency"`
+	SubtotalAmount    int64          `db:"subtotal_amount"`
+	DiscountAmount    int64          `db:"discount_amount"`
+	DeliveryAmount    int64          `db:"delivery_amount"`
+	TotalAmount       int64          `db:"total_amount"`
+	PromoCode         sql.NullString `db:"promo_code"`
+	Status            string         `db:"status"`
+	ReservationID     uuid.NullUUID  `db:"reservation_id"`
+	StatusChangedAt   time.Time      `db:"status_changed_at"`
+	CreatedAt         time.Time      `db:"created_at"`
+	Version           int64          `db:"version"`
 }
 
 func (r *orderRow) ValuesMap() map[string]any {
 	return map[string]any{
 		"id":                  r.ID,
 		"user_id":             r.UserID,
-		"items":               r.Items,
 		"delivery_variant_id": r.DeliveryVariantID,
 		"delivery_date":       r.DeliveryDate,
+		"currency":            r.Currency,
+		"subtotal_amount":     r.SubtotalAmount,
+		"discount_amount":     r.DiscountAmount,
+		"delivery_amount":     r.DeliveryAmount,
+		"total_amount":        r.TotalAmount,
+		"promo_code":          r.PromoCode,
+		"status":              r.Status,
+		"reservation_id":      r.ReservationID,
+		"status_changed_at":   r.StatusChangedAt,
+		"created_at":          r.CreatedAt,
+		"version":             r.Version,
 	}
 }
 
@@ -57,23 +114,59 @@ func (r *orderRow) Values(columns ...string) []any {
 	return values
 }
 
-func newOrderRowFromModelsOrder(order *models.Order) (*orderRow, error) {
-	items, err := json.Marshal(getOrderItems(order))
-	if err != nil {
-		return nil, pkgerrors.Wrap("newOrderRowFromModelsOrder", err)
-	}
-
+func newOrderRowFromModelsOrder(order *models.Order) *orderRow {
 	return &orderRow{
 		ID:     uuid.UUID(order.ID),
 		UserID: int64(order.UserID),
-		Items:  items,
 		DeliveryVariantID: sql.NullInt64{
 			Int64: int64(order.DeliveryVariantID),
 			Valid: order.DeliveryVariantID != 0,
 		},
 		DeliveryDate: sql.NullTime{
 			Time:  order.DeliveryDate,
-			Valid: order.DeliveryDate.IsZero(),
+			Valid: !order.DeliveryDate.IsZero(),
 		},
-	}, nil
+		// суммы заказа в одной валюте (см. models.CalculateTotals)
+		Currency:       string(order.Totals.Total.Currency),
+		SubtotalAmount: order.Totals.Subtotal.Amount,
+		DiscountAmount: order.Totals.Discount.Amount,
+		DeliveryAmount: order.Totals.Delivery.Amount,
+		TotalAmount:    order.Totals.Total.Amount,
+		PromoCode: sql.NullString{
+			String: order.PromoCode,
+			Valid:  order.PromoCode != "",
+		},
+		Status: string(order.Status),
+		ReservationID: uuid.NullUUID{
+			UUID:  googleuuid.UUID(order.ReservationID),
+			Valid: !order.ReservationID.IsZero(),
+		},
+		StatusChangedAt: order.StatusChangedAt,
+		CreatedAt:       order.CreatedAt,
+		Version:         int64(order.Version),
+	}
+}
+
+// ToModelsOrder - конвертирует строку из БД в доменную модель заказа (без позиций, см. selectOrderItems)
+func (r *orderRow) ToModelsOrder() *models.Order {
+	return &models.Order{
+		ID:     models.OrderID(r.ID),
+		UserID: models.UserID(r.UserID),
+		DeliveryOrderInfo: models.DeliveryOrderInfo{
+			DeliveryVariantID: models.DeliveryVariantID(r.DeliveryVariantID.Int64),
+			DeliveryDate:      r.DeliveryDate.Time,
+		},
+		Totals: models.OrderTotals{
+			Subtotal: models.NewMoney(r.SubtotalAmount, models.Currency(r.Currency)),
+			Discount: models.NewMoney(r.DiscountAmount, models.Currency(r.Currency)),
+			Delivery: models.NewMoney(r.DeliveryAmount, models.Currency(r.Currency)),
+			Total:    models.NewMoney(r.TotalAmount, models.Currency(r.Currency)),
+		},
+		PromoCode:       r.PromoCode.String,
+		Status:          models.OrderStatus(r.Status),
+		ReservationID:   models.ReservationID(r.ReservationID.UUID),
+		StatusChangedAt: r.StatusChangedAt,
+		CreatedAt:       r.CreatedAt,
+		Version:         uint64(r.Version),
+	}
 }
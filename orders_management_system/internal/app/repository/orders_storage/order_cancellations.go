@@ -0,0 +1,162 @@
+package orders_storage
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	googleuuid "github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+type orderCancellationRow struct {
+	OrderID   uuid.UUID `db:"order_id"`
+	Payload   []byte    `db:"payload"`
+	Attempts  int32     `db:"attempts"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// orderCancellationPayload - действия отмены в order_cancellations.payload
+type orderCancellationPayload struct {
+	ReservationID googleuuid.UUID     `json:"reservation_id"`
+	Warehouses    []uint64            `json:"warehouses"`
+	Voids         []string            `json:"voids"`
+	Refunds       []paymentRefundData `json:"refunds"`
+}
+
+type paymentRefundData struct {
+	ProviderPaymentID string `json:"provider_payment_id"`
+	Amount            int64  `json:"amount"`
+	Currency          string `json:"currency"`
+}
+
+func newOrderCancellationPayload(c models.OrderCancellation) orderCancellationPayload {
+	p := orderCancellationPayload{
+		ReservationID: googleuuid.UUID(c.ReservationID),
+		Warehouses:    make([]uint64, 0, len(c.Warehouses)),
+		Voids:         c.Voids,
+		Refunds:       make([]paymentRefundData, 0, len(c.Refunds)),
+	}
+	for _, warehouseID := range c.Warehouses {
+		p.Warehouses = append(p.Warehouses, uint64(warehouseID))
+	}
+	for _, refund := range c.Refunds {
+		p.Refunds = append(p.Refunds, paymentRefundData{
+			ProviderPaymentID: refund.ProviderPaymentID,
+			Amount:            refund.Amount.Amount,
+			Currency:          string(refund.Amount.Currency),
+		})
+	}
+	return p
+}
+
+func (r *orderCancellationRow) ToModelsOrderCancellation() (models.OrderCancellation, error) {
+	var p orderCancellationPayload
+	if err := json.Unmarshal(r.Payload, &p); err != nil {
+		return models.OrderCancellation{}, err
+	}
+
+	c := models.OrderCancellation{
+		OrderID:       models.OrderID(r.OrderID),
+		ReservationID: models.ReservationID(p.ReservationID),
+		Voids:         p.Voids,
+		Attempts:      uint32(r.Attempts),
+		CreatedAt:     r.CreatedAt,
+	}
+	for _, warehouseID := range p.Warehouses {
+		c.Warehouses = append(c.Warehouses, models.WarehouseID(warehouseID))
+	}
+	for _, refund := range p.Refunds {
+		c.Refunds = append(c.Refunds, models.PaymentRefund{
+			ProviderPaymentID: refund.ProviderPaymentID,
+			Amount:            models.NewMoney(refund.Amount, models.Currency(refund.Currency)),
+		})
+	}
+	return c, nil
+}
+
+// CreateOrderCancellation - запись действий отмены заказа: выполняются после коммита транзакции отмены
+func (r *OrdersStorage) CreateOrderCancellation(ctx context.Context, cancellation models.OrderCancellation) error {
+	const api = "orders_storage.CreateOrderCancellation"
+
+	payload, err := json.Marshal(newOrderCancellationPayload(cancellation))
+	if err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	query := squirrel.Insert(tableOrderCancellationsName).
+		Columns("order_id", "payload", "attempts", "next_attempt_at", "created_at").
+		Values(uuid.UUID(cancellation.OrderID), payload, 0, cancellation.CreatedAt, cancellation.CreatedAt).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+// ClaimOrderCancellations - захватывает до конца транзакции отмены, время следующей попытки которых наступило
+func (r *OrdersStorage) ClaimOrderCancellations(ctx context.Context, now time.Time, limit uint64) ([]models.OrderCancellation, error) {
+	const api = "orders_storage.ClaimOrderCancellations"
+
+	query := squirrel.Select("order_id", "payload", "attempts", "created_at").
+		From(tableOrderCancellationsName).
+		Where(squirrel.LtOrEq{"next_attempt_at": now}).
+		OrderBy("next_attempt_at").
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []orderCancellationRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	cancellations := make([]models.OrderCancellation, 0, len(rows))
+	for i := range rows {
+		cancellation, err := rows[i].ToModelsOrderCancellation()
+		if err != nil {
+			return nil, pkgerrors.Wrap(api, err)
+		}
+		cancellations = append(cancellations, cancellation)
+	}
+
+	return cancellations, nil
+}
+
+// DeleteOrderCancellation - удаление выполненной отмены
+func (r *OrdersStorage) DeleteOrderCancellation(ctx context.Context, orderID models.OrderID) error {
+	const api = "orders_storage.DeleteOrderCancellation"
+
+	query := squirrel.Delete(tableOrderCancellationsName).
+		Where(squirrel.Eq{"order_id": uuid.UUID(orderID)}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+// PostponeOrderCancellation - перенос неудавшейся отмены на nextAttemptAt
+func (r *OrdersStorage) PostponeOrderCancellation(ctx context.Context, orderID models.OrderID, nextAttemptAt time.Time, lastError string) error {
+	const api = "orders_storage.PostponeOrderCancellation"
+
+	query := squirrel.Update(tableOrderCancellationsName).
+		Set("attempts", squirrel.Expr("attempts + 1")).
+		Set("next_attempt_at", nextAttemptAt).
+		Set("last_error", lastError).
+		Where(squirrel.Eq{"order_id": uuid.UUID(orderID)}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,142 @@
+package orders_storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	googleuuid "github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
+
+// orderState - состояние заказа в json: снимки order_snapshots.state и исходный заказ в событии OrderCreated.
+// Ключи совпадают с json_build_object в миграции снимков уже созданных заказов
+type orderState struct {
+	ID                googleuuid.UUID `json:"id"`
+	UserID            int64           `json:"user_id"`
+	DeliveryVariantID int64           `json:"delivery_variant_id"`
+	DeliveryDate      time.Time       `json:"delivery_date"`
+	Currency          string          `json:"currency"`
+	SubtotalAmount    int64           `json:"subtotal_amount"`
+	DiscountAmount    int64           `json:"discount_amount"`
+	DeliveryAmount    int64           `json:"delivery_amount"`
+	TotalAmount       int64           `json:"total_amount"`
+	PromoCode         string          `json:"promo_code"`
+	Status            string          `json:"status"`
+	ReservationID     googleuuid.UUID `json:"reservation_id"` // null - резерва нет
+	StatusChangedAt   time.Time       `json:"status_changed_at"`
+	CreatedAt         time.Time       `json:"created_at"`
+	Version           uint64          `json:"version"`
+	Items             []orderItem     `json:"items"`
+}
+
+func newOrderState(order *models.Order) *orderState {
+	return &orderState{
+		ID:                googleuuid.UUID(order.ID),
+		UserID:            int64(order.UserID),
+		DeliveryVariantID: int64(order.DeliveryVariantID),
+		DeliveryDate:      order.DeliveryDate,
+		// суммы заказа в одной валюте (см. models.CalculateTotals)
+		Currency:        string(order.Totals.Total.Currency),
+		SubtotalAmount:  order.Totals.Subtotal.Amount,
+		DiscountAmount:  order.Totals.Discount.Amount,
+		DeliveryAmount:  order.Totals.Delivery.Amount,
+		TotalAmount:     order.Totals.Total.Amount,
+		PromoCode:       order.PromoCode,
+		Status:          string(order.Status),
+		ReservationID:   googleuuid.UUID(order.ReservationID),
+		StatusChangedAt: order.StatusChangedAt,
+		CreatedAt:       order.CreatedAt,
+		Version:         order.Version,
+		Items:           getOrderItems(order.Items),
+	}
+}
+
+func (s *orderState) ToModelsOrder() *models.Order {
+	currency := models.Currency(s.Currency)
+	return &models.Order{
+		ID:     models.OrderID(s.ID),
+		UserID: models.UserID(s.UserID),
+		Items:  newModelsItems(s.Items),
+		DeliveryOrderInfo: models.DeliveryOrderInfo{
+			DeliveryVariantID: models.DeliveryVariantID(s.DeliveryVariantID),
+			DeliveryDate:      s.DeliveryDate,
+		},
+		Totals: models.OrderTotals{
+			Subtotal: models.NewMoney(s.SubtotalAmount, currency),
+			Discount: models.NewMoney(s.DiscountAmount, currency),
+			Delivery: models.NewMoney(s.DeliveryAmount, currency),
+			Total:    models.NewMoney(s.TotalAmount, currency),
+		},
+		PromoCode:       s.PromoCode,
+		Status:          models.OrderStatus(s.Status),
+		ReservationID:   models.ReservationID(s.ReservationID),
+		StatusChangedAt: s.StatusChangedAt,
+		CreatedAt:       s.CreatedAt,
+		Version:         s.Version,
+	}
+}
+
+type orderTransitionData struct {
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// orderEventData - изменение состояния заказа, которое вносит событие (order_events.data).
+// Из него заказ восстанавливается сверткой событий (models.Order.Apply)
+type orderEventData struct {
+	Order      *orderState          `json:"order,omitempty"`      // исходный заказ (OrderCreated)
+	Transition *orderTransitionData `json:"transition,omitempty"` // смена статуса
+	Items      []orderItem          `json:"items,omitempty"`      // отмененные позиции (OrderItemsCancelled)
+}
+
+func marshalOrderEventData(event models.OrderEvent) ([]byte, error) {
+	var data orderEventData
+	switch event.Type {
+	case models.OrderEventCreated:
+		data.Order = newOrderState(&event.Order)
+	case models.OrderEventItemsCancelled:
+		data.Items = getOrderItems(event.Items)
+	}
+	if event.Transition != nil {
+		data.Transition = &orderTransitionData{
+			From:      string(event.Transition.From),
+			To:        string(event.Transition.To),
+			ChangedAt: event.Transition.ChangedAt,
+		}
+	}
+
+	return json.Marshal(data)
+}
+
+// unmarshalOrderEventData - заполняет изменение состояния заказа в событии event из order_events.data
+func unmarshalOrderEventData(raw []byte, event *models.OrderEvent) error {
+	if raw == nil {
+		// события до event sourcing восстанавливаются только из снимка
+		return fmt.Errorf("%w: %s event %d of order %s has no data", models.ErrNotFound, event.Type, event.StreamVersion, event.Order.ID)
+	}
+
+	var data orderEventData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return err
+	}
+
+	orderID := event.Order.ID
+	if data.Order != nil {
+		event.Order = *data.Order.ToModelsOrder()
+	}
+	if data.Transition != nil {
+		event.Transition = &models.OrderStatusTransition{
+			OrderID:   orderID,
+			From:      models.OrderStatus(data.Transition.From),
+			To:        models.OrderStatus(data.Transition.To),
+			ChangedAt: data.Transition.ChangedAt,
+		}
+	}
+	if len(data.Items) > 0 {
+		event.Items = newModelsItems(data.Items)
+	}
+
+	return nil
+}
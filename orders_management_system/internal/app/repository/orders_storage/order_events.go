@@ -0,0 +1,243 @@
+package orders_storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	googleuuid "github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+// колонки таблицы order_events, которые читаем в orderEventRow
+var orderEventColumns = []string{
+	"seq",
+	"id",
+	"order_id",
+	"type",
+	"stream_version",
+	"version",
+	"order_status",
+	"actor_type",
+	"actor_id",
+	"reason",
+	"data",
+	"occurred_at",
+}
+
+type orderEventRow struct {
+	Seq           int64     `db:"seq"`
+	ID            uuid.UUID `db:"id"`
+	OrderID       uuid.UUID `db:"order_id"`
+	Type          string    `db:"type"`
+	StreamVersion int64     `db:"stream_version"`
+	Version       int32     `db:"version"`
+	OrderStatus   string    `db:"order_status"`
+	ActorType     string    `db:"actor_type"`
+	ActorID       string    `db:"actor_id"`
+	Reason        string    `db:"reason"`
+	Data          []byte    `db:"data"`
+	OccurredAt    time.Time `db:"occurred_at"`
+}
+
+func (r *orderEventRow) ToModelsOrderTimelineEntry() models.OrderTimelineEntry {
+	return models.OrderTimelineEntry{
+		EventID: googleuuid.UUID(r.ID),
+		OrderID: models.OrderID(r.OrderID),
+		Type:    models.OrderEventType(r.Type),
+		Status:  models.OrderStatus(r.OrderStatus),
+		Actor: models.Actor{
+			Type: models.ActorType(r.ActorType),
+			ID:   r.ActorID,
+		},
+		Reason:     r.Reason,
+		OccurredAt: r.OccurredAt,
+	}
+}
+
+// ToModelsOrderEvent - событие потока заказа для свертки (models.Order.Apply)
+func (r *orderEventRow) ToModelsOrderEvent() (models.OrderEvent, error) {
+	event := models.OrderEvent{
+		ID:            googleuuid.UUID(r.ID),
+		Type:          models.OrderEventType(r.Type),
+		StreamVersion: uint64(r.StreamVersion),
+		Seq:           r.Seq,
+		Version:       uint32(r.Version),
+		OccurredAt:    r.OccurredAt,
+		Order:         models.Order{ID: models.OrderID(r.OrderID)},
+		Actor: models.Actor{
+			Type: models.ActorType(r.ActorType),
+			ID:   r.ActorID,
+		},
+		Reason: r.Reason,
+	}
+	if err := unmarshalOrderEventData(r.Data, &event); err != nil {
+		return models.OrderEvent{}, err
+	}
+	return event, nil
+}
+
+// AppendOrderEvent - запись события в журнал заказа и в outbox (в транзакции вместе с изменением заказа).
+// Версия заказа в orders сдвигается на номер события: если заказ успели изменить параллельно - ErrConcurrentModification
+func (r *OrdersStorage) AppendOrderEvent(ctx context.Context, event models.OrderEvent) error {
+	const api = "orders_storage.AppendOrderEvent"
+
+	engine := r.driver.GetQueryEngine(ctx)
+
+	if err := r.insertOrderEvent(ctx, engine, event); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	query := squirrel.Update(tableOrdersName).
+		Set("version", int64(event.StreamVersion)).
+		Where(squirrel.Eq{
+			"id":      uuid.UUID(event.Order.ID),
+			"version": int64(event.StreamVersion) - 1,
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	tag, err := engine.Execx(ctx, query)
+	if err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pkgerrors.Wrap(api, models.ErrConcurrentModification)
+	}
+
+	return nil
+}
+
+// insertOrderEvent - событие в журнал, ссылка на него в outbox и, если пора, снимок состояния заказа
+func (r *OrdersStorage) insertOrderEvent(ctx context.Context, engine transaction_manager.QueryEngine, event models.OrderEvent) error {
+	payload, err := marshalOrderEvent(event)
+	if err != nil {
+		return err
+	}
+	data, err := marshalOrderEventData(event)
+	if err != nil {
+		return err
+	}
+
+	eventQuery := squirrel.Insert(tableOrderEventsName).
+		Columns("id", "order_id", "type", "stream_version", "version", "order_status", "actor_type", "actor_id", "reason", "payload", "data", "occurred_at").
+		Values(
+			uuid.UUID(event.ID),
+			uuid.UUID(event.Order.ID),
+			string(event.Type),
+			int64(event.StreamVersion),
+			int64(event.Version),
+			string(event.Order.Status),
+			string(event.Actor.Type),
+			event.Actor.ID,
+			event.Reason,
+			payload,
+			data,
+			event.OccurredAt,
+		).
+		PlaceholderFormat(squirrel.Dollar)
+
+	// в outbox - только ссылка на событие: тип и конверт берем из журнала при публикации
+	outboxQuery := squirrel.Insert(tableOrdersOutboxMessagesName).
+		Columns("order_id", "event_id").
+		Values(uuid.UUID(event.Order.ID), uuid.UUID(event.ID)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := engine.Execx(ctx, eventQuery); err != nil {
+		// событие с этим номером уже записал параллельный запрос
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) && pgError.Code == pgerrcode.UniqueViolation {
+			return models.ErrConcurrentModification
+		}
+		return err
+	}
+	if _, err := engine.Execx(ctx, outboxQuery); err != nil {
+		return err
+	}
+
+	if r.snapshotEvery > 0 && event.StreamVersion%r.snapshotEvery == 0 {
+		return insertOrderSnapshot(ctx, engine, &event.Order, event.OccurredAt)
+	}
+
+	return nil
+}
+
+func (r *OrdersStorage) ListOrderEvents(ctx context.Context, orderID models.OrderID) ([]models.OrderTimelineEntry, error) {
+	const api = "orders_storage.ListOrderEvents"
+
+	query := squirrel.Select(orderEventColumns...).
+		From(tableOrderEventsName).
+		Where(squirrel.Eq{"order_id": uuid.UUID(orderID)}).
+		OrderBy("seq").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []orderEventRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	entries := make([]models.OrderTimelineEntry, len(rows))
+	for i := range rows {
+		entries[i] = rows[i].ToModelsOrderTimelineEntry()
+	}
+
+	return entries, nil
+}
+
+func (r *OrdersStorage) GetOrderAt(ctx context.Context, orderID models.OrderID, at time.Time) (*models.Order, error) {
+	const api = "orders_storage.GetOrderAt"
+
+	order, err := r.loadOrder(ctx, orderID, at)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+	return order, nil
+}
+
+// loadOrder - заказ, восстановленный из последнего снимка и событий после него.
+// Если at не пустое - состояние на момент at: события после at не применяются
+func (r *OrdersStorage) loadOrder(ctx context.Context, orderID models.OrderID, at time.Time) (*models.Order, error) {
+	engine := r.driver.GetQueryEngine(ctx)
+
+	snapshot, err := selectOrderSnapshot(ctx, engine, orderID, at)
+	if err != nil {
+		return nil, err
+	}
+
+	var fromVersion uint64
+	if snapshot != nil {
+		fromVersion = snapshot.Version
+	}
+
+	query := squirrel.Select(orderEventColumns...).
+		From(tableOrderEventsName).
+		Where(squirrel.Eq{"order_id": uuid.UUID(orderID)}).
+		Where(squirrel.Gt{"stream_version": int64(fromVersion)}).
+		OrderBy("stream_version").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []orderEventRow
+	if err := engine.Selectx(ctx, &rows, query); err != nil {
+		return nil, err
+	}
+
+	events := make([]models.OrderEvent, 0, len(rows))
+	for i := range rows {
+		// поток упорядочен по номеру события, а не по времени: останавливаемся на первом событии после at
+		if !at.IsZero() && rows[i].OccurredAt.After(at) {
+			break
+		}
+		event, err := rows[i].ToModelsOrderEvent()
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return models.RebuildOrder(snapshot, events)
+}
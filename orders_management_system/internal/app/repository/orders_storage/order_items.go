@@ -0,0 +1,141 @@
+package orders_storage
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+// колонки таблицы order_items, которые пишем и читаем в orderItemRow
+var orderItemColumns = []string{
+	"order_id",
+	"line_no",
+	"sku_id",
+	"warehouse_id",
+	"quantity",
+	"price_amount",
+	"price_currency",
+}
+
+// orderItemRow - позиция заказа. line_no (с 1) сохраняет порядок позиций в заказе
+type orderItemRow struct {
+	OrderID       uuid.UUID `db:"order_id"`
+	LineNo        int32     `db:"line_no"`
+	SKUID         int64     `db:"sku_id"`
+	WarehouseID   int64     `db:"warehouse_id"`
+	Quantity      int32     `db:"quantity"`
+	PriceAmount   int64     `db:"price_amount"`
+	PriceCurrency string    `db:"price_currency"`
+}
+
+func (r *orderItemRow) Values() []any {
+	return []any{r.OrderID, r.LineNo, r.SKUID, r.WarehouseID, r.Quantity, r.PriceAmount, r.PriceCurrency}
+}
+
+func (r *orderItemRow) ToModelsItem() models.Item {
+	return models.Item{
+		SKU:         models.SKU{ID: models.SKUID(r.SKUID)},
+		Quantity:    uint32(r.Quantity),
+		WarehouseID: models.WarehouseID(r.WarehouseID),
+		Price:       models.NewMoney(r.PriceAmount, models.Currency(r.PriceCurrency)),
+	}
+}
+
+func newOrderItemRows(order *models.Order) []orderItemRow {
+	rows := make([]orderItemRow, len(order.Items))
+	for i, item := range order.Items {
+		rows[i] = orderItemRow{
+			OrderID:       uuid.UUID(order.ID),
+			LineNo:        int32(i + 1),
+			SKUID:         int64(item.SKU.ID),
+			WarehouseID:   int64(item.WarehouseID),
+			Quantity:      int32(item.Quantity),
+			PriceAmount:   item.Price.Amount,
+			PriceCurrency: string(item.Price.Currency),
+		}
+	}
+	return rows
+}
+
+// insertOrderItems - позиции нового заказа одним COPY
+func insertOrderItems(ctx context.Context, engine transaction_manager.QueryEngine, order *models.Order) error {
+	rows := newOrderItemRows(order)
+	if len(rows) == 0 {
+		return nil
+	}
+
+	_, err := engine.CopyFrom(ctx, pgx.Identifier{tableOrderItemsName}, orderItemColumns,
+		pgx.CopyFromSlice(len(rows), func(i int) ([]any, error) {
+			return rows[i].Values(), nil
+		}),
+	)
+	return err
+}
+
+// replaceOrderItems - замена позиций заказа одним пакетом запросов (DELETE + INSERT)
+func replaceOrderItems(ctx context.Context, engine transaction_manager.QueryEngine, order *models.Order) error {
+	var batch pgx.Batch
+
+	deleteQuery, args, err := squirrel.Delete(tableOrderItemsName).
+		Where(squirrel.Eq{"order_id": uuid.UUID(order.ID)}).
+		PlaceholderFormat(squirrel.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	batch.Queue(deleteQuery, args...)
+
+	if rows := newOrderItemRows(order); len(rows) > 0 {
+		insert := squirrel.Insert(tableOrderItemsName).
+			Columns(orderItemColumns...).
+			PlaceholderFormat(squirrel.Dollar)
+		for i := range rows {
+			insert = insert.Values(rows[i].Values()...)
+		}
+		insertQuery, args, err := insert.ToSql()
+		if err != nil {
+			return err
+		}
+		batch.Queue(insertQuery, args...)
+	}
+
+	return engine.SendBatch(ctx, &batch).Close()
+}
+
+// selectOrderItems - позиции заказов orders одним запросом
+func (r *OrdersStorage) selectOrderItems(ctx context.Context, orders ...*models.Order) error {
+	if len(orders) == 0 {
+		return nil
+	}
+
+	ids := make([]uuid.UUID, len(orders))
+	for i, order := range orders {
+		ids[i] = uuid.UUID(order.ID)
+	}
+
+	query := squirrel.Select(orderItemColumns...).
+		From(tableOrderItemsName).
+		Where(squirrel.Eq{"order_id": ids}).
+		OrderBy("order_id", "line_no").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []orderItemRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return err
+	}
+
+	items := make(map[models.OrderID][]models.Item, len(orders))
+	for i := range rows {
+		orderID := models.OrderID(rows[i].OrderID)
+		items[orderID] = append(items[orderID], rows[i].ToModelsItem())
+	}
+	for _, order := range orders {
+		order.Items = items[order.ID]
+	}
+
+	return nil
+}
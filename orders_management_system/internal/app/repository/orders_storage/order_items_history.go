@@ -0,0 +1,137 @@
+package orders_storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+// колонки таблицы orders_items_history, которые пишем и читаем в orderItemChangeRow
+var orderItemChangeColumns = []string{
+	"order_id",
+	"sku_id",
+	"warehouse_id",
+	"price_amount",
+	"price_currency",
+	"from_quantity",
+	"to_quantity",
+	"reason",
+	"changed_at",
+}
+
+type orderItemChangeRow struct {
+	OrderID       uuid.UUID `db:"order_id"`
+	SKUID         int64     `db:"sku_id"`
+	WarehouseID   int64     `db:"warehouse_id"`
+	PriceAmount   int64     `db:"price_amount"`
+	PriceCurrency string    `db:"price_currency"`
+	FromQuantity  int32     `db:"from_quantity"`
+	ToQuantity    int32     `db:"to_quantity"`
+	Reason        string    `db:"reason"`
+	ChangedAt     time.Time `db:"changed_at"`
+}
+
+func (r *orderItemChangeRow) ToModelsOrderItemChange() models.OrderItemChange {
+	return models.OrderItemChange{
+		OrderID:      models.OrderID(r.OrderID),
+		SKUID:        models.SKUID(r.SKUID),
+		WarehouseID:  models.WarehouseID(r.WarehouseID),
+		Price:        models.NewMoney(r.PriceAmount, models.Currency(r.PriceCurrency)),
+		FromQuantity: uint32(r.FromQuantity),
+		ToQuantity:   uint32(r.ToQuantity),
+		Reason:       models.OrderItemChangeReason(r.Reason),
+		ChangedAt:    r.ChangedAt,
+	}
+}
+
+func (r *OrdersStorage) UpdateOrderItems(ctx context.Context, order *models.Order, changes []models.OrderItemChange) error {
+	const api = "orders_storage.UpdateOrderItems"
+
+	row := newOrderRowFromModelsOrder(order)
+
+	query := squirrel.Update(tableOrdersName).
+		Set("currency", row.Currency).
+		Set("subtotal_amount", row.SubtotalAmount).
+		Set("discount_amount", row.DiscountAmount).
+		Set("delivery_amount", row.DeliveryAmount).
+		Set("total_amount", row.TotalAmount).
+		Where(squirrel.Eq{"id": row.ID}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	engine := r.driver.GetQueryEngine(ctx)
+
+	tag, err := engine.Execx(ctx, query)
+	if err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pkgerrors.Wrap(api, models.ErrNotFound)
+	}
+
+	if err := replaceOrderItems(ctx, engine, order); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	if err := insertOrderItemChanges(ctx, engine, changes); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+func (r *OrdersStorage) ListOrderItemChanges(ctx context.Context, orderID models.OrderID) ([]models.OrderItemChange, error) {
+	const api = "orders_storage.ListOrderItemChanges"
+
+	query := squirrel.Select(orderItemChangeColumns...).
+		From(tableOrdersItemsHistoryName).
+		Where(squirrel.Eq{"order_id": uuid.UUID(orderID)}).
+		OrderBy("id").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []orderItemChangeRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	changes := make([]models.OrderItemChange, 0, len(rows))
+	for i := range rows {
+		changes = append(changes, rows[i].ToModelsOrderItemChange())
+	}
+
+	return changes, nil
+}
+
+func insertOrderItemChanges(ctx context.Context, engine transaction_manager.QueryEngine, changes []models.OrderItemChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	query := squirrel.Insert(tableOrdersItemsHistoryName).
+		Columns(orderItemChangeColumns...).
+		PlaceholderFormat(squirrel.Dollar)
+
+	for _, change := range changes {
+		query = query.Values(
+			uuid.UUID(change.OrderID),
+			int64(change.SKUID),
+			int64(change.WarehouseID),
+			change.Price.Amount,
+			string(change.Price.Currency),
+			int32(change.FromQuantity),
+			int32(change.ToQuantity),
+			string(change.Reason),
+			change.ChangedAt,
+		)
+	}
+
+	if _, err := engine.Execx(ctx, query); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package orders_storage
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// OrderEventsWatermark - позиция журнала, до которой все записи завершены: на время чтения max(seq)
+// ждем транзакции, которые уже пишут в журнал (EventSourcedOrdersStorage.AppendOrderEvent).
+// Без этого проекция могла бы пропустить событие, чей seq выдан раньше, а транзакция закоммичена позже
+func (r *OrdersStorage) OrderEventsWatermark(ctx context.Context) (int64, error) {
+	const api = "orders_storage.OrderEventsWatermark"
+
+	engine := r.driver.GetQueryEngine(ctx)
+
+	if err := lockOrderEventsAppend(ctx, engine, true); err != nil {
+		return 0, pkgerrors.Wrap(api, err)
+	}
+
+	query := squirrel.Select("coalesce(max(seq), 0)").
+		From(tableOrderEventsName).
+		PlaceholderFormat(squirrel.Dollar)
+
+	var watermark int64
+	if err := engine.Getx(ctx, &watermark, query); err != nil {
+		return 0, pkgerrors.Wrap(api, err)
+	}
+
+	return watermark, nil
+}
+
+// LockProjection - позиция проекции name в журнале с блокировкой до конца транзакции
+func (r *OrdersStorage) LockProjection(ctx context.Context, name string) (int64, error) {
+	const api = "orders_storage.LockProjection"
+
+	query := squirrel.Select("last_seq").
+		From(tableOrderProjectionsName).
+		Where(squirrel.Eq{"name": name}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var lastSeq int64
+	if err := r.driver.GetQueryEngine(ctx).Getx(ctx, &lastSeq, query); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, pkgerrors.Wrap(api, models.ErrNotFound)
+		}
+		return 0, pkgerrors.Wrap(api, err)
+	}
+
+	return lastSeq, nil
+}
+
+// UpdateProjection - сдвигает позицию проекции name в журнале
+func (r *OrdersStorage) UpdateProjection(ctx context.Context, name string, lastSeq int64) error {
+	const api = "orders_storage.UpdateProjection"
+
+	query := squirrel.Update(tableOrderProjectionsName).
+		Set("last_seq", lastSeq).
+		Set("updated_at", time.Now().UTC()).
+		Where(squirrel.Eq{"name": name}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+// ListOrderEventsBetween - события журнала с позициями (afterSeq, toSeq] в порядке записи, не больше limit
+func (r *OrdersStorage) ListOrderEventsBetween(ctx context.Context, afterSeq, toSeq int64, limit uint64) ([]models.OrderEvent, error) {
+	const api = "orders_storage.ListOrderEventsBetween"
+
+	query := squirrel.Select(orderEventColumns...).
+		From(tableOrderEventsName).
+		Where(squirrel.Gt{"seq": afterSeq}).
+		Where(squirrel.LtOrEq{"seq": toSeq}).
+		OrderBy("seq").
+		Limit(limit).
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []orderEventRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	events := make([]models.OrderEvent, 0, len(rows))
+	for i := range rows {
+		event, err := rows[i].ToModelsOrderEvent()
+		if err != nil {
+			return nil, pkgerrors.Wrap(api, err)
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// SaveOrderProjection - состояние заказа в read model (orders и order_items), если версия заказа
+// в read model все еще fromVersion. Заказа в read model нет - создает его
+func (r *OrdersStorage) SaveOrderProjection(ctx context.Context, order *models.Order, fromVersion uint64) error {
+	const api = "orders_storage.SaveOrderProjection"
+
+	row := newOrderRowFromModelsOrder(order)
+
+	query := squirrel.Insert(tableOrdersName).
+		SetMap(map[string]any{
+			"id":                  row.ID,
+			"user_id":             row.UserID,
+			"delivery_variant_id": row.DeliveryVariantID,
+			"delivery_date":       row.DeliveryDate,
+			"currency":            row.Currency,
+			"subtotal_amount":     row.SubtotalAmount,
+			"discount_amount":     row.DiscountAmount,
+			"delivery_amount":     row.DeliveryAmount,
+			"total_amount":        row.TotalAmount,
+			"promo_code":          row.PromoCode,
+			"status":              row.Status,
+			"reservation_id":      row.ReservationID,
+			"status_changed_at":   row.StatusChangedAt,
+			"version":             row.Version,
+		}).
+		Suffix(`ON CONFLICT (id) DO UPDATE SET
+			currency = excluded.currency,
+			subtotal_amount = excluded.subtotal_amount,
+			discount_amount = excluded.discount_amount,
+			delivery_amount = excluded.delivery_amount,
+			total_amount = excluded.total_amount,
+			status = excluded.status,
+			status_changed_at = excluded.status_changed_at,
+			version = excluded.version
+		WHERE `+tableOrdersName+`.version = ?`, int64(fromVersion)).
+		PlaceholderFormat(squirrel.Dollar)
+
+	engine := r.driver.GetQueryEngine(ctx)
+
+	tag, err := engine.Execx(ctx, query)
+	if err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pkgerrors.Wrap(api, models.ErrConcurrentModification)
+	}
+
+	if err := replaceOrderItems(ctx, engine, order); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
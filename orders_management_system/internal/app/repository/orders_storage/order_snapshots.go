@@ -0,0 +1,64 @@
+package orders_storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+type orderSnapshotRow struct {
+	State []byte `db:"state"`
+}
+
+// insertOrderSnapshot - снимок состояния заказа order (после события order.Version, случившегося в at)
+func insertOrderSnapshot(ctx context.Context, engine transaction_manager.QueryEngine, order *models.Order, at time.Time) error {
+	state, err := json.Marshal(newOrderState(order))
+	if err != nil {
+		return err
+	}
+
+	query := squirrel.Insert(tableOrderSnapshotsName).
+		Columns("order_id", "version", "state", "occurred_at").
+		Values(uuid.UUID(order.ID), int64(order.Version), state, at).
+		Suffix("ON CONFLICT (order_id, version) DO NOTHING").
+		PlaceholderFormat(squirrel.Dollar)
+
+	_, err = engine.Execx(ctx, query)
+	return err
+}
+
+// selectOrderSnapshot - последний снимок состояния заказа (если at не пустое - сделанный не позже at).
+// Снимков нет - nil
+func selectOrderSnapshot(ctx context.Context, engine transaction_manager.QueryEngine, orderID models.OrderID, at time.Time) (*models.Order, error) {
+	query := squirrel.Select("state").
+		From(tableOrderSnapshotsName).
+		Where(squirrel.Eq{"order_id": uuid.UUID(orderID)}).
+		OrderBy("version DESC").
+		Limit(1).
+		PlaceholderFormat(squirrel.Dollar)
+	if !at.IsZero() {
+		query = query.Where(squirrel.LtOrEq{"occurred_at": at})
+	}
+
+	var row orderSnapshotRow
+	if err := engine.Getx(ctx, &row, query); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var state orderState
+	if err := json.Unmarshal(row.State, &state); err != nil {
+		return nil, err
+	}
+
+	return state.ToModelsOrder(), nil
+}
@@ -0,0 +1,158 @@
+package orders_storage
+
+import (
+	"fmt"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/converters"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// marshalOrderEvent - сериализует событие в pb.EventEnvelope
+func marshalOrderEvent(event models.OrderEvent) ([]byte, error) {
+	var (
+		order   = event.Order
+		payload proto.Message
+	)
+	switch event.Type {
+	case models.OrderEventCreated:
+		deliveryInfo := &pb.Order_DeliveryInfo{
+			DeliveryVariantId: uint64(order.DeliveryVariantID),
+		}
+		if !order.DeliveryDate.IsZero() {
+			deliveryInfo.DeliveryDate = timestamppb.New(order.DeliveryDate)
+		}
+		payload = &pb.OrderCreated{
+			OrderId:      order.ID.String(),
+			UserId:       uint64(order.UserID),
+			Items:        newPbOrderItems(order.Items),
+			DeliveryInfo: deliveryInfo,
+			Status:       converters.NewPbOrderStatusFromModelsOrderStatus(order.Status),
+			CreatedAt:    timestamppb.New(event.OccurredAt),
+			Totals:       newPbOrderTotals(order.Totals),
+		}
+	case models.OrderEventCancelled:
+		if event.Transition == nil {
+			return nil, fmt.Errorf("%s event without transition", event.Type)
+		}
+		payload = &pb.OrderCancelled{
+			OrderId:        order.ID.String(),
+			UserId:         uint64(order.UserID),
+			PreviousStatus: converters.NewPbOrderStatusFromModelsOrderStatus(event.Transition.From),
+			Items:          newPbOrderItems(order.Items),
+			CancelledAt:    timestamppb.New(event.Transition.ChangedAt),
+		}
+	case models.OrderEventStatusChanged:
+		if event.Transition == nil {
+			return nil, fmt.Errorf("%s event without transition", event.Type)
+		}
+		payload = &pb.OrderStatusChanged{
+			OrderId:   order.ID.String(),
+			From:      converters.NewPbOrderStatusFromModelsOrderStatus(event.Transition.From),
+			To:        converters.NewPbOrderStatusFromModelsOrderStatus(event.Transition.To),
+			ChangedAt: timestamppb.New(event.Transition.ChangedAt),
+		}
+	case models.OrderEventPaid:
+		if event.Transition == nil || event.Payment == nil {
+			return nil, fmt.Errorf("%s event without transition or payment", event.Type)
+		}
+		payload = &pb.OrderPaid{
+			OrderId:   order.ID.String(),
+			UserId:    uint64(order.UserID),
+			PaymentId: event.Payment.ID.String(),
+			Amount:    newPbMoney(event.Payment.Amount),
+			PaidAt:    timestamppb.New(event.Transition.ChangedAt),
+		}
+	case models.OrderEventPaymentFailed:
+		if event.Transition == nil || event.Payment == nil {
+			return nil, fmt.Errorf("%s event without transition or payment", event.Type)
+		}
+		payload = &pb.OrderPaymentFailed{
+			OrderId:       order.ID.String(),
+			UserId:        uint64(order.UserID),
+			PaymentId:     event.Payment.ID.String(),
+			FailureReason: event.Payment.FailureReason,
+			FailedAt:      timestamppb.New(event.Transition.ChangedAt),
+		}
+	case models.OrderEventItemsCancelled:
+		payload = &pb.OrderItemsCancelled{
+			OrderId:        order.ID.String(),
+			UserId:         uint64(order.UserID),
+			CancelledItems: newPbOrderItems(event.Items),
+			Items:          newPbOrderItems(order.Items),
+			Totals:         newPbOrderTotals(order.Totals),
+			CancelledAt:    timestamppb.New(event.OccurredAt),
+		}
+	case models.OrderEventReturnRequested:
+		if event.Return == nil {
+			return nil, fmt.Errorf("%s event without return", event.Type)
+		}
+		payload = &pb.OrderReturnRequested{
+			OrderId:     order.ID.String(),
+			UserId:      uint64(order.UserID),
+			ReturnId:    event.Return.ID.String(),
+			Items:       newPbOrderItems(event.Return.Items),
+			Refund:      newPbMoney(event.Return.Refund),
+			RequestedAt: timestamppb.New(event.Return.CreatedAt),
+		}
+	case models.OrderEventReturnApproved:
+		if event.Return == nil {
+			return nil, fmt.Errorf("%s event without return", event.Type)
+		}
+		payload = &pb.OrderReturnApproved{
+			OrderId:    order.ID.String(),
+			UserId:     uint64(order.UserID),
+			ReturnId:   event.Return.ID.String(),
+			Items:      newPbOrderItems(event.Return.Items),
+			Refund:     newPbMoney(event.Return.Refund),
+			ApprovedAt: timestamppb.New(event.Return.UpdatedAt),
+		}
+	default:
+		return nil, fmt.Errorf("unknown event type %q", event.Type)
+	}
+
+	payloadBytes, err := proto.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(&pb.EventEnvelope{
+		EventId:     event.ID.String(),
+		Type:        string(event.Type),
+		Version:     event.Version,
+		OccurredAt:  timestamppb.New(event.OccurredAt),
+		AggregateId: order.ID.String(),
+		Payload:     payloadBytes,
+	})
+}
+
+func newPbOrderItems(items []models.Item) []*pb.Order_Item {
+	res := make([]*pb.Order_Item, len(items))
+	for i := range items {
+		res[i] = &pb.Order_Item{
+			SkuId:       uint64(items[i].SKU.ID),
+			Quantity:    items[i].Quantity,
+			WarehouseId: uint64(items[i].WarehouseID),
+			UnitPrice:   newPbMoney(items[i].Price),
+		}
+	}
+	return res
+}
+
+func newPbOrderTotals(totals models.OrderTotals) *pb.OrderTotals {
+	return &pb.OrderTotals{
+		Subtotal: newPbMoney(totals.Subtotal),
+		Discount: newPbMoney(totals.Discount),
+		Delivery: newPbMoney(totals.Delivery),
+		Total:    newPbMoney(totals.Total),
+	}
+}
+
+func newPbMoney(money models.Money) *pb.Money {
+	return &pb.Money{
+		Amount:       money.Amount,
+		CurrencyCode: string(money.Currency),
+	}
+}
@@ -0,0 +1,221 @@
+//go:build test
+
+package orders_storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestMarshalOrderEvent(t *testing.T) {
+	var (
+		rub   = func(amount int64) models.Money { return models.NewMoney(amount, models.CurrencyRUB) }
+		pbRub = func(amount int64) *pb.Money { return &pb.Money{Amount: amount, CurrencyCode: "RUB"} }
+		at    = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+		orderID   = models.OrderID(uuid.New())
+		paymentID = models.PaymentID(uuid.New())
+		returnID  = models.ReturnID(uuid.New())
+
+		items = []models.Item{
+			{SKU: models.SKU{ID: 1}, Quantity: 2, WarehouseID: 10, Price: rub(1000)},
+		}
+		pbItems = []*pb.Order_Item{
+			{SkuId: 1, Quantity: 2, WarehouseId: 10, UnitPrice: pbRub(1000)},
+		}
+		order = models.Order{
+			ID:     orderID,
+			UserID: 7,
+			Items:  items,
+			DeliveryOrderInfo: models.DeliveryOrderInfo{
+				DeliveryVariantID: 3,
+				DeliveryDate:      at.Add(48 * time.Hour),
+			},
+			Status: models.OrderStatusReserved,
+			Totals: models.OrderTotals{Subtotal: rub(2000), Discount: rub(0), Delivery: rub(300), Total: rub(2300)},
+		}
+		pbTotals = &pb.OrderTotals{Subtotal: pbRub(2000), Discount: pbRub(0), Delivery: pbRub(300), Total: pbRub(2300)}
+
+		transition = &models.OrderStatusTransition{
+			OrderID:   orderID,
+			From:      models.OrderStatusAwaitingPayment,
+			To:        models.OrderStatusPaid,
+			ChangedAt: at,
+		}
+		payment = &models.Payment{ID: paymentID, OrderID: orderID, Amount: rub(2300), FailureReason: "insufficient funds"}
+		ret     = &models.Return{ID: returnID, OrderID: orderID, Items: items, Refund: rub(2000), CreatedAt: at, UpdatedAt: at.Add(time.Hour)}
+
+		withoutDeliveryDate = func(order models.Order) models.Order {
+			order.DeliveryDate = time.Time{}
+			return order
+		}
+	)
+
+	tests := []struct {
+		name    string
+		event   models.OrderEvent
+		want    proto.Message // ожидаемый payload конверта
+		wantErr bool
+	}{
+		{
+			name:  "Test 1. Positive. OrderCreated.",
+			event: models.OrderEvent{Type: models.OrderEventCreated, Order: order},
+			want: &pb.OrderCreated{
+				OrderId: orderID.String(),
+				UserId:  7,
+				Items:   pbItems,
+				DeliveryInfo: &pb.Order_DeliveryInfo{
+					DeliveryVariantId: 3,
+					DeliveryDate:      timestamppb.New(at.Add(48 * time.Hour)),
+				},
+				Status:    pb.OrderStatus_ORDER_STATUS_RESERVED,
+				CreatedAt: timestamppb.New(at),
+				Totals:    pbTotals,
+			},
+		},
+		{
+			name:  "Test 2. Positive. OrderCreated without delivery date.",
+			event: models.OrderEvent{Type: models.OrderEventCreated, Order: withoutDeliveryDate(order)},
+			want: &pb.OrderCreated{
+				OrderId:      orderID.String(),
+				UserId:       7,
+				Items:        pbItems,
+				DeliveryInfo: &pb.Order_DeliveryInfo{DeliveryVariantId: 3},
+				Status:       pb.OrderStatus_ORDER_STATUS_RESERVED,
+				CreatedAt:    timestamppb.New(at),
+				Totals:       pbTotals,
+			},
+		},
+		{
+			name:  "Test 3. Positive. OrderCancelled.",
+			event: models.OrderEvent{Type: models.OrderEventCancelled, Order: order, Transition: transition},
+			want: &pb.OrderCancelled{
+				OrderId:        orderID.String(),
+				UserId:         7,
+				PreviousStatus: pb.OrderStatus_ORDER_STATUS_AWAITING_PAYMENT,
+				Items:          pbItems,
+				CancelledAt:    timestamppb.New(at),
+			},
+		},
+		{
+			name:  "Test 4. Positive. OrderStatusChanged.",
+			event: models.OrderEvent{Type: models.OrderEventStatusChanged, Order: order, Transition: transition},
+			want: &pb.OrderStatusChanged{
+				OrderId:   orderID.String(),
+				From:      pb.OrderStatus_ORDER_STATUS_AWAITING_PAYMENT,
+				To:        pb.OrderStatus_ORDER_STATUS_PAID,
+				ChangedAt: timestamppb.New(at),
+			},
+		},
+		{
+			name:  "Test 5. Positive. OrderPaid.",
+			event: models.OrderEvent{Type: models.OrderEventPaid, Order: order, Transition: transition, Payment: payment},
+			want: &pb.OrderPaid{
+				OrderId:   orderID.String(),
+				UserId:    7,
+				PaymentId: paymentID.String(),
+				Amount:    pbRub(2300),
+				PaidAt:    timestamppb.New(at),
+			},
+		},
+		{
+			name:  "Test 6. Positive. OrderPaymentFailed.",
+			event: models.OrderEvent{Type: models.OrderEventPaymentFailed, Order: order, Transition: transition, Payment: payment},
+			want: &pb.OrderPaymentFailed{
+				OrderId:       orderID.String(),
+				UserId:        7,
+				PaymentId:     paymentID.String(),
+				FailureReason: "insufficient funds",
+				FailedAt:      timestamppb.New(at),
+			},
+		},
+		{
+			name:  "Test 7. Positive. OrderItemsCancelled.",
+			event: models.OrderEvent{Type: models.OrderEventItemsCancelled, Order: order, Items: items},
+			want: &pb.OrderItemsCancelled{
+				OrderId:        orderID.String(),
+				UserId:         7,
+				CancelledItems: pbItems,
+				Items:          pbItems,
+				Totals:         pbTotals,
+				CancelledAt:    timestamppb.New(at),
+			},
+		},
+		{
+			name:  "Test 8. Positive. OrderReturnRequested.",
+			event: models.OrderEvent{Type: models.OrderEventReturnRequested, Order: order, Return: ret},
+			want: &pb.OrderReturnRequested{
+				OrderId:     orderID.String(),
+				UserId:      7,
+				ReturnId:    returnID.String(),
+				Items:       pbItems,
+				Refund:      pbRub(2000),
+				RequestedAt: timestamppb.New(at),
+			},
+		},
+		{
+			name:  "Test 9. Positive. OrderReturnApproved.",
+			event: models.OrderEvent{Type: models.OrderEventReturnApproved, Order: order, Return: ret},
+			want: &pb.OrderReturnApproved{
+				OrderId:    orderID.String(),
+				UserId:     7,
+				ReturnId:   returnID.String(),
+				Items:      pbItems,
+				Refund:     pbRub(2000),
+				ApprovedAt: timestamppb.New(at.Add(time.Hour)),
+			},
+		},
+		{
+			name:    "Test 10. Negative. OrderPaid without payment.",
+			event:   models.OrderEvent{Type: models.OrderEventPaid, Order: order, Transition: transition},
+			wantErr: true,
+		},
+		{
+			name:    "Test 11. Negative. OrderReturnApproved without return.",
+			event:   models.OrderEvent{Type: models.OrderEventReturnApproved, Order: order},
+			wantErr: true,
+		},
+		{
+			name:    "Test 12. Negative. Unknown event type.",
+			event:   models.OrderEvent{Type: "OrderTeleported", Order: order},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.event.ID = uuid.New()
+			tt.event.Version = models.OrderEventVersion
+			tt.event.OccurredAt = at
+
+			// act
+			data, err := marshalOrderEvent(tt.event)
+
+			// assert
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var envelope pb.EventEnvelope
+			require.NoError(t, proto.Unmarshal(data, &envelope))
+			assert.Equal(t, tt.event.ID.String(), envelope.GetEventId())
+			assert.Equal(t, string(tt.event.Type), envelope.GetType())
+			assert.Equal(t, models.OrderEventVersion, envelope.GetVersion())
+			assert.Equal(t, at, envelope.GetOccurredAt().AsTime())
+			assert.Equal(t, orderID.String(), envelope.GetAggregateId())
+
+			got := tt.want.ProtoReflect().New().Interface()
+			require.NoError(t, proto.Unmarshal(envelope.GetPayload(), got))
+			assert.True(t, proto.Equal(tt.want, got), "want: %v\ngot: %v", tt.want, got)
+		})
+	}
+}
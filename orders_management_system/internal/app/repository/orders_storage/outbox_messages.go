@@ -0,0 +1,68 @@
+package orders_storage
+
+import (
+	"context"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+type outboxMessageRow struct {
+	ID        int64     `db:"id"`
+	OrderID   uuid.UUID `db:"order_id"`
+	EventType string    `db:"event_type"`
+	Payload   []byte    `db:"payload"`
+}
+
+// ClaimOutboxMessages - захватывает пачку сообщений до конца транзакции.
+// Сообщения, захваченные другими экземплярами сервиса, пропускаются.
+func (r *OrdersStorage) ClaimOutboxMessages(ctx context.Context, limit uint64) ([]models.OutboxMessage, error) {
+	const api = "orders_storage.ClaimOutboxMessages"
+
+	// тип и конверт события - из журнала событий заказа, блокируем только сообщения outbox
+	query := squirrel.Select("m.id", "m.order_id", "e.type AS event_type", "e.payload").
+		From(tableOrdersOutboxMessagesName + " m").
+		Join(tableOrderEventsName + " e ON e.id = m.event_id").
+		OrderBy("m.id").
+		Limit(limit).
+		Suffix("FOR UPDATE OF m SKIP LOCKED").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []outboxMessageRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	messages := make([]models.OutboxMessage, len(rows))
+	for i := range rows {
+		messages[i] = models.OutboxMessage{
+			ID:        rows[i].ID,
+			OrderID:   models.OrderID(rows[i].OrderID),
+			EventType: models.OrderEventType(rows[i].EventType),
+			Payload:   rows[i].Payload,
+		}
+	}
+
+	return messages, nil
+}
+
+// DeleteOutboxMessages - удаляет опубликованные сообщения
+func (r *OrdersStorage) DeleteOutboxMessages(ctx context.Context, ids []int64) error {
+	const api = "orders_storage.DeleteOutboxMessages"
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query := squirrel.Delete(tableOrdersOutboxMessagesName).
+		Where(squirrel.Eq{"id": ids}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
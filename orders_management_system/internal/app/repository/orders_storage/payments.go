@@ -0,0 +1,152 @@
+package orders_storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+// колонки таблицы payments, которые читаем в paymentRow
+var paymentColumns = []string{
+	"id",
+	"order_id",
+	"amount",
+	"refunded_amount",
+	"currency",
+	"method",
+	"status",
+	"provider_payment_id",
+	"failure_reason",
+	"created_at",
+	"updated_at",
+}
+
+type paymentRow struct {
+	ID                uuid.UUID      `db:"id"`
+	OrderID           uuid.UUID      `db:"order_id"`
+	Amount            int64          `db:"amount"`
+	RefundedAmount    int64          `db:"refunded_amount"`
+	Currency          string         `db:"currency"`
+	Method            string         `db:"method"`
+	Status            string         `db:"status"`
+	ProviderPaymentID sql.NullString `db:"provider_payment_id"`
+	FailureReason     string         `db:"failure_reason"`
+	CreatedAt         time.Time      `db:"created_at"`
+	UpdatedAt         time.Time      `db:"updated_at"`
+}
+
+func (r *paymentRow) ToModelsPayment() *models.Payment {
+	return &models.Payment{
+		ID:                models.PaymentID(r.ID),
+		OrderID:           models.OrderID(r.OrderID),
+		Amount:            models.NewMoney(r.Amount, models.Currency(r.Currency)),
+		Refunded:          models.NewMoney(r.RefundedAmount, models.Currency(r.Currency)),
+		Method:            r.Method,
+		Status:            models.PaymentStatus(r.Status),
+		ProviderPaymentID: r.ProviderPaymentID.String,
+		FailureReason:     r.FailureReason,
+		CreatedAt:         r.CreatedAt,
+		UpdatedAt:         r.UpdatedAt,
+	}
+}
+
+func (r *OrdersStorage) CreatePayment(ctx context.Context, payment *models.Payment) error {
+	const api = "orders_storage.CreatePayment"
+
+	query := squirrel.Insert(tablePaymentsName).
+		Columns(paymentColumns...).
+		Values(
+			uuid.UUID(payment.ID),
+			uuid.UUID(payment.OrderID),
+			payment.Amount.Amount,
+			payment.Refunded.Amount,
+			string(payment.Amount.Currency),
+			payment.Method,
+			string(payment.Status),
+			sql.NullString{String: payment.ProviderPaymentID, Valid: payment.ProviderPaymentID != ""},
+			payment.FailureReason,
+			payment.CreatedAt,
+			payment.UpdatedAt,
+		).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) && pgError.Code == pgerrcode.UniqueViolation {
+			return pkgerrors.Wrap(api, models.ErrAlreadyExists)
+		}
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+func (r *OrdersStorage) LockPayment(ctx context.Context, paymentID models.PaymentID) (*models.Payment, error) {
+	const api = "orders_storage.LockPayment"
+
+	query := squirrel.Select(paymentColumns...).
+		From(tablePaymentsName).
+		Where(squirrel.Eq{"id": uuid.UUID(paymentID)}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var row paymentRow
+	if err := r.driver.GetQueryEngine(ctx).Getx(ctx, &row, query); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkgerrors.Wrap(api, models.ErrNotFound)
+		}
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return row.ToModelsPayment(), nil
+}
+
+func (r *OrdersStorage) UpdatePayment(ctx context.Context, payment *models.Payment) error {
+	const api = "orders_storage.UpdatePayment"
+
+	query := squirrel.Update(tablePaymentsName).
+		Set("status", string(payment.Status)).
+		Set("refunded_amount", payment.Refunded.Amount).
+		Set("provider_payment_id", sql.NullString{String: payment.ProviderPaymentID, Valid: payment.ProviderPaymentID != ""}).
+		Set("failure_reason", payment.FailureReason).
+		Set("updated_at", payment.UpdatedAt).
+		Where(squirrel.Eq{"id": uuid.UUID(payment.ID)}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+func (r *OrdersStorage) ListOrderPayments(ctx context.Context, orderID models.OrderID) ([]*models.Payment, error) {
+	const api = "orders_storage.ListOrderPayments"
+
+	query := squirrel.Select(paymentColumns...).
+		From(tablePaymentsName).
+		Where(squirrel.Eq{"order_id": uuid.UUID(orderID)}).
+		OrderBy("created_at").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []paymentRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	payments := make([]*models.Payment, 0, len(rows))
+	for i := range rows {
+		payments = append(payments, rows[i].ToModelsPayment())
+	}
+
+	return payments, nil
+}
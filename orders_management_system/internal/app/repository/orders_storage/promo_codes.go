@@ -0,0 +1,194 @@
+package orders_storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+// колонки таблицы promo_codes, которые читаем в promoCodeRow
+var promoCodeColumns = []string{
+	"code",
+	"rule_type",
+	"percent_off",
+	"amount_off",
+	"currency",
+	"sku_id",
+	"buy_quantity",
+	"pay_quantity",
+	"per_user_limit",
+	"global_limit",
+	"redemptions",
+	"valid_from",
+	"valid_to",
+	"active",
+}
+
+type promoCodeRow struct {
+	Code         string       `db:"code"`
+	RuleType     string       `db:"rule_type"`
+	PercentOff   int32        `db:"percent_off"`
+	AmountOff    int64        `db:"amount_off"`
+	Currency     string       `db:"currency"`
+	SKUID        int64        `db:"sku_id"`
+	BuyQuantity  int32        `db:"buy_quantity"`
+	PayQuantity  int32        `db:"pay_quantity"`
+	PerUserLimit int32        `db:"per_user_limit"`
+	GlobalLimit  int32        `db:"global_limit"`
+	Redemptions  int32        `db:"redemptions"`
+	ValidFrom    sql.NullTime `db:"valid_from"`
+	ValidTo      sql.NullTime `db:"valid_to"`
+	Active       bool         `db:"active"`
+}
+
+func (r *promoCodeRow) ToModelsPromoCode() *models.PromoCode {
+	return &models.PromoCode{
+		Code: r.Code,
+		Rule: models.DiscountRule{
+			Type:        models.DiscountType(r.RuleType),
+			PercentOff:  uint32(r.PercentOff),
+			AmountOff:   models.NewMoney(r.AmountOff, models.Currency(r.Currency)),
+			SKUID:       models.SKUID(r.SKUID),
+			BuyQuantity: uint32(r.BuyQuantity),
+			PayQuantity: uint32(r.PayQuantity),
+		},
+		PerUserLimit: uint32(r.PerUserLimit),
+		GlobalLimit:  uint32(r.GlobalLimit),
+		Redemptions:  uint32(r.Redemptions),
+		ValidFrom:    r.ValidFrom.Time,
+		ValidTo:      r.ValidTo.Time,
+		Active:       r.Active,
+	}
+}
+
+func (r *OrdersStorage) GetPromoCode(ctx context.Context, code string) (*models.PromoCode, error) {
+	const api = "orders_storage.GetPromoCode"
+
+	promo, err := r.getPromoCode(ctx, promoCodeQuery(code))
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+	return promo, nil
+}
+
+func (r *OrdersStorage) LockPromoCode(ctx context.Context, code string) (*models.PromoCode, error) {
+	const api = "orders_storage.LockPromoCode"
+
+	promo, err := r.getPromoCode(ctx, promoCodeQuery(code).Suffix("FOR UPDATE"))
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+	return promo, nil
+}
+
+func promoCodeQuery(code string) squirrel.SelectBuilder {
+	return squirrel.Select(promoCodeColumns...).
+		From(tablePromoCodesName).
+		Where(squirrel.Eq{"code": code}).
+		PlaceholderFormat(squirrel.Dollar)
+}
+
+func (r *OrdersStorage) getPromoCode(ctx context.Context, query squirrel.SelectBuilder) (*models.PromoCode, error) {
+	var row promoCodeRow
+	if err := r.driver.GetQueryEngine(ctx).Getx(ctx, &row, query); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, models.ErrNotFound
+		}
+		return nil, err
+	}
+	return row.ToModelsPromoCode(), nil
+}
+
+func (r *OrdersStorage) CountUserRedemptions(ctx context.Context, code string, userID models.UserID) (uint32, error) {
+	const api = "orders_storage.CountUserRedemptions"
+
+	query := squirrel.Select("count(*)").
+		From(tablePromoRedemptionsName).
+		Where(squirrel.Eq{
+			"code":    code,
+			"user_id": int64(userID),
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	var count int64
+	if err := r.driver.GetQueryEngine(ctx).Getx(ctx, &count, query); err != nil {
+		return 0, pkgerrors.Wrap(api, err)
+	}
+
+	return uint32(count), nil
+}
+
+func (r *OrdersStorage) CreateRedemption(ctx context.Context, redemption models.PromoRedemption) error {
+	const api = "orders_storage.CreateRedemption"
+
+	engine := r.driver.GetQueryEngine(ctx)
+
+	insert := squirrel.Insert(tablePromoRedemptionsName).
+		Columns("order_id", "code", "user_id", "discount_amount", "currency", "redeemed_at").
+		Values(
+			uuid.UUID(redemption.OrderID),
+			redemption.Code,
+			int64(redemption.UserID),
+			redemption.Discount.Amount,
+			string(redemption.Discount.Currency),
+			redemption.RedeemedAt,
+		).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := engine.Execx(ctx, insert); err != nil {
+		var pgError *pgconn.PgError
+		if errors.As(err, &pgError) && pgError.Code == pgerrcode.UniqueViolation {
+			return pkgerrors.Wrap(api, models.ErrAlreadyExists)
+		}
+		return pkgerrors.Wrap(api, err)
+	}
+
+	update := squirrel.Update(tablePromoCodesName).
+		Set("redemptions", squirrel.Expr("redemptions + 1")).
+		Where(squirrel.Eq{"code": redemption.Code}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := engine.Execx(ctx, update); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+func (r *OrdersStorage) DeleteRedemption(ctx context.Context, orderID models.OrderID) error {
+	const api = "orders_storage.DeleteRedemption"
+
+	engine := r.driver.GetQueryEngine(ctx)
+
+	remove := squirrel.Delete(tablePromoRedemptionsName).
+		Where(squirrel.Eq{"order_id": uuid.UUID(orderID)}).
+		Suffix("RETURNING code").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var codes []string
+	if err := engine.Selectx(ctx, &codes, remove); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+	if len(codes) == 0 {
+		return nil // применение уже отменено
+	}
+
+	update := squirrel.Update(tablePromoCodesName).
+		Set("redemptions", squirrel.Expr("greatest(redemptions - 1, 0)")).
+		Where(squirrel.Eq{"code": codes}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := engine.Execx(ctx, update); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
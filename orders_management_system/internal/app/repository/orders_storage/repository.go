@@ -3,11 +3,21 @@ package orders_storage
 import (
 	transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
 	oms "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/workers/orders_projection"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/workers/outbox_relay"
 )
 
 // Check that we implemet contract for usecase
 var (
-	_ oms.OrdersStorage = (*OrdersStorage)(nil)
+	_ oms.OrdersStorage                   = (*OrdersStorage)(nil)
+	_ oms.OrdersStorage                   = (*EventSourcedOrdersStorage)(nil)
+	_ oms.CancellationsStorage            = (*OrdersStorage)(nil)
+	_ oms.SagaStorage                     = (*OrdersStorage)(nil)
+	_ oms.PromoStorage                    = (*OrdersStorage)(nil)
+	_ oms.PaymentsStorage                 = (*OrdersStorage)(nil)
+	_ oms.ReturnsStorage                  = (*OrdersStorage)(nil)
+	_ outbox_relay.OutboxStorage          = (*OrdersStorage)(nil)
+	_ orders_projection.ProjectionStorage = (*OrdersStorage)(nil)
 )
 
 type OrdersStorage struct {
@@ -15,16 +25,51 @@ type OrdersStorage struct {
 	// connection Connection // если мокаете базу данных
 
 	driver transaction_manager.QueryEngineProvider
+
+	snapshotEvery uint64 // снимок состояния заказа после каждого snapshotEvery-го события потока (0 - без снимков)
+}
+
+// defaultSnapshotEvery - частота снимков состояния заказа по умолчанию
+const defaultSnapshotEvery = 20
+
+// Option - опция OrdersStorage
+type Option func(r *OrdersStorage)
+
+// WithSnapshotEvery - снимок состояния заказа после каждого n-го события его потока (0 - без снимков)
+func WithSnapshotEvery(n uint64) Option {
+	return func(r *OrdersStorage) {
+		r.snapshotEvery = n
+	}
 }
 
 // New - returns OrdersStorage
-func New( /*connection *postgres.Connection*/ driver transaction_manager.QueryEngineProvider) *OrdersStorage {
-	return &OrdersStorage{
+func New( /*connection *postgres.Connection*/ driver transaction_manager.QueryEngineProvider, opts ...Option) *OrdersStorage {
+	r := &OrdersStorage{
 		// connection: connection, // было
-		driver: driver, // стало
+		driver:        driver, // стало
+		snapshotEvery: defaultSnapshotEvery,
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
 }
 
 const (
-	tableOrdersName = "orders"
+	tableOrdersName                = "orders"
+	tableOrderItemsName            = "order_items"
+	tableOrdersStatusHistoryName   = "orders_status_history"
+	tableOrdersItemsHistoryName    = "orders_items_history"
+	tableOrderEventsName           = "order_events"
+	tableOrderSnapshotsName        = "order_snapshots"
+	tableOrderProjectionsName      = "order_projections"
+	tableOrdersIdempotencyKeysName = "orders_idempotency_keys"
+	tableOrdersOutboxMessagesName  = "orders_outbox_messages"
+	tableOrdersSagasName           = "orders_sagas"
+	tableOrderCancellationsName    = "order_cancellations"
+	tableOrderExpiryAttemptsName   = "order_expiry_attempts"
+	tablePromoCodesName            = "promo_codes"
+	tablePromoRedemptionsName      = "promo_redemptions"
+	tablePaymentsName              = "payments"
+	tableReturnsName               = "returns"
 )
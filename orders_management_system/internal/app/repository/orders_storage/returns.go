@@ -0,0 +1,158 @@
+package orders_storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+// колонки таблицы returns, которые читаем в returnRow
+var returnColumns = []string{
+	"id",
+	"order_id",
+	"user_id",
+	"items",
+	"reason",
+	"refund_amount",
+	"currency",
+	"status",
+	"created_at",
+	"updated_at",
+}
+
+type returnRow struct {
+	ID           uuid.UUID `db:"id"`
+	OrderID      uuid.UUID `db:"order_id"`
+	UserID       int64     `db:"user_id"`
+	Items        []byte    `db:"items"`
+	Reason       string    `db:"reason"`
+	RefundAmount int64     `db:"refund_amount"`
+	Currency     string    `db:"currency"`
+	Status       string    `db:"status"`
+	CreatedAt    time.Time `db:"created_at"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
+
+func (r *returnRow) ToModelsReturn() (*models.Return, error) {
+	var items []orderItem
+	if err := json.Unmarshal(r.Items, &items); err != nil {
+		return nil, pkgerrors.Wrap("returnRow.ToModelsReturn", err)
+	}
+
+	return &models.Return{
+		ID:        models.ReturnID(r.ID),
+		OrderID:   models.OrderID(r.OrderID),
+		UserID:    models.UserID(r.UserID),
+		Items:     newModelsItems(items),
+		Reason:    r.Reason,
+		Refund:    models.NewMoney(r.RefundAmount, models.Currency(r.Currency)),
+		Status:    models.ReturnStatus(r.Status),
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}, nil
+}
+
+func (r *OrdersStorage) CreateReturn(ctx context.Context, ret *models.Return) error {
+	const api = "orders_storage.CreateReturn"
+
+	items, err := json.Marshal(getOrderItems(ret.Items))
+	if err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	query := squirrel.Insert(tableReturnsName).
+		Columns(returnColumns...).
+		Values(
+			uuid.UUID(ret.ID),
+			uuid.UUID(ret.OrderID),
+			int64(ret.UserID),
+			items,
+			ret.Reason,
+			ret.Refund.Amount,
+			string(ret.Refund.Currency),
+			string(ret.Status),
+			ret.CreatedAt,
+			ret.UpdatedAt,
+		).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+func (r *OrdersStorage) LockReturn(ctx context.Context, returnID models.ReturnID) (*models.Return, error) {
+	const api = "orders_storage.LockReturn"
+
+	query := squirrel.Select(returnColumns...).
+		From(tableReturnsName).
+		Where(squirrel.Eq{"id": uuid.UUID(returnID)}).
+		Suffix("FOR UPDATE").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var row returnRow
+	if err := r.driver.GetQueryEngine(ctx).Getx(ctx, &row, query); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, pkgerrors.Wrap(api, models.ErrNotFound)
+		}
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	ret, err := row.ToModelsReturn()
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return ret, nil
+}
+
+func (r *OrdersStorage) UpdateReturn(ctx context.Context, ret *models.Return) error {
+	const api = "orders_storage.UpdateReturn"
+
+	query := squirrel.Update(tableReturnsName).
+		Set("status", string(ret.Status)).
+		Set("updated_at", ret.UpdatedAt).
+		Where(squirrel.Eq{"id": uuid.UUID(ret.ID)}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+func (r *OrdersStorage) ListOrderReturns(ctx context.Context, orderID models.OrderID) ([]*models.Return, error) {
+	const api = "orders_storage.ListOrderReturns"
+
+	query := squirrel.Select(returnColumns...).
+		From(tableReturnsName).
+		Where(squirrel.Eq{"order_id": uuid.UUID(orderID)}).
+		OrderBy("created_at").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []returnRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	returns := make([]*models.Return, 0, len(rows))
+	for i := range rows {
+		ret, err := rows[i].ToModelsReturn()
+		if err != nil {
+			return nil, pkgerrors.Wrap(api, err)
+		}
+		returns = append(returns, ret)
+	}
+
+	return returns, nil
+}
@@ -0,0 +1,82 @@
+package orders_storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	googleuuid "github.com/google/uuid"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/workflow"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+type sagaRow struct {
+	ID             uuid.UUID `db:"id"`
+	Name           string    `db:"name"`
+	Payload        []byte    `db:"payload"`
+	Status         string    `db:"status"`
+	CompletedSteps []string  `db:"completed_steps"`
+	Error          string    `db:"error"`
+	UpdatedAt      time.Time `db:"updated_at"`
+}
+
+// SaveWorkflowState - сохранение прогресса саги (upsert)
+func (r *OrdersStorage) SaveWorkflowState(ctx context.Context, state workflow.State) error {
+	const api = "orders_storage.SaveWorkflowState"
+
+	completedSteps := state.CompletedSteps
+	if completedSteps == nil {
+		completedSteps = []string{}
+	}
+
+	query := squirrel.Insert(tableOrdersSagasName).
+		Columns("id", "name", "payload", "status", "completed_steps", "error", "updated_at").
+		Values(uuid.UUID(state.ID), state.Name, state.Payload, string(state.Status), completedSteps, state.Error, state.UpdatedAt).
+		Suffix(`ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			completed_steps = EXCLUDED.completed_steps,
+			error = EXCLUDED.error,
+			updated_at = EXCLUDED.updated_at`).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := r.driver.GetQueryEngine(ctx).Execx(ctx, query); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+// ClaimStuckSagas - захватывает до конца транзакции незавершенные саги, которые не обновлялись с updatedBefore
+func (r *OrdersStorage) ClaimStuckSagas(ctx context.Context, updatedBefore time.Time, limit uint64) ([]workflow.State, error) {
+	const api = "orders_storage.ClaimStuckSagas"
+
+	query := squirrel.Select("id", "name", "payload", "status", "completed_steps", "error", "updated_at").
+		From(tableOrdersSagasName).
+		Where(squirrel.Eq{"status": []string{string(workflow.StatusRunning), string(workflow.StatusCompensating)}}).
+		Where(squirrel.Lt{"updated_at": updatedBefore}).
+		OrderBy("updated_at").
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED").
+		PlaceholderFormat(squirrel.Dollar)
+
+	var rows []sagaRow
+	if err := r.driver.GetQueryEngine(ctx).Selectx(ctx, &rows, query); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	states := make([]workflow.State, len(rows))
+	for i := range rows {
+		states[i] = workflow.State{
+			ID:             googleuuid.UUID(rows[i].ID),
+			Name:           rows[i].Name,
+			Payload:        rows[i].Payload,
+			Status:         workflow.Status(rows[i].Status),
+			CompletedSteps: rows[i].CompletedSteps,
+			Error:          rows[i].Error,
+			UpdatedAt:      rows[i].UpdatedAt,
+		}
+	}
+
+	return states, nil
+}
@@ -0,0 +1,60 @@
+package orders_storage
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	uuid "github.com/vgarvardt/pgx-google-uuid/v5"
+)
+
+func (r *OrdersStorage) UpdateOrderStatus(ctx context.Context, transition models.OrderStatusTransition) error {
+	const api = "orders_storage.UpdateOrderStatus"
+
+	// Обновляем статус только если заказ все еще в исходном статусе (защита от гонок)
+	query := squirrel.Update(tableOrdersName).
+		Set("status", string(transition.To)).
+		Set("status_changed_at", transition.ChangedAt).
+		Where(squirrel.Eq{
+			"id":     uuid.UUID(transition.OrderID),
+			"status": string(transition.From),
+		}).
+		PlaceholderFormat(squirrel.Dollar)
+
+	engine := r.driver.GetQueryEngine(ctx)
+
+	tag, err := engine.Execx(ctx, query)
+	if err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+	if tag.RowsAffected() == 0 {
+		return pkgerrors.Wrap(api, models.ErrInvalidStatusTransition)
+	}
+
+	if err := insertOrderStatusTransition(ctx, engine, transition); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+func insertOrderStatusTransition(ctx context.Context, engine transaction_manager.QueryEngine, transition models.OrderStatusTransition) error {
+	query := squirrel.Insert(tableOrdersStatusHistoryName).
+		Columns("order_id", "from_status", "to_status", "changed_at").
+		Values(
+			uuid.UUID(transition.OrderID),
+			sql.NullString{String: string(transition.From), Valid: transition.From != ""},
+			string(transition.To),
+			transition.ChangedAt,
+		).
+		PlaceholderFormat(squirrel.Dollar)
+
+	if _, err := engine.Execx(ctx, query); err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+)
+
+func (s *Server) AddToBasket(ctx context.Context, req *pb.AddToBasketRequest) (*pb.AddToBasketResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	item := models.Item{
+		SKU:         models.SKU{ID: models.SKUID(req.GetSkuId())},
+		Quantity:    req.GetQuantity(),
+		WarehouseID: models.WarehouseID(req.GetWarehouseId()),
+	}
+
+	// 3. call usecase
+	basket, err := s.OMSUsecase.AddToBasket(ctx, models.UserID(req.GetUserId()), item)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.AddToBasketResponse{
+		Basket: newPbBasketFromModelsBasket(basket),
+	}, nil
+}
@@ -0,0 +1,38 @@
+package server
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+)
+
+func (s *Server) ApplyPromoCode(ctx context.Context, req *pb.ApplyPromoCodeRequest) (*pb.ApplyPromoCodeResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	info := orders_management_system.PreviewOrderInfo{
+		Items:             newModelsItemsFromPbPreviewItems(req.GetItems()),
+		DeliveryVariantID: models.DeliveryVariantID(req.GetDeliveryVariantId()),
+		PromoCode:         req.GetPromoCode(),
+	}
+
+	// 3. call usecase
+	preview, err := s.OMSUsecase.PreviewOrder(ctx, models.UserID(req.GetUserId()), info)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.ApplyPromoCodeResponse{
+		PromoCode: req.GetPromoCode(),
+		Discount:  newPbMoneyFromModelsMoney(preview.Totals.Discount),
+		Totals:    newPbOrderTotalsFromModelsOrderTotals(preview.Totals),
+	}, nil
+}
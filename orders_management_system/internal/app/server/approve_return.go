@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+)
+
+func (s *Server) ApproveReturn(ctx context.Context, req *pb.ApproveReturnRequest) (*pb.ApproveReturnResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	returnID, err := uuid.Parse(req.GetReturnId())
+	if err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 3. call usecase
+	ret, err := s.OMSUsecase.ApproveReturn(ctx, models.ReturnID(returnID))
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.ApproveReturnResponse{
+		Return: newPbReturnFromModelsReturn(ret),
+	}, nil
+}
@@ -0,0 +1,35 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+)
+
+func (s *Server) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.CancelOrderResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	orderID, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 3. call usecase
+	order, err := s.OMSUsecase.CancelOrder(ctx, models.OrderID(orderID), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.CancelOrderResponse{
+		Order: newPbOrderFromModelsOrder(order),
+	}, nil
+}
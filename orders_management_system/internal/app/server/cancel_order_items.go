@@ -0,0 +1,46 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+)
+
+func (s *Server) CancelOrderItems(ctx context.Context, req *pb.CancelOrderItemsRequest) (*pb.CancelOrderItemsResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	orderID, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	items := make([]models.Item, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		items = append(items, models.Item{
+			SKU:         models.SKU{ID: models.SKUID(item.GetSkuId())},
+			Quantity:    item.GetQuantity(),
+			WarehouseID: models.WarehouseID(item.GetWarehouseId()),
+		})
+	}
+
+	// 3. call usecase
+	res, err := s.OMSUsecase.CancelOrderItems(ctx, models.OrderID(orderID), items)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.CancelOrderItemsResponse{
+		Order:          newPbOrderFromModelsOrder(res.Order),
+		CancelledItems: newPbOrderItemsFromModelsItems(res.CancelledItems),
+		Refund:         newPbMoneyFromModelsMoney(res.Refund),
+	}, nil
+}
@@ -0,0 +1,75 @@
+//go:build test
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/server/mocks"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_CancelOrderItems(t *testing.T) {
+	var (
+		ctx     = context.Background() // dummy
+		rub     = func(amount int64) models.Money { return models.NewMoney(amount, models.CurrencyRUB) }
+		orderID = models.OrderID(uuid.New())
+	)
+
+	tests := []struct {
+		name     string
+		req      *pb.CancelOrderItemsRequest
+		wantCode codes.Code
+
+		on func(*mocks.UsecaseInterface)
+	}{
+		{
+			name: "Test 1. Positive. Valid request reaches usecase.",
+			req: &pb.CancelOrderItemsRequest{
+				OrderId: orderID.String(),
+				Items:   []*pb.CancelOrderItemsRequest_Item{{SkuId: 2, Quantity: 1, WarehouseId: 10}},
+			},
+			wantCode: codes.OK,
+
+			on: func(u *mocks.UsecaseInterface) {
+				u.On("CancelOrderItems", ctx, orderID, []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 1, WarehouseID: 10}}).
+					Return(&orders_management_system.CancelOrderItemsResult{
+						Order:          &models.Order{ID: orderID, Status: models.OrderStatusPaid},
+						CancelledItems: []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 1, WarehouseID: 10, Price: rub(500)}},
+						Refund:         rub(500),
+					}, nil)
+			},
+		},
+		{
+			name:     "Test 2. Negative. Zero quantity.",
+			req:      &pb.CancelOrderItemsRequest{OrderId: orderID.String(), Items: []*pb.CancelOrderItemsRequest_Item{{SkuId: 2}}},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			usecase := mocks.NewUsecaseInterface(t)
+			if tt.on != nil {
+				tt.on(usecase)
+			}
+			s := newTestServer(t, usecase)
+
+			// act
+			got, err := s.CancelOrderItems(ctx, tt.req)
+
+			// assert
+			assert.Equal(t, tt.wantCode, status.Code(err), "error: %v", err)
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, int64(500), got.GetRefund().GetAmount())
+			}
+		})
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
 	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
 	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 func (s *Server) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.CreateOrderResponse, error) {
@@ -18,6 +20,12 @@ func (s *Server) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*
 	// 2. convert delivery models to DTO/Entity models
 
 	createOrderInfo := createOrderInfoFromPbCreateOrderRequest(req)
+	if createOrderInfo.IdempotencyKey == "" {
+		createOrderInfo.IdempotencyKey = idempotencyKeyFromContext(ctx)
+		if len(createOrderInfo.IdempotencyKey) > maxIdempotencyKeyLen {
+			return nil, status.Errorf(codes.InvalidArgument, "%s is too long", idempotencyKeyHeader)
+		}
+	}
 
 	// 3. call usecase
 	order, err := s.OMSUsecase.CreateOrder(ctx, models.UserID(req.GetUserId()), createOrderInfo)
@@ -31,7 +39,9 @@ func (s *Server) CreateOrder(ctx context.Context, req *pb.CreateOrderRequest) (*
 
 	// 5. send response
 	return &pb.CreateOrderResponse{
-		OrderId: order.ID.String(),
+		OrderId:    order.ID.String(),
+		Allocation: newPbOrderItemsFromModelsItems(order.Items),
+		Totals:     newPbOrderTotalsFromModelsOrderTotals(order.Totals),
 	}, nil
 }
 
@@ -43,7 +53,7 @@ func createOrderInfoFromPbCreateOrderRequest(req *pb.CreateOrderRequest) orders_
 				ID: models.SKUID(item.GetId()),
 			},
 			Quantity:    item.GetQuantity(),
-			WarehouseID: models.WarehouseID(item.GetQuantity()),
+			WarehouseID: models.WarehouseID(item.GetWarehouseId()), // 0 - склад выберет usecase
 		})
 	}
 
@@ -54,6 +64,8 @@ func createOrderInfoFromPbCreateOrderRequest(req *pb.CreateOrderRequest) orders_
 			DeliveryVariantID: models.DeliveryVariantID(deliveryInfo.GetDeliveryVariantId()),
 			DeliveryDate:      deliveryInfo.GetDeliveryDate().AsTime(),
 		},
-		Items: items,
+		Items:          items,
+		IdempotencyKey: req.GetIdempotencyKey(),
+		PromoCode:      req.GetPromoCode(),
 	}
 }
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) CreateOrderFromBasket(ctx context.Context, req *pb.CreateOrderFromBasketRequest) (*pb.CreateOrderFromBasketResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	deliveryInfo := req.GetDeliveryInfo()
+	info := orders_management_system.CreateOrderFromBasketInfo{
+		DeliveryOrderInfo: models.DeliveryOrderInfo{
+			DeliveryVariantID: models.DeliveryVariantID(deliveryInfo.GetDeliveryVariantId()),
+			DeliveryDate:      deliveryInfo.GetDeliveryDate().AsTime(),
+		},
+		IdempotencyKey: req.GetIdempotencyKey(),
+		PromoCode:      req.GetPromoCode(),
+	}
+	if info.IdempotencyKey == "" {
+		info.IdempotencyKey = idempotencyKeyFromContext(ctx)
+		if len(info.IdempotencyKey) > maxIdempotencyKeyLen {
+			return nil, status.Errorf(codes.InvalidArgument, "%s is too long", idempotencyKeyHeader)
+		}
+	}
+
+	// 3. call usecase
+	order, err := s.OMSUsecase.CreateOrderFromBasket(ctx, models.UserID(req.GetUserId()), info)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.CreateOrderFromBasketResponse{
+		Order: newPbOrderFromModelsOrder(order),
+	}, nil
+}
@@ -0,0 +1,47 @@
+package server
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) GetBasket(ctx context.Context, req *pb.GetBasketRequest) (*pb.GetBasketResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	// 3. call usecase
+	basket, err := s.OMSUsecase.GetBasket(ctx, models.UserID(req.GetUserId()))
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.GetBasketResponse{
+		Basket: newPbBasketFromModelsBasket(basket),
+	}, nil
+}
+
+func newPbBasketFromModelsBasket(basket *models.Basket) *pb.Basket {
+	items := make([]*pb.Basket_Item, 0, len(basket.Items))
+	for _, item := range basket.Items {
+		items = append(items, &pb.Basket_Item{
+			SkuId:       uint64(item.SKU.ID),
+			Quantity:    item.Quantity,
+			WarehouseId: uint64(item.WarehouseID),
+			UpdatedAt:   timestamppb.New(item.UpdatedAt),
+		})
+	}
+
+	return &pb.Basket{
+		UserId: uint64(basket.UserID),
+		Items:  items,
+	}
+}
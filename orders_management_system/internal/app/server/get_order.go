@@ -0,0 +1,86 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *Server) GetOrder(ctx context.Context, req *pb.GetOrderRequest) (*pb.GetOrderResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	orderID, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 3. call usecase
+	order, err := s.OMSUsecase.GetOrder(ctx, models.OrderID(orderID))
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.GetOrderResponse{
+		Order: newPbOrderFromModelsOrder(order),
+	}, nil
+}
+
+func newPbOrderFromModelsOrder(order *models.Order) *pb.Order {
+	deliveryInfo := &pb.Order_DeliveryInfo{
+		DeliveryVariantId: uint64(order.DeliveryVariantID),
+	}
+	if !order.DeliveryDate.IsZero() {
+		deliveryInfo.DeliveryDate = timestamppb.New(order.DeliveryDate)
+	}
+
+	return &pb.Order{
+		OrderId:         order.ID.String(),
+		UserId:          uint64(order.UserID),
+		Items:           newPbOrderItemsFromModelsItems(order.Items),
+		DeliveryInfo:    deliveryInfo,
+		CreatedAt:       timestamppb.New(order.CreatedAt),
+		Status:          newPbOrderStatusFromModelsOrderStatus(order.Status),
+		StatusChangedAt: timestamppb.New(order.StatusChangedAt),
+		Totals:          newPbOrderTotalsFromModelsOrderTotals(order.Totals),
+		PromoCode:       order.PromoCode,
+	}
+}
+
+func newPbOrderItemsFromModelsItems(items []models.Item) []*pb.Order_Item {
+	res := make([]*pb.Order_Item, 0, len(items))
+	for _, item := range items {
+		res = append(res, &pb.Order_Item{
+			SkuId:       uint64(item.SKU.ID),
+			Quantity:    item.Quantity,
+			WarehouseId: uint64(item.WarehouseID),
+			UnitPrice:   newPbMoneyFromModelsMoney(item.Price),
+		})
+	}
+	return res
+}
+
+func newPbOrderTotalsFromModelsOrderTotals(totals models.OrderTotals) *pb.OrderTotals {
+	return &pb.OrderTotals{
+		Subtotal: newPbMoneyFromModelsMoney(totals.Subtotal),
+		Discount: newPbMoneyFromModelsMoney(totals.Discount),
+		Delivery: newPbMoneyFromModelsMoney(totals.Delivery),
+		Total:    newPbMoneyFromModelsMoney(totals.Total),
+	}
+}
+
+func newPbMoneyFromModelsMoney(money models.Money) *pb.Money {
+	return &pb.Money{
+		Amount:       money.Amount,
+		CurrencyCode: string(money.Currency),
+	}
+}
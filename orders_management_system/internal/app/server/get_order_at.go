@@ -0,0 +1,36 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+)
+
+func (s *Server) GetOrderAt(ctx context.Context, req *pb.GetOrderAtRequest) (*pb.GetOrderAtResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	orderID, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 3. call usecase
+	order, err := s.OMSUsecase.GetOrderAt(ctx, models.OrderID(orderID), req.GetAt().AsTime())
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.GetOrderAtResponse{
+		Order:   newPbOrderFromModelsOrder(order),
+		Version: order.Version,
+	}, nil
+}
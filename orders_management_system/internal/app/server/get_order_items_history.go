@@ -0,0 +1,54 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var pbOrderItemChangeReasonByModelsReason = map[models.OrderItemChangeReason]pb.OrderItemChangeReason{
+	models.OrderItemCreated:   pb.OrderItemChangeReason_ORDER_ITEM_CHANGE_REASON_CREATED,
+	models.OrderItemCancelled: pb.OrderItemChangeReason_ORDER_ITEM_CHANGE_REASON_CANCELLED,
+}
+
+func (s *Server) GetOrderItemsHistory(ctx context.Context, req *pb.GetOrderItemsHistoryRequest) (*pb.GetOrderItemsHistoryResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	orderID, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 3. call usecase
+	changes, err := s.OMSUsecase.GetOrderItemsHistory(ctx, models.OrderID(orderID))
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	resp := &pb.GetOrderItemsHistoryResponse{
+		Changes: make([]*pb.OrderItemChange, 0, len(changes)),
+	}
+	for _, change := range changes {
+		resp.Changes = append(resp.Changes, &pb.OrderItemChange{
+			SkuId:        uint64(change.SKUID),
+			WarehouseId:  uint64(change.WarehouseID),
+			UnitPrice:    newPbMoneyFromModelsMoney(change.Price),
+			FromQuantity: change.FromQuantity,
+			ToQuantity:   change.ToQuantity,
+			Reason:       pbOrderItemChangeReasonByModelsReason[change.Reason],
+			ChangedAt:    timestamppb.New(change.ChangedAt),
+		})
+	}
+
+	// 5. send response
+	return resp, nil
+}
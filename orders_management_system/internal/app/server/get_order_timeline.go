@@ -0,0 +1,56 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var pbActorTypeByModelsActorType = map[models.ActorType]pb.ActorType{
+	models.ActorSystem:          pb.ActorType_ACTOR_TYPE_SYSTEM,
+	models.ActorCustomer:        pb.ActorType_ACTOR_TYPE_CUSTOMER,
+	models.ActorSupport:         pb.ActorType_ACTOR_TYPE_SUPPORT,
+	models.ActorPaymentProvider: pb.ActorType_ACTOR_TYPE_PAYMENT_PROVIDER,
+}
+
+func (s *Server) GetOrderTimeline(ctx context.Context, req *pb.GetOrderTimelineRequest) (*pb.GetOrderTimelineResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	orderID, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 3. call usecase
+	entries, err := s.OMSUsecase.GetOrderTimeline(ctx, models.OrderID(orderID))
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	resp := &pb.GetOrderTimelineResponse{
+		Events: make([]*pb.OrderTimelineEvent, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		resp.Events = append(resp.Events, &pb.OrderTimelineEvent{
+			EventId:    entry.EventID.String(),
+			Type:       string(entry.Type),
+			Status:     newPbOrderStatusFromModelsOrderStatus(entry.Status),
+			ActorType:  pbActorTypeByModelsActorType[entry.Actor.Type],
+			ActorId:    entry.Actor.ID,
+			Reason:     entry.Reason,
+			OccurredAt: timestamppb.New(entry.OccurredAt),
+		})
+	}
+
+	// 5. send response
+	return resp, nil
+}
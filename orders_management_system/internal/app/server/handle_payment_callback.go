@@ -0,0 +1,44 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+)
+
+var modelsPaymentResultByPbResult = map[pb.HandlePaymentCallbackRequest_Result]models.PaymentResult{
+	pb.HandlePaymentCallbackRequest_RESULT_AUTHORIZED: models.PaymentResultAuthorized,
+	pb.HandlePaymentCallbackRequest_RESULT_FAILED:     models.PaymentResultFailed,
+}
+
+func (s *Server) HandlePaymentCallback(ctx context.Context, req *pb.HandlePaymentCallbackRequest) (*pb.HandlePaymentCallbackResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	paymentID, err := uuid.Parse(req.GetPaymentId())
+	if err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 3. call usecase
+	err = s.OMSUsecase.HandlePaymentCallback(ctx, models.PaymentCallback{
+		PaymentID:         models.PaymentID(paymentID),
+		ProviderPaymentID: req.GetProviderPaymentId(),
+		Result:            modelsPaymentResultByPbResult[req.GetResult()],
+		FailureReason:     req.GetFailureReason(),
+		Signature:         req.GetSignature(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.HandlePaymentCallbackResponse{}, nil
+}
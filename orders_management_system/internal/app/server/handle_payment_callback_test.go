@@ -0,0 +1,81 @@
+//go:build test
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/server/mocks"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_HandlePaymentCallback(t *testing.T) {
+	var (
+		ctx       = context.Background() // dummy
+		paymentID = models.PaymentID(uuid.New())
+	)
+
+	tests := []struct {
+		name     string
+		req      *pb.HandlePaymentCallbackRequest
+		wantCode codes.Code
+
+		on func(*mocks.UsecaseInterface)
+	}{
+		{
+			name: "Test 1. Positive. Valid request reaches usecase.",
+			req: &pb.HandlePaymentCallbackRequest{
+				PaymentId:         paymentID.String(),
+				ProviderPaymentId: "fake-1",
+				Result:            pb.HandlePaymentCallbackRequest_RESULT_AUTHORIZED,
+				Signature:         "c0ffee",
+			},
+			wantCode: codes.OK,
+
+			on: func(u *mocks.UsecaseInterface) {
+				u.On("HandlePaymentCallback", ctx, models.PaymentCallback{
+					PaymentID:         paymentID,
+					ProviderPaymentID: "fake-1",
+					Result:            models.PaymentResultAuthorized,
+					Signature:         "c0ffee",
+				}).Return(nil)
+			},
+		},
+		{
+			name:     "Test 2. Negative. No provider payment id.",
+			req:      &pb.HandlePaymentCallbackRequest{PaymentId: paymentID.String(), Result: pb.HandlePaymentCallbackRequest_RESULT_FAILED, Signature: "c0ffee"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name: "Test 3. Negative. No signature.",
+			req: &pb.HandlePaymentCallbackRequest{
+				PaymentId:         paymentID.String(),
+				ProviderPaymentId: "fake-1",
+				Result:            pb.HandlePaymentCallbackRequest_RESULT_AUTHORIZED,
+			},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			usecase := mocks.NewUsecaseInterface(t)
+			if tt.on != nil {
+				tt.on(usecase)
+			}
+			s := newTestServer(t, usecase)
+
+			// act
+			_, err := s.HandlePaymentCallback(ctx, tt.req)
+
+			// assert
+			assert.Equal(t, tt.wantCode, status.Code(err), "error: %v", err)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/middleware/actor"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// idempotencyKeyHeader - HTTP заголовок с ключом идемпотентности
+	idempotencyKeyHeader = "Idempotency-Key"
+	// idempotencyKeyMetadata - gRPC metadata с ключом идемпотентности
+	idempotencyKeyMetadata = "idempotency-key"
+	// maxIdempotencyKeyLen - максимальная длина ключа (как у поля idempotency_key в CreateOrderRequest)
+	maxIdempotencyKeyLen = 128
+
+	// actorTypeHeader, actorIDHeader - HTTP заголовки с инициатором запроса (см. middleware/actor)
+	actorTypeHeader = "X-Actor-Type"
+	actorIDHeader   = "X-Actor-Id"
+)
+
+// idempotencyKeyFromContext - достает ключ идемпотентности из входящей gRPC metadata
+func idempotencyKeyFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	if values := md.Get(idempotencyKeyMetadata); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// incomingHeaderMatcher - пробрасывает заголовки Idempotency-Key и X-Actor-* из grpc gateway в metadata
+func incomingHeaderMatcher(key string) (string, bool) {
+	switch {
+	case strings.EqualFold(key, idempotencyKeyHeader):
+		return idempotencyKeyMetadata, true
+	case strings.EqualFold(key, actorTypeHeader):
+		return actor.TypeMetadata, true
+	case strings.EqualFold(key, actorIDHeader):
+		return actor.IDMetadata, true
+	}
+	return runtime.DefaultHeaderMatcher(key)
+}
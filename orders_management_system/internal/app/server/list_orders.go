@@ -0,0 +1,55 @@
+package server
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+)
+
+func (s *Server) ListOrders(ctx context.Context, req *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	filter := listOrdersFilterFromPbListOrdersRequest(req)
+
+	// 3. call usecase
+	result, err := s.OMSUsecase.ListOrders(ctx, models.UserID(req.GetUserId()), filter)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	orders := make([]*pb.Order, 0, len(result.Orders))
+	for _, order := range result.Orders {
+		orders = append(orders, newPbOrderFromModelsOrder(order))
+	}
+
+	// 5. send response
+	return &pb.ListOrdersResponse{
+		Orders:        orders,
+		NextPageToken: result.NextPageToken,
+	}, nil
+}
+
+func listOrdersFilterFromPbListOrdersRequest(req *pb.ListOrdersRequest) orders_management_system.ListOrdersFilter {
+	filter := orders_management_system.ListOrdersFilter{
+		Statuses:  newModelsOrderStatusesFromPbOrderStatuses(req.GetFilter().GetStatuses()),
+		PageSize:  req.GetPageSize(),
+		PageToken: req.GetPageToken(),
+	}
+
+	if from := req.GetFilter().GetDeliveryDateFrom(); from != nil {
+		filter.DeliveryDateFrom = from.AsTime()
+	}
+	if to := req.GetFilter().GetDeliveryDateTo(); to != nil {
+		filter.DeliveryDateTo = to.AsTime()
+	}
+
+	return filter
+}
@@ -0,0 +1,21 @@
+package server
+
+import (
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/converters"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+)
+
+func newPbOrderStatusFromModelsOrderStatus(status models.OrderStatus) pb.OrderStatus {
+	return converters.NewPbOrderStatusFromModelsOrderStatus(status)
+}
+
+func newModelsOrderStatusesFromPbOrderStatuses(statuses []pb.OrderStatus) []models.OrderStatus {
+	res := make([]models.OrderStatus, 0, len(statuses))
+	for _, status := range statuses {
+		if s, ok := converters.NewModelsOrderStatusFromPbOrderStatus(status); ok {
+			res = append(res, s)
+		}
+	}
+	return res
+}
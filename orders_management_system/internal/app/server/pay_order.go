@@ -0,0 +1,58 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var pbPaymentStatusByModelsPaymentStatus = map[models.PaymentStatus]pb.PaymentStatus{
+	models.PaymentStatusPending:    pb.PaymentStatus_PAYMENT_STATUS_PENDING,
+	models.PaymentStatusAuthorized: pb.PaymentStatus_PAYMENT_STATUS_AUTHORIZED,
+	models.PaymentStatusCaptured:   pb.PaymentStatus_PAYMENT_STATUS_CAPTURED,
+	models.PaymentStatusFailed:     pb.PaymentStatus_PAYMENT_STATUS_FAILED,
+	models.PaymentStatusVoided:     pb.PaymentStatus_PAYMENT_STATUS_VOIDED,
+	models.PaymentStatusRefunded:   pb.PaymentStatus_PAYMENT_STATUS_REFUNDED,
+}
+
+func (s *Server) PayOrder(ctx context.Context, req *pb.PayOrderRequest) (*pb.PayOrderResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	orderID, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 3. call usecase
+	res, err := s.OMSUsecase.PayOrder(ctx, models.OrderID(orderID), req.GetPaymentMethod())
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.PayOrderResponse{
+		Order:   newPbOrderFromModelsOrder(res.Order),
+		Payment: newPbPaymentFromModelsPayment(res.Payment),
+	}, nil
+}
+
+func newPbPaymentFromModelsPayment(payment *models.Payment) *pb.Payment {
+	return &pb.Payment{
+		PaymentId:         payment.ID.String(),
+		OrderId:           payment.OrderID.String(),
+		Amount:            newPbMoneyFromModelsMoney(payment.Amount),
+		Status:            pbPaymentStatusByModelsPaymentStatus[payment.Status],
+		ProviderPaymentId: payment.ProviderPaymentID,
+		FailureReason:     payment.FailureReason,
+		CreatedAt:         timestamppb.New(payment.CreatedAt),
+	}
+}
@@ -0,0 +1,73 @@
+//go:build test
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/server/mocks"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_PreviewOrder(t *testing.T) {
+	var (
+		ctx   = context.Background() // dummy
+		rub   = func(amount int64) models.Money { return models.NewMoney(amount, models.CurrencyRUB) }
+		items = []*pb.PreviewOrderRequest_Item{{SkuId: 2, Quantity: 3}}
+	)
+
+	tests := []struct {
+		name     string
+		req      *pb.PreviewOrderRequest
+		wantCode codes.Code
+
+		on func(*mocks.UsecaseInterface)
+	}{
+		{
+			name:     "Test 1. Positive. Valid request reaches usecase.",
+			req:      &pb.PreviewOrderRequest{UserId: 1, Items: items, DeliveryVariantId: 1, PromoCode: "FREESHIP"},
+			wantCode: codes.OK,
+
+			on: func(u *mocks.UsecaseInterface) {
+				u.On("PreviewOrder", ctx, models.UserID(1), orders_management_system.PreviewOrderInfo{
+					Items:             []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3}},
+					DeliveryVariantID: 1,
+					PromoCode:         "FREESHIP",
+				}).Return(&orders_management_system.OrderPreview{
+					Items:  []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, Price: rub(1000)}},
+					Totals: models.OrderTotals{Subtotal: rub(3000), Discount: rub(300), Delivery: rub(300), Total: rub(3000)},
+				}, nil)
+			},
+		},
+		{
+			name:     "Test 2. Negative. No items.",
+			req:      &pb.PreviewOrderRequest{UserId: 1, DeliveryVariantId: 1},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			usecase := mocks.NewUsecaseInterface(t)
+			if tt.on != nil {
+				tt.on(usecase)
+			}
+			s := newTestServer(t, usecase)
+
+			// act
+			got, err := s.PreviewOrder(ctx, tt.req)
+
+			// assert
+			assert.Equal(t, tt.wantCode, status.Code(err), "error: %v", err)
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, int64(3000), got.GetTotals().GetTotal().GetAmount())
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package server
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+)
+
+func (s *Server) RemoveFromBasket(ctx context.Context, req *pb.RemoveFromBasketRequest) (*pb.RemoveFromBasketResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	// 3. call usecase
+	basket, err := s.OMSUsecase.RemoveFromBasket(ctx, models.UserID(req.GetUserId()), models.SKUID(req.GetSkuId()))
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.RemoveFromBasketResponse{
+		Basket: newPbBasketFromModelsBasket(basket),
+	}, nil
+}
@@ -0,0 +1,67 @@
+package server
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	grpcutils "github.com/moguchev/microservices_courcse/orders_management_system/pkg/grpc_utils"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var pbReturnStatusByModelsReturnStatus = map[models.ReturnStatus]pb.ReturnStatus{
+	models.ReturnStatusRequested: pb.ReturnStatus_RETURN_STATUS_REQUESTED,
+	models.ReturnStatusApproved:  pb.ReturnStatus_RETURN_STATUS_APPROVED,
+	models.ReturnStatusRejected:  pb.ReturnStatus_RETURN_STATUS_REJECTED,
+}
+
+func (s *Server) RequestReturn(ctx context.Context, req *pb.RequestReturnRequest) (*pb.RequestReturnResponse, error) {
+	// 1. validation
+	if err := s.validator.Validate(req); err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	// 2. convert delivery models to DTO/Entity models
+	orderID, err := uuid.Parse(req.GetOrderId())
+	if err != nil {
+		return nil, grpcutils.RPCValidationError(err)
+	}
+
+	items := make([]models.Item, 0, len(req.GetItems()))
+	for _, item := range req.GetItems() {
+		items = append(items, models.Item{
+			SKU:      models.SKU{ID: models.SKUID(item.GetSkuId())},
+			Quantity: item.GetQuantity(),
+		})
+	}
+
+	// 3. call usecase
+	ret, err := s.OMSUsecase.RequestReturn(ctx, models.OrderID(orderID), orders_management_system.RequestReturnInfo{
+		Items:  items,
+		Reason: req.GetReason(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. convert DTO/Entity models to delivery models
+	// 5. send response
+	return &pb.RequestReturnResponse{
+		Return: newPbReturnFromModelsReturn(ret),
+	}, nil
+}
+
+func newPbReturnFromModelsReturn(ret *models.Return) *pb.Return {
+	return &pb.Return{
+		ReturnId:  ret.ID.String(),
+		OrderId:   ret.OrderID.String(),
+		UserId:    uint64(ret.UserID),
+		Items:     newPbOrderItemsFromModelsItems(ret.Items),
+		Reason:    ret.Reason,
+		Refund:    newPbMoneyFromModelsMoney(ret.Refund),
+		Status:    pbReturnStatusByModelsReturnStatus[ret.Status],
+		CreatedAt: timestamppb.New(ret.CreatedAt),
+	}
+}
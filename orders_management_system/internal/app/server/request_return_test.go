@@ -0,0 +1,71 @@
+//go:build test
+
+package server
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/server/mocks"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestServer_RequestReturn(t *testing.T) {
+	var (
+		ctx      = context.Background() // dummy
+		orderID  = models.OrderID(uuid.New())
+		returnID = models.ReturnID(uuid.New())
+		items    = []*pb.RequestReturnRequest_Item{{SkuId: 2, Quantity: 1}}
+	)
+
+	tests := []struct {
+		name     string
+		req      *pb.RequestReturnRequest
+		wantCode codes.Code
+
+		on func(*mocks.UsecaseInterface)
+	}{
+		{
+			name:     "Test 1. Positive. Valid request reaches usecase.",
+			req:      &pb.RequestReturnRequest{OrderId: orderID.String(), Items: items, Reason: "broken"},
+			wantCode: codes.OK,
+
+			on: func(u *mocks.UsecaseInterface) {
+				u.On("RequestReturn", ctx, orderID, orders_management_system.RequestReturnInfo{
+					Items:  []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 1}},
+					Reason: "broken",
+				}).Return(&models.Return{ID: returnID, OrderID: orderID, Status: models.ReturnStatusRequested}, nil)
+			},
+		},
+		{
+			name:     "Test 2. Negative. No items.",
+			req:      &pb.RequestReturnRequest{OrderId: orderID.String()},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			usecase := mocks.NewUsecaseInterface(t)
+			if tt.on != nil {
+				tt.on(usecase)
+			}
+			s := newTestServer(t, usecase)
+
+			// act
+			got, err := s.RequestReturn(ctx, tt.req)
+
+			// assert
+			assert.Equal(t, tt.wantCode, status.Code(err), "error: %v", err)
+			if tt.wantCode == codes.OK {
+				assert.Equal(t, returnID.String(), got.GetReturn().GetReturnId())
+			}
+		})
+	}
+}
@@ -28,9 +28,11 @@ type Config struct {
 	DebugPort       string
 
 	ChainUnaryInterceptors []grpc.UnaryServerInterceptor
-	UnaryInterceptors      []grpc.UnaryServerInterceptor
+	UnaryInterceptors      []grpc.UnaryServerInterceptor // выполняются раньше ChainUnaryInterceptors
 }
 
+//go:generate mockery --srcpkg=github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system --name=UsecaseInterface --filename=usecase_mock.go --disable-version-string
+
 // Deps - server deps
 type Deps struct {
 	OMSUsecase orders_management_system.UsecaseInterface
@@ -70,13 +72,7 @@ func New(ctx context.Context, cfg Config, d Deps) (*Server, error) {
 
 	// validator
 	{
-		validator, err := protovalidate.New(
-			protovalidate.WithDisableLazy(true),
-			protovalidate.WithMessages(
-				// Добавляем сюда все запросы наши
-				&pb.CreateOrderRequest{},
-			),
-		)
+		validator, err := newValidator()
 		if err != nil {
 			return nil, fmt.Errorf("server: failed to initialize validator: %w", err)
 		}
@@ -85,12 +81,11 @@ func New(ctx context.Context, cfg Config, d Deps) (*Server, error) {
 
 	// grpc 82
 	{
-		grpcServerOptions := unaryInterceptorsToGrpcServerOptions(cfg.UnaryInterceptors...)
-		grpcServerOptions = append(grpcServerOptions,
+		// grpc.UnaryInterceptor можно задать только один раз: наши middleware - тоже цепочкой, перед остальными
+		grpcServer := grpc.NewServer(
+			grpc.ChainUnaryInterceptor(cfg.UnaryInterceptors...),
 			grpc.ChainUnaryInterceptor(cfg.ChainUnaryInterceptors...),
 		)
-
-		grpcServer := grpc.NewServer(grpcServerOptions...)
 		pb.RegisterOrdersManagementSystemServiceServer(grpcServer, srv)
 
 		reflection.Register(grpcServer)
@@ -107,7 +102,9 @@ func New(ctx context.Context, cfg Config, d Deps) (*Server, error) {
 	// grpc gateway 80, 443
 	// https://grpc-ecosystem.github.io/grpc-gateway/docs/mapping/customizing_your_gateway/
 	{
-		mux := runtime.NewServeMux()
+		mux := runtime.NewServeMux(
+			runtime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		)
 		if err := pb.RegisterOrdersManagementSystemServiceHandlerServer(ctx, mux, srv); err != nil {
 			return nil, fmt.Errorf("server: failed to register handler: %v", err)
 		}
@@ -154,6 +151,36 @@ func New(ctx context.Context, cfg Config, d Deps) (*Server, error) {
 	return srv, nil
 }
 
+// newValidator - валидатор запросов. Правила собираются заранее (WithDisableLazy),
+// поэтому незарегистрированный запрос не пройдет валидацию никогда
+func newValidator() (*protovalidate.Validator, error) {
+	return protovalidate.New(
+		protovalidate.WithDisableLazy(true),
+		protovalidate.WithMessages(
+			// Добавляем сюда все запросы наши
+			&pb.CreateOrderRequest{},
+			&pb.GetOrderRequest{},
+			&pb.ListOrdersRequest{},
+			&pb.CancelOrderRequest{},
+			&pb.AddToBasketRequest{},
+			&pb.UpdateBasketItemRequest{},
+			&pb.RemoveFromBasketRequest{},
+			&pb.GetBasketRequest{},
+			&pb.CreateOrderFromBasketRequest{},
+			&pb.PreviewOrderRequest{},
+			&pb.ApplyPromoCodeRequest{},
+			&pb.PayOrderRequest{},
+			&pb.HandlePaymentCallbackRequest{},
+			&pb.RequestReturnRequest{},
+			&pb.ApproveReturnRequest{},
+			&pb.CancelOrderItemsRequest{},
+			&pb.GetOrderItemsHistoryRequest{},
+			&pb.GetOrderTimelineRequest{},
+			&pb.GetOrderAtRequest{},
+		),
+	)
+}
+
 func (s *Server) AddHealthcheck(hc func() error) {
 	s.healthchecksMx.Lock()
 	defer s.healthchecksMx.Unlock()
@@ -223,11 +250,3 @@ func (s *Server) Run(ctx context.Context) error {
 
 	return group.Wait()
 }
-
-func unaryInterceptorsToGrpcServerOptions(interceptors ...grpc.UnaryServerInterceptor) []grpc.ServerOption {
-	opts := make([]grpc.ServerOption, 0, len(interceptors))
-	for _, interceptor := range interceptors {
-		opts = append(opts, grpc.UnaryInterceptor(interceptor))
-	}
-	return opts
-}
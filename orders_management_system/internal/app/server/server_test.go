@@ -0,0 +1,109 @@
+//go:build test
+
+package server
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/bufbuild/protovalidate-go"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	middleware_actor "github.com/moguchev/microservices_courcse/orders_management_system/internal/middleware/actor"
+	middleware_errors "github.com/moguchev/microservices_courcse/orders_management_system/internal/middleware/errors"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/orders_management_system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+func TestNew(t *testing.T) {
+	ctx := context.Background() // dummy
+
+	// calls - порядок вызова interceptors
+	var calls []string
+	recordCall := func(name string) grpc.UnaryServerInterceptor {
+		return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			calls = append(calls, name)
+			return handler(ctx, req)
+		}
+	}
+
+	// arrange: interceptors как в cmd/orders_management_system
+	cfg := Config{
+		GRPCPort:        "127.0.0.1:0",
+		GRPCGatewayPort: "127.0.0.1:0",
+		DebugPort:       "127.0.0.1:0",
+		ChainUnaryInterceptors: []grpc.UnaryServerInterceptor{
+			recordCall("chain"),
+		},
+		UnaryInterceptors: []grpc.UnaryServerInterceptor{
+			recordCall("unary"),
+			middleware_errors.ErrorsUnaryInterceptor(),
+			middleware_actor.ActorUnaryInterceptor(),
+		},
+	}
+
+	// act
+	var (
+		srv *Server
+		err error
+	)
+	require.NotPanics(t, func() { srv, err = New(ctx, cfg, Deps{}) })
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		srv.grpc.server.Stop()
+		_ = srv.grpcGateway.lis.Close()
+		_ = srv.internal.lis.Close()
+	})
+	go func() { _ = srv.grpc.server.Serve(srv.grpc.lis) }()
+
+	conn, err := grpc.NewClient(srv.grpc.lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	// невалидный запрос не доходит до usecase
+	_, err = pb.NewOrdersManagementSystemServiceClient(conn).GetOrder(ctx, &pb.GetOrderRequest{OrderId: "not-uuid"})
+
+	// assert
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "error: %v", err)
+	assert.Equal(t, []string{"unary", "chain"}, calls)
+}
+
+func TestNewValidator(t *testing.T) {
+	validator, err := newValidator()
+	require.NoError(t, err)
+
+	// Запрос, который забыли добавить в newValidator, не пройдет валидацию никогда (WithDisableLazy)
+	services := pb.File_api_orders_management_system_service_proto.Services()
+	for i := 0; i < services.Len(); i++ {
+		methods := services.Get(i).Methods()
+		for j := 0; j < methods.Len(); j++ {
+			input := methods.Get(j).Input()
+			t.Run(string(methods.Get(j).Name()), func(t *testing.T) {
+				mt, err := protoregistry.GlobalTypes.FindMessageByName(input.FullName())
+				require.NoError(t, err)
+
+				// пустой запрос может не пройти правила - важно лишь, что правила для него собраны
+				var compilationErr *protovalidate.CompilationError
+				err = validator.Validate(mt.New().Interface())
+				assert.False(t, errors.As(err, &compilationErr), "%s is not registered in newValidator: %v", input.FullName(), err)
+			})
+		}
+	}
+}
+
+// newTestServer - сервер без сети: для вызова обработчиков напрямую
+func newTestServer(t *testing.T, usecase orders_management_system.UsecaseInterface) *Server {
+	validator, err := newValidator()
+	require.NoError(t, err)
+
+	return &Server{
+		Deps:      Deps{OMSUsecase: usecase},
+		validator: validator,
+	}
+}
@@ -0,0 +1,249 @@
+package payments
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+)
+
+// DeclinedMethodPrefix - способы оплаты с этим префиксом провайдер отклоняет ("declined", "declined_insufficient_funds")
+const DeclinedMethodPrefix = "declined"
+
+// CallbackHandler - обработчик уведомлений о результате авторизации (usecase.HandlePaymentCallback)
+type CallbackHandler func(ctx context.Context, callback models.PaymentCallback) error
+
+// Fake - платежный провайдер в памяти процесса для локального запуска и тестов.
+// Авторизация асинхронная: результат доставляется в CallbackHandler с задержкой,
+// при ошибке доставка повторяется (как webhook настоящего провайдера)
+type Fake struct {
+	mu       sync.Mutex
+	payments map[string]*fakePayment     // по ID платежа у провайдера
+	byRef    map[models.PaymentID]string // ID платежа OMS -> ID у провайдера (идемпотентность Authorize)
+	handler  CallbackHandler
+	secret   []byte // ключ подписи уведомлений (HMAC-SHA256)
+
+	callbackDelay    time.Duration
+	callbackAttempts int
+	wg               sync.WaitGroup
+}
+
+type fakePayment struct {
+	amount    models.Money
+	status    models.PaymentStatus
+	refunded  models.Money
+	refundIDs map[string]struct{} // выполненные возвраты (идемпотентность Refund)
+}
+
+// Check that we implemet contract for usecase
+var _ orders_management_system.Payments = (*Fake)(nil)
+
+// Option - настройка Fake
+type Option func(*Fake)
+
+// WithCallbackDelay - задержка перед доставкой уведомления и между повторами (по умолчанию 100ms)
+func WithCallbackDelay(d time.Duration) Option {
+	return func(f *Fake) { f.callbackDelay = d }
+}
+
+// WithCallbackAttempts - сколько раз пытаться доставить уведомление (по умолчанию 5)
+func WithCallbackAttempts(n int) Option {
+	return func(f *Fake) { f.callbackAttempts = n }
+}
+
+// WithCallbackSecret - общий с провайдером ключ подписи уведомлений.
+// По умолчанию случайный: уведомления принимаются только от этого экземпляра Fake
+func WithCallbackSecret(secret []byte) Option {
+	return func(f *Fake) { f.secret = secret }
+}
+
+// NewFake - платежный провайдер в памяти
+func NewFake(opts ...Option) *Fake {
+	f := &Fake{
+		payments:         make(map[string]*fakePayment),
+		byRef:            make(map[models.PaymentID]string),
+		callbackDelay:    100 * time.Millisecond,
+		callbackAttempts: 5,
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	if len(f.secret) == 0 {
+		f.secret = make([]byte, 32)
+		_, _ = rand.Read(f.secret)
+	}
+	return f
+}
+
+// SignCallback - подпись уведомления ключом secret: hex(HMAC-SHA256) от полей уведомления
+func SignCallback(secret []byte, callback models.PaymentCallback) string {
+	mac := hmac.New(sha256.New, secret)
+	fmt.Fprintf(mac, "%s\n%s\n%s\n%s",
+		callback.PaymentID, callback.ProviderPaymentID, callback.Result, callback.FailureReason)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyCallback - уведомление подписано ключом провайдера
+func (f *Fake) VerifyCallback(_ context.Context, callback models.PaymentCallback) error {
+	signature, err := hex.DecodeString(callback.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: malformed payment callback signature", models.ErrUnauthenticated)
+	}
+	expected, _ := hex.DecodeString(SignCallback(f.secret, callback))
+	if !hmac.Equal(signature, expected) {
+		return fmt.Errorf("%w: invalid payment callback signature", models.ErrUnauthenticated)
+	}
+	return nil
+}
+
+// SetCallbackHandler - куда доставлять уведомления. Без обработчика уведомления теряются
+func (f *Fake) SetCallbackHandler(handler CallbackHandler) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.handler = handler
+}
+
+// Wait - ожидание доставки всех отправленных уведомлений
+func (f *Fake) Wait() {
+	f.wg.Wait()
+}
+
+func (f *Fake) Authorize(_ context.Context, payment models.Payment) (string, error) {
+	if payment.Amount.Amount < 0 {
+		return "", fmt.Errorf("%w: negative amount %s", models.ErrInvalidArgument, payment.Amount)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if providerID, ok := f.byRef[payment.ID]; ok {
+		return providerID, nil
+	}
+
+	var (
+		providerID = uuid.NewString()
+		callback   = models.PaymentCallback{
+			PaymentID:         payment.ID,
+			ProviderPaymentID: providerID,
+			Result:            models.PaymentResultAuthorized,
+		}
+		p = &fakePayment{amount: payment.Amount, status: models.PaymentStatusAuthorized}
+	)
+	if strings.HasPrefix(payment.Method, DeclinedMethodPrefix) {
+		callback.Result = models.PaymentResultFailed
+		callback.FailureReason = "declined by issuer"
+		p.status = models.PaymentStatusFailed
+	}
+	f.payments[providerID] = p
+	f.byRef[payment.ID] = providerID
+	callback.Signature = SignCallback(f.secret, callback)
+
+	f.wg.Add(1)
+	go f.deliver(f.handler, callback)
+
+	return providerID, nil
+}
+
+// deliver - доставка уведомления с повторами (OMS могла еще не закоммитить платеж)
+func (f *Fake) deliver(handler CallbackHandler, callback models.PaymentCallback) {
+	defer f.wg.Done()
+	if handler == nil {
+		return
+	}
+
+	ctx := context.Background()
+	for attempt := 1; attempt <= f.callbackAttempts; attempt++ {
+		time.Sleep(f.callbackDelay)
+
+		err := handler(ctx, callback)
+		if err == nil {
+			return
+		}
+		logger.ErrorKV(ctx, "payment callback failed",
+			"payment_id", callback.PaymentID.String(), "attempt", attempt, "error", err.Error())
+	}
+}
+
+func (f *Fake) Capture(_ context.Context, providerPaymentID string, amount models.Money) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.payments[providerPaymentID]
+	if !ok {
+		return fmt.Errorf("%w: payment %q", models.ErrNotFound, providerPaymentID)
+	}
+	switch p.status {
+	case models.PaymentStatusCaptured:
+		return nil
+	case models.PaymentStatusAuthorized:
+		if amount.Currency != p.amount.Currency || amount.Amount > p.amount.Amount {
+			return fmt.Errorf("%w: capture %s of %s", models.ErrInvalidArgument, amount, p.amount)
+		}
+		p.status, p.amount = models.PaymentStatusCaptured, amount
+		return nil
+	default:
+		return fmt.Errorf("%w: capture %s payment", models.ErrInvalidArgument, p.status)
+	}
+}
+
+func (f *Fake) Refund(_ context.Context, providerPaymentID string, amount models.Money, refundID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.payments[providerPaymentID]
+	if !ok {
+		return fmt.Errorf("%w: payment %q", models.ErrNotFound, providerPaymentID)
+	}
+	if _, ok := p.refundIDs[refundID]; ok {
+		return nil
+	}
+	if p.status != models.PaymentStatusCaptured && p.status != models.PaymentStatusRefunded {
+		return fmt.Errorf("%w: refund %s payment", models.ErrInvalidArgument, p.status)
+	}
+
+	refunded, err := p.refunded.Add(amount)
+	if err != nil {
+		return err
+	}
+	if refunded.Amount > p.amount.Amount {
+		return fmt.Errorf("%w: refund %s exceeds captured %s", models.ErrInvalidArgument, refunded, p.amount)
+	}
+	p.refunded = refunded
+	if p.refundIDs == nil {
+		p.refundIDs = make(map[string]struct{})
+	}
+	p.refundIDs[refundID] = struct{}{}
+	if refunded.Amount == p.amount.Amount {
+		p.status = models.PaymentStatusRefunded
+	}
+	return nil
+}
+
+func (f *Fake) Void(_ context.Context, providerPaymentID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	p, ok := f.payments[providerPaymentID]
+	if !ok {
+		return fmt.Errorf("%w: payment %q", models.ErrNotFound, providerPaymentID)
+	}
+	switch p.status {
+	case models.PaymentStatusVoided, models.PaymentStatusFailed:
+		return nil
+	case models.PaymentStatusAuthorized:
+		p.status = models.PaymentStatusVoided
+		return nil
+	default:
+		return fmt.Errorf("%w: void %s payment", models.ErrInvalidArgument, p.status)
+	}
+}
@@ -0,0 +1,146 @@
+//go:build test
+
+package payments
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFake(t *testing.T) {
+	var (
+		ctx       = context.Background()
+		rub       = func(amount int64) models.Money { return models.NewMoney(amount, models.CurrencyRUB) }
+		mu        sync.Mutex
+		callbacks = make(map[models.PaymentID]models.PaymentCallback)
+		attempts  int
+		secret    = []byte("secret")
+		f         = NewFake(WithCallbackDelay(0), WithCallbackAttempts(3), WithCallbackSecret(secret))
+	)
+	f.SetCallbackHandler(func(_ context.Context, callback models.PaymentCallback) error {
+		mu.Lock()
+		defer mu.Unlock()
+		// первая доставка падает: OMS еще не закоммитила платеж
+		if attempts++; attempts == 1 {
+			return errors.New("payment not committed yet")
+		}
+		callbacks[callback.PaymentID] = callback
+		return nil
+	})
+
+	paid := models.Payment{ID: models.PaymentID(uuid.New()), Amount: rub(1000), Method: "card"}
+	paidProviderID, err := f.Authorize(ctx, paid)
+	assert.NoError(t, err)
+	f.Wait()
+
+	// повторная авторизация того же платежа - тот же ID у провайдера
+	again, err := f.Authorize(ctx, paid)
+	assert.NoError(t, err)
+	assert.Equal(t, paidProviderID, again)
+
+	declined := models.Payment{ID: models.PaymentID(uuid.New()), Amount: rub(1000), Method: "declined_card"}
+	declinedProviderID, err := f.Authorize(ctx, declined)
+	assert.NoError(t, err)
+	f.Wait()
+
+	paidCallback := models.PaymentCallback{
+		PaymentID:         paid.ID,
+		ProviderPaymentID: paidProviderID,
+		Result:            models.PaymentResultAuthorized,
+	}
+	paidCallback.Signature = SignCallback(secret, paidCallback)
+	assert.Equal(t, paidCallback, callbacks[paid.ID])
+	assert.NoError(t, f.VerifyCallback(ctx, callbacks[declined.ID]))
+	assert.Equal(t, models.PaymentResultFailed, callbacks[declined.ID].Result)
+
+	// capture идемпотентен, refund идемпотентен по refundID и не больше списанного
+	assert.NoError(t, f.Capture(ctx, paidProviderID, rub(1000)))
+	assert.NoError(t, f.Capture(ctx, paidProviderID, rub(1000)))
+	assert.NoError(t, f.Refund(ctx, paidProviderID, rub(400), "refund-1"))
+	assert.NoError(t, f.Refund(ctx, paidProviderID, rub(400), "refund-1"))
+	err = f.Refund(ctx, paidProviderID, rub(700), "refund-2")
+	assert.True(t, errors.Is(err, models.ErrInvalidArgument), "got error: %v", err)
+	assert.NoError(t, f.Refund(ctx, paidProviderID, rub(600), "refund-2"))
+	err = f.Void(ctx, paidProviderID)
+	assert.True(t, errors.Is(err, models.ErrInvalidArgument), "got error: %v", err)
+
+	assert.NoError(t, f.Void(ctx, declinedProviderID))
+	err = f.Capture(ctx, declinedProviderID, rub(1000))
+	assert.True(t, errors.Is(err, models.ErrInvalidArgument), "got error: %v", err)
+
+	err = f.Capture(ctx, "unknown", rub(1000))
+	assert.True(t, errors.Is(err, models.ErrNotFound), "got error: %v", err)
+}
+
+func TestFake_VerifyCallback(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		secret   = []byte("secret")
+		f        = NewFake(WithCallbackSecret(secret))
+		callback = models.PaymentCallback{
+			PaymentID:         models.PaymentID(uuid.New()),
+			ProviderPaymentID: "provider-1",
+			Result:            models.PaymentResultFailed,
+			FailureReason:     "declined by issuer",
+		}
+		signed = func(secret []byte, callback models.PaymentCallback) models.PaymentCallback {
+			callback.Signature = SignCallback(secret, callback)
+			return callback
+		}
+	)
+
+	tests := []struct {
+		name     string
+		callback models.PaymentCallback
+		wantErr  error
+	}{
+		{
+			name:     "Test 1. Positive. Signed by provider.",
+			callback: signed(secret, callback),
+		},
+		{
+			name: "Test 2. Negative. Result was changed after signing.",
+			callback: func() models.PaymentCallback {
+				c := signed(secret, callback)
+				c.Result = models.PaymentResultAuthorized
+				return c
+			}(),
+			wantErr: models.ErrUnauthenticated,
+		},
+		{
+			name:     "Test 3. Negative. Signed with another key.",
+			callback: signed([]byte("another"), callback),
+			wantErr:  models.ErrUnauthenticated,
+		},
+		{
+			name:     "Test 4. Negative. No signature.",
+			callback: callback,
+			wantErr:  models.ErrUnauthenticated,
+		},
+		{
+			name: "Test 5. Negative. Malformed signature.",
+			callback: func() models.PaymentCallback {
+				c := callback
+				c.Signature = "not-hex"
+				return c
+			}(),
+			wantErr: models.ErrUnauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := f.VerifyCallback(ctx, tt.callback)
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package pricing
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+)
+
+// InMemory - цены из прайс-листа в памяти процесса
+type InMemory struct {
+	mu   sync.RWMutex
+	list PriceList
+}
+
+// Check that we implemet contract for usecase
+var _ orders_management_system.Pricing = (*InMemory)(nil)
+
+// NewInMemory - цены из прайс-листа list
+func NewInMemory(list PriceList) *InMemory {
+	p := &InMemory{}
+	p.Update(list)
+	return p
+}
+
+// Update - замена прайс-листа целиком (уже созданные заказы хранят свои цены)
+func (p *InMemory) Update(list PriceList) {
+	skus := make(map[uint64]int64, len(list.SKUs))
+	for id, amount := range list.SKUs {
+		skus[id] = amount
+	}
+	delivery := make(map[uint64]int64, len(list.Delivery))
+	for id, amount := range list.Delivery {
+		delivery[id] = amount
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.list = PriceList{Currency: list.Currency, SKUs: skus, Delivery: delivery}
+}
+
+func (p *InMemory) GetPrices(_ context.Context, skus []models.SKUID) (map[models.SKUID]models.Money, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	prices := make(map[models.SKUID]models.Money, len(skus))
+	for _, sku := range skus {
+		amount, ok := p.list.SKUs[uint64(sku)]
+		if !ok {
+			return nil, fmt.Errorf("%w: sku %d", models.ErrPriceNotFound, sku)
+		}
+		prices[sku] = models.NewMoney(amount, p.list.Currency)
+	}
+	return prices, nil
+}
+
+func (p *InMemory) GetDeliveryPrice(_ context.Context, deliveryVariantID models.DeliveryVariantID) (models.Money, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	amount, ok := p.list.Delivery[uint64(deliveryVariantID)]
+	if !ok {
+		return models.Money{}, fmt.Errorf("%w: delivery variant %d", models.ErrPriceNotFound, deliveryVariantID)
+	}
+	return models.NewMoney(amount, p.list.Currency), nil
+}
@@ -0,0 +1,40 @@
+//go:build test
+
+package pricing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInMemory(t *testing.T) {
+	var (
+		ctx = context.Background()
+		p   = NewInMemory(PriceList{
+			Currency: models.CurrencyRUB,
+			SKUs:     map[uint64]int64{1: 1000, 2: 550},
+			Delivery: map[uint64]int64{1: 300},
+		})
+	)
+
+	prices, err := p.GetPrices(ctx, []models.SKUID{1, 2})
+	assert.NoError(t, err)
+	assert.Equal(t, map[models.SKUID]models.Money{
+		1: models.NewMoney(1000, models.CurrencyRUB),
+		2: models.NewMoney(550, models.CurrencyRUB),
+	}, prices)
+
+	_, err = p.GetPrices(ctx, []models.SKUID{1, 3})
+	assert.True(t, errors.Is(err, models.ErrPriceNotFound), "got error: %v", err)
+
+	delivery, err := p.GetDeliveryPrice(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, models.NewMoney(300, models.CurrencyRUB), delivery)
+
+	_, err = p.GetDeliveryPrice(ctx, 2)
+	assert.True(t, errors.Is(err, models.ErrPriceNotFound), "got error: %v", err)
+}
@@ -0,0 +1,57 @@
+package pricing
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"gopkg.in/yaml.v3"
+)
+
+// PriceList - прайс-лист, все цены в минорных единицах валюты Currency
+type PriceList struct {
+	Currency models.Currency  `json:"currency" yaml:"currency"`
+	SKUs     map[uint64]int64 `json:"skus" yaml:"skus"`         // цена за единицу по id SKU
+	Delivery map[uint64]int64 `json:"delivery" yaml:"delivery"` // стоимость по id способа доставки
+}
+
+// LoadPriceList - читает прайс-лист из YAML (.yaml, .yml) или JSON (.json) файла
+func LoadPriceList(path string) (PriceList, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return PriceList{}, fmt.Errorf("pricing: read price list: %w", err)
+	}
+
+	list, err := ParsePriceList(data, filepath.Ext(path))
+	if err != nil {
+		return PriceList{}, fmt.Errorf("%w (%s)", err, path)
+	}
+	return list, nil
+}
+
+// ParsePriceList - разбирает прайс-лист в формате по расширению файла ext (.yaml, .yml, .json)
+func ParsePriceList(data []byte, ext string) (PriceList, error) {
+	var (
+		list PriceList
+		err  error
+	)
+	switch ext = strings.ToLower(ext); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &list)
+	case ".json":
+		err = json.Unmarshal(data, &list)
+	default:
+		return PriceList{}, fmt.Errorf("pricing: unsupported price list format %q", ext)
+	}
+	if err != nil {
+		return PriceList{}, fmt.Errorf("pricing: parse price list: %w", err)
+	}
+	if list.Currency == "" {
+		list.Currency = models.CurrencyRUB
+	}
+
+	return list, nil
+}
@@ -0,0 +1,47 @@
+//go:build test
+
+package pricing
+
+import (
+	"testing"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePriceList(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		ext     string
+		want    PriceList
+		wantErr bool
+	}{
+		{
+			name: "Test 1. Positive. YAML without currency defaults to RUB.",
+			data: "skus:\n  1: 1000\ndelivery:\n  2: 300\n",
+			ext:  ".YML",
+			want: PriceList{Currency: models.CurrencyRUB, SKUs: map[uint64]int64{1: 1000}, Delivery: map[uint64]int64{2: 300}},
+		},
+		{
+			name: "Test 2. Positive. JSON.",
+			data: `{"currency": "USD", "skus": {"1": 1000}}`,
+			ext:  ".json",
+			want: PriceList{Currency: "USD", SKUs: map[uint64]int64{1: 1000}},
+		},
+		{
+			name:    "Test 3. Negative. Unsupported format.",
+			data:    "1,1000",
+			ext:     ".csv",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePriceList([]byte(tt.data), tt.ext)
+
+			assert.Equal(t, tt.wantErr, err != nil, "error: %v", err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,19 +1,81 @@
 package warehouses_management_system
 
-import "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+import (
+	"fmt"
+	"time"
 
+	grpc_opentracing "github.com/grpc-ecosystem/grpc-opentracing/go/otgrpc"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/warehouses_management_system"
+	"github.com/opentracing/opentracing-go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// defaultCallTimeout - дедлайн одного вызова WMS, если не задан WithCallTimeout
+	defaultCallTimeout = time.Second
+)
+
+// Client - gRPC адаптер сервиса WMS
 type Client struct {
-	/*
-		HTTP, gRPC, ... client
-	*/
+	client      pb.WarehousesManagementSystemServiceClient
+	callTimeout time.Duration
 }
 
 // Check that we implemet contract for usecase
-var _ orders_management_system.WarehouseManagementSystem = (*Client)(nil)
+var (
+	_ orders_management_system.WarehouseManagementSystem = (*Client)(nil)
+	_ orders_management_system.Inventory                 = (*Client)(nil)
+)
+
+// Option - опция Client
+type Option func(c *Client)
+
+// WithCallTimeout - дедлайн одного вызова WMS (дедлайн из ctx, если он раньше, сохраняется)
+func WithCallTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.callTimeout = timeout
+	}
+}
 
 // NewClient - returns WMS service adapter
-func NewClient( /* ... */ ) *Client {
-	return &Client{
-		/* ... */
+func NewClient(conn grpc.ClientConnInterface, opts ...Option) *Client {
+	c := &Client{
+		client:      pb.NewWarehousesManagementSystemServiceClient(conn),
+		callTimeout: defaultCallTimeout,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// DialOptions - опции соединения с WMS по умолчанию:
+// трейсинг (span context уходит в metadata) и проброс входящей metadata в исходящую
+func DialOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		// WMS во внутренней сети
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithKeepaliveParams(keepalive.ClientParameters{
+			Time:                30 * time.Second,
+			Timeout:             5 * time.Second,
+			PermitWithoutStream: true,
+		}),
+		grpc.WithChainUnaryInterceptor(
+			grpc_opentracing.OpenTracingClientInterceptor(opentracing.GlobalTracer()),
+			ForwardMetadataUnaryClientInterceptor(forwardedMetadata...),
+		),
+	}
+}
+
+// Dial - соединение с WMS по адресу target (соединение устанавливается лениво при первом вызове).
+// opts добавляются после DialOptions
+func Dial(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient(target, append(DialOptions(), opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("warehouses_management_system: dial %s: %w", target, err)
 	}
+	return conn, nil
 }
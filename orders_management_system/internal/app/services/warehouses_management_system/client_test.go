@@ -0,0 +1,147 @@
+//go:build test
+
+package warehouses_management_system
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/warehouses_management_system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// wmsServer - WMS, который отвечает функцией reserve
+type wmsServer struct {
+	pb.UnimplementedWarehousesManagementSystemServiceServer
+
+	reserve func(ctx context.Context, req *pb.ReserveStocksRequest) error
+}
+
+func (s *wmsServer) ReserveStocks(ctx context.Context, req *pb.ReserveStocksRequest) (*pb.ReserveStocksResponse, error) {
+	if err := s.reserve(ctx, req); err != nil {
+		return nil, err
+	}
+	return &pb.ReserveStocksResponse{}, nil
+}
+
+func newTestClient(t *testing.T, srv *wmsServer, opts ...Option) *Client {
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pb.RegisterWarehousesManagementSystemServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(lis) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := Dial("passthrough:///bufnet", grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+		return lis.DialContext(ctx)
+	}))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return NewClient(conn, opts...)
+}
+
+func statusWithDetails(t *testing.T, code codes.Code, details *pb.ErrorDetails) error {
+	st, err := status.New(code, code.String()).WithDetails(details)
+	require.NoError(t, err)
+	return st.Err()
+}
+
+func TestClient_ReserveStocks(t *testing.T) {
+	var (
+		items         = []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}}
+		reservationID = models.ReservationID(uuid.New())
+	)
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		reserve func(t *testing.T) func(ctx context.Context, req *pb.ReserveStocksRequest) error
+		wantErr error
+	}{
+		{
+			name: "Test 1. Positive. Request and metadata are propagated.",
+			ctx:  metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-request-id", "42", "authorization", "secret")),
+			reserve: func(t *testing.T) func(ctx context.Context, req *pb.ReserveStocksRequest) error {
+				return func(ctx context.Context, req *pb.ReserveStocksRequest) error {
+					md, _ := metadata.FromIncomingContext(ctx)
+					assert.Equal(t, []string{"42"}, md.Get("x-request-id"))
+					assert.Empty(t, md.Get("authorization"))
+
+					_, ok := ctx.Deadline()
+					assert.True(t, ok)
+
+					assert.Equal(t, uint64(1), req.GetUserId())
+					assert.Equal(t, []*pb.Item{{SkuId: 2, Quantity: 3, WarehouseId: 4}}, req.GetItems())
+					assert.Equal(t, reservationID.String(), req.GetReservationId())
+					return nil
+				}
+			},
+		},
+		{
+			name: "Test 2. Negative. Out of stock.",
+			ctx:  context.Background(),
+			reserve: func(t *testing.T) func(ctx context.Context, req *pb.ReserveStocksRequest) error {
+				return func(context.Context, *pb.ReserveStocksRequest) error {
+					return statusWithDetails(t, codes.FailedPrecondition, &pb.ErrorDetails{
+						Reason:      pb.ErrorReason_ERROR_REASON_OUT_OF_STOCK,
+						SkuId:       2,
+						WarehouseId: 4,
+					})
+				}
+			},
+			wantErr: models.ErrOutOfStock,
+		},
+		{
+			name: "Test 3. Negative. Unknown warehouse.",
+			ctx:  context.Background(),
+			reserve: func(t *testing.T) func(ctx context.Context, req *pb.ReserveStocksRequest) error {
+				return func(context.Context, *pb.ReserveStocksRequest) error {
+					return statusWithDetails(t, codes.NotFound, &pb.ErrorDetails{
+						Reason:      pb.ErrorReason_ERROR_REASON_UNKNOWN_WAREHOUSE,
+						WarehouseId: 4,
+					})
+				}
+			},
+			wantErr: models.ErrUnknownWarehouse,
+		},
+		{
+			name: "Test 4. Negative. Deadline exceeded.",
+			ctx:  context.Background(),
+			reserve: func(t *testing.T) func(ctx context.Context, req *pb.ReserveStocksRequest) error {
+				return func(ctx context.Context, _ *pb.ReserveStocksRequest) error {
+					<-ctx.Done()
+					return status.FromContextError(ctx.Err()).Err()
+				}
+			},
+			wantErr: models.ErrServiceUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			c := newTestClient(t, &wmsServer{reserve: tt.reserve(t)}, WithCallTimeout(100*time.Millisecond))
+
+			// act
+			err := c.ReserveStocks(tt.ctx, reservationID, 1, items)
+
+			// assert
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+			_, isStatus := status.FromError(err)
+			assert.False(t, isStatus, "wms status must not leak to OMS clients")
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package warehouses_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/warehouses_management_system"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/opentracing/opentracing-go"
+)
+
+func (r *Client) ConfirmReservation(
+	ctx context.Context,
+	reservationID models.ReservationID,
+) error {
+	const api = "warehouses_management_system.ConfirmReservation"
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "warehouses_management_system.ConfirmReservation")
+	defer span.Finish()
+
+	span.SetTag("reservation_id", reservationID.String())
+
+	ctx, cancel := context.WithTimeout(ctx, r.callTimeout)
+	defer cancel()
+
+	_, err := r.client.ConfirmReservation(ctx, &pb.ConfirmReservationRequest{
+		ReservationId: reservationID.String(),
+	})
+	if err != nil {
+		return pkgerrors.Wrap(api, convertError(err))
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package warehouses_management_system
+
+import (
+	"fmt"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/warehouses_management_system"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// convertError - ошибка WMS в доменную ошибку.
+// Статус WMS не отдаем наружу как есть, иначе его код дойдет до клиентов OMS
+func convertError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		details, ok := detail.(*pb.ErrorDetails)
+		if !ok {
+			continue
+		}
+
+		switch details.GetReason() {
+		case pb.ErrorReason_ERROR_REASON_OUT_OF_STOCK:
+			return fmt.Errorf("sku %d on warehouse %d: %w", details.GetSkuId(), details.GetWarehouseId(), models.ErrOutOfStock)
+		case pb.ErrorReason_ERROR_REASON_UNKNOWN_WAREHOUSE:
+			return fmt.Errorf("warehouse %d: %w", details.GetWarehouseId(), models.ErrUnknownWarehouse)
+		case pb.ErrorReason_ERROR_REASON_UNKNOWN_RESERVATION:
+			return fmt.Errorf("wms: %s: %w", st.Message(), models.ErrUnknownReservation)
+		}
+	}
+
+	switch st.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return fmt.Errorf("wms: %s: %w", st.Message(), models.ErrServiceUnavailable)
+	default:
+		return fmt.Errorf("wms: %s: %s", st.Code(), st.Message())
+	}
+}
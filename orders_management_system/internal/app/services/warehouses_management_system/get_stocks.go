@@ -0,0 +1,58 @@
+package warehouses_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/warehouses_management_system"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/opentracing/opentracing-go"
+)
+
+func (r *Client) GetStocks(
+	ctx context.Context,
+	skus []models.SKUID,
+	deliveryVariantID models.DeliveryVariantID,
+) ([]models.WarehouseStocks, error) {
+	const api = "warehouses_management_system.GetStocks"
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "warehouses_management_system.GetStocks")
+	defer span.Finish()
+
+	span.SetTag("delivery_variant_id", deliveryVariantID)
+
+	ctx, cancel := context.WithTimeout(ctx, r.callTimeout)
+	defer cancel()
+
+	skuIDs := make([]uint64, 0, len(skus))
+	for _, sku := range skus {
+		skuIDs = append(skuIDs, uint64(sku))
+	}
+
+	resp, err := r.client.GetStocks(ctx, &pb.GetStocksRequest{
+		SkuIds:            skuIDs,
+		DeliveryVariantId: uint64(deliveryVariantID),
+	})
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, convertError(err))
+	}
+
+	return newModelsWarehouseStocksFromPb(resp.GetWarehouses()), nil
+}
+
+func newModelsWarehouseStocksFromPb(warehouses []*pb.WarehouseStocks) []models.WarehouseStocks {
+	res := make([]models.WarehouseStocks, 0, len(warehouses))
+	for _, w := range warehouses {
+		stocks := make(map[models.SKUID]uint32, len(w.GetStocks()))
+		for _, stock := range w.GetStocks() {
+			stocks[models.SKUID(stock.GetSkuId())] += stock.GetQuantity()
+		}
+		res = append(res, models.WarehouseStocks{
+			WarehouseID:  models.WarehouseID(w.GetWarehouseId()),
+			Stocks:       stocks,
+			DistanceKm:   w.GetDistanceKm(),
+			ShippingCost: w.GetShippingCost(),
+		})
+	}
+	return res
+}
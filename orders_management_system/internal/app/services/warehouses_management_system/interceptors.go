@@ -0,0 +1,36 @@
+package warehouses_management_system
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// forwardedMetadata - входящая metadata, которую пробрасываем в WMS
+var forwardedMetadata = []string{
+	"x-request-id",
+	"idempotency-key",
+}
+
+// ForwardMetadataUnaryClientInterceptor - пробрасывает ключи keys из входящей metadata в исходящую
+func ForwardMetadataUnaryClientInterceptor(keys ...string) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply interface{},
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			for _, key := range keys {
+				for _, value := range md.Get(key) {
+					ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+				}
+			}
+		}
+
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -2,18 +2,16 @@ package warehouses_management_system
 
 import (
 	"context"
-	"errors"
-	"math/rand"
-	"time"
 
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/warehouses_management_system"
 	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
-	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
 	"github.com/opentracing/opentracing-go"
 )
 
 func (r *Client) ReserveStocks(
 	ctx context.Context,
+	reservationID models.ReservationID,
 	userID models.UserID,
 	items []models.Item,
 ) error {
@@ -23,15 +21,31 @@ func (r *Client) ReserveStocks(
 	defer span.Finish()
 
 	span.SetTag("user_id", userID)
+	span.SetTag("reservation_id", reservationID.String())
+
+	ctx, cancel := context.WithTimeout(ctx, r.callTimeout)
+	defer cancel()
+
+	_, err := r.client.ReserveStocks(ctx, &pb.ReserveStocksRequest{
+		UserId:        uint64(userID),
+		Items:         newPbItemsFromModelsItems(items),
+		ReservationId: reservationID.String(),
+	})
+	if err != nil {
+		return pkgerrors.Wrap(api, convertError(err))
+	}
 
-	logger.Info(ctx, "stock reserved")
-
-	/* call external service */
-	time.Sleep(50 * time.Millisecond)
+	return nil
+}
 
-	if rand.Int31n(4) == 3 {
-		return pkgerrors.Wrap(api, errors.New("error"))
+func newPbItemsFromModelsItems(items []models.Item) []*pb.Item {
+	res := make([]*pb.Item, 0, len(items))
+	for _, item := range items {
+		res = append(res, &pb.Item{
+			SkuId:       uint64(item.SKU.ID),
+			Quantity:    item.Quantity,
+			WarehouseId: uint64(item.WarehouseID),
+		})
 	}
-	return nil
-	// return pkgerrors.Wrap(api, models.ErrUnimplemented)
+	return res
 }
@@ -5,19 +5,29 @@ package warehouses_management_system
 
 import (
 	"context"
+	"os"
 	"testing"
 
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestClient_ReserveStocks(t *testing.T) {
 	// prepare
 
-	r := &Client{}
+	conn, err := Dial(os.Getenv("WMS_ADDR")) // "localhost:8092"
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
 
-	t.Run("Test 1.", func(t *testing.T) {
-		if err := r.ReserveStocks(context.Background(), 1, nil); err == nil {
-			assert.NotNil(t, err)
-		}
+	r := NewClient(conn)
+
+	t.Run("Test 1. Unknown warehouse.", func(t *testing.T) {
+		err := r.ReserveStocks(context.Background(), models.ReservationID(uuid.New()), 1, []models.Item{
+			{SKU: models.SKU{ID: 1}, Quantity: 1, WarehouseID: 1 << 62},
+		})
+		assert.ErrorIs(t, err, models.ErrUnknownWarehouse)
 	})
 }
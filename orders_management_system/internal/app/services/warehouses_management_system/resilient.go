@@ -0,0 +1,97 @@
+package warehouses_management_system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/resilience"
+)
+
+// WMS - порты usecase, которые реализует WMS
+type WMS interface {
+	orders_management_system.WarehouseManagementSystem
+	orders_management_system.Inventory
+}
+
+// Resilient - декоратор портов WMS: повторы, таймаут попытки и circuit breaker по политике resilience.
+// Повторять вызовы WMS безопасно: резерв идемпотентен по reservation_id,
+// подтверждение и снятие резерва - по ID резерва, уменьшение резерва задает итоговый резерв,
+// GetStocks только читает
+type Resilient struct {
+	next   WMS
+	policy *resilience.Policy
+}
+
+// Check that we implemet contract for usecase
+var _ WMS = (*Resilient)(nil)
+
+// NewResilient - оборачивает next в политику "warehouses_management_system".
+// Отказом WMS считается только models.ErrServiceUnavailable: бизнес-ошибки (нет стоков)
+// не повторяются и не размыкают breaker. opts применяются после настроек по умолчанию
+func NewResilient(next WMS, opts ...resilience.Option) *Resilient {
+	opts = append([]resilience.Option{
+		resilience.WithRetryable(func(err error) bool {
+			return errors.Is(err, models.ErrServiceUnavailable)
+		}),
+	}, opts...)
+
+	return &Resilient{
+		next:   next,
+		policy: resilience.NewPolicy("warehouses_management_system", opts...),
+	}
+}
+
+func (r *Resilient) ReserveStocks(
+	ctx context.Context,
+	reservationID models.ReservationID,
+	userID models.UserID,
+	items []models.Item,
+) error {
+	return r.do(ctx, func(ctx context.Context) error {
+		return r.next.ReserveStocks(ctx, reservationID, userID, items)
+	})
+}
+
+func (r *Resilient) ConfirmReservation(ctx context.Context, reservationID models.ReservationID) error {
+	return r.do(ctx, func(ctx context.Context) error {
+		return r.next.ConfirmReservation(ctx, reservationID)
+	})
+}
+
+func (r *Resilient) ReleaseReservation(ctx context.Context, reservationID models.ReservationID) error {
+	return r.do(ctx, func(ctx context.Context) error {
+		return r.next.ReleaseReservation(ctx, reservationID)
+	})
+}
+
+func (r *Resilient) ShrinkReservation(ctx context.Context, reservationID models.ReservationID, items []models.Item) error {
+	return r.do(ctx, func(ctx context.Context) error {
+		return r.next.ShrinkReservation(ctx, reservationID, items)
+	})
+}
+
+func (r *Resilient) GetStocks(
+	ctx context.Context,
+	skus []models.SKUID,
+	deliveryVariantID models.DeliveryVariantID,
+) ([]models.WarehouseStocks, error) {
+	var stocks []models.WarehouseStocks
+	err := r.do(ctx, func(ctx context.Context) error {
+		var err error
+		stocks, err = r.next.GetStocks(ctx, skus, deliveryVariantID)
+		return err
+	})
+	return stocks, err
+}
+
+func (r *Resilient) do(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := r.policy.Do(ctx, fn)
+	if errors.Is(err, resilience.ErrCircuitOpen) {
+		// для usecase разомкнутый breaker - та же недоступность WMS
+		return fmt.Errorf("%w: %w", models.ErrServiceUnavailable, err)
+	}
+	return err
+}
@@ -0,0 +1,105 @@
+//go:build test
+
+package warehouses_management_system
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/resilience"
+	"github.com/stretchr/testify/assert"
+)
+
+// scriptedWMS - WMS, который отвечает ошибками из results по порядку
+type scriptedWMS struct {
+	results []error
+	calls   int
+}
+
+func (s *scriptedWMS) next() error {
+	err := s.results[min(s.calls, len(s.results)-1)]
+	s.calls++
+	return err
+}
+
+func (s *scriptedWMS) ReserveStocks(context.Context, models.ReservationID, models.UserID, []models.Item) error {
+	return s.next()
+}
+
+func (s *scriptedWMS) ConfirmReservation(context.Context, models.ReservationID) error {
+	return s.next()
+}
+
+func (s *scriptedWMS) ReleaseReservation(context.Context, models.ReservationID) error {
+	return s.next()
+}
+
+func (s *scriptedWMS) ShrinkReservation(context.Context, models.ReservationID, []models.Item) error {
+	return s.next()
+}
+
+func (s *scriptedWMS) GetStocks(context.Context, []models.SKUID, models.DeliveryVariantID) ([]models.WarehouseStocks, error) {
+	return nil, s.next()
+}
+
+func TestResilient_ReserveStocks(t *testing.T) {
+	var (
+		ctx           = context.Background()
+		reservationID = models.ReservationID(uuid.New())
+		noBackoff     = resilience.WithBackoff(resilience.Backoff{})
+	)
+
+	tests := []struct {
+		name      string
+		opts      []resilience.Option
+		results   []error
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "Test 1. Positive. Unavailable WMS is retried.",
+			opts:      []resilience.Option{noBackoff},
+			results:   []error{models.ErrServiceUnavailable, nil},
+			wantCalls: 2,
+		},
+		{
+			name:      "Test 2. Negative. Business error is not retried.",
+			opts:      []resilience.Option{noBackoff},
+			results:   []error{models.ErrOutOfStock},
+			wantCalls: 1,
+			wantErr:   models.ErrOutOfStock,
+		},
+		{
+			name: "Test 3. Negative. Open circuit breaker is reported as unavailable WMS.",
+			opts: []resilience.Option{noBackoff, resilience.WithMaxAttempts(3),
+				resilience.WithCircuitBreaker(resilience.BreakerConfig{FailureThreshold: 1}),
+			},
+			results:   []error{models.ErrServiceUnavailable},
+			wantCalls: 1,
+			wantErr:   resilience.ErrCircuitOpen,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			wms := &scriptedWMS{results: tt.results}
+			r := NewResilient(wms, tt.opts...)
+
+			// act
+			err := r.ReserveStocks(ctx, reservationID, 1, nil)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				if tt.wantErr == resilience.ErrCircuitOpen {
+					assert.ErrorIs(t, err, models.ErrServiceUnavailable)
+				}
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, wms.calls)
+		})
+	}
+}
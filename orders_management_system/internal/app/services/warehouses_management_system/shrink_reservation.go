@@ -0,0 +1,36 @@
+package warehouses_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/warehouses_management_system"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/opentracing/opentracing-go"
+)
+
+func (r *Client) ShrinkReservation(
+	ctx context.Context,
+	reservationID models.ReservationID,
+	items []models.Item,
+) error {
+	const api = "warehouses_management_system.ShrinkReservation"
+
+	span, ctx := opentracing.StartSpanFromContext(ctx, "warehouses_management_system.ShrinkReservation")
+	defer span.Finish()
+
+	span.SetTag("reservation_id", reservationID.String())
+
+	ctx, cancel := context.WithTimeout(ctx, r.callTimeout)
+	defer cancel()
+
+	_, err := r.client.ShrinkReservation(ctx, &pb.ShrinkReservationRequest{
+		ReservationId: reservationID.String(),
+		Items:         newPbItemsFromModelsItems(items),
+	})
+	if err != nil {
+		return pkgerrors.Wrap(api, convertError(err))
+	}
+
+	return nil
+}
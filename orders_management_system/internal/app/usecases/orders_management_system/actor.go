@@ -0,0 +1,31 @@
+package orders_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
+
+type actorContextKey struct{}
+
+// WithActor - контекст с инициатором изменений заказа (проставляет транспортный слой).
+// Без него изменения записываются в журнал от имени models.ActorSystem
+func WithActor(ctx context.Context, actor models.Actor) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, actor)
+}
+
+// actorFromContext - инициатор изменений заказа из контекста
+func actorFromContext(ctx context.Context) models.Actor {
+	if actor, ok := ctx.Value(actorContextKey{}).(models.Actor); ok {
+		return actor
+	}
+	return models.Actor{Type: models.ActorSystem}
+}
+
+// appendOrderEvent - запись события в журнал заказа (и outbox) от имени инициатора из контекста
+func (oms *usecase) appendOrderEvent(txCtx context.Context, event models.OrderEvent) error {
+	if event.Actor.Type == "" {
+		event.Actor = actorFromContext(txCtx)
+	}
+	return oms.OrdersStorage.AppendOrderEvent(txCtx, event)
+}
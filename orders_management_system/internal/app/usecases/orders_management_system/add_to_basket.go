@@ -0,0 +1,47 @@
+package orders_management_system
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// AddToBasket - добавление товара в корзину
+func (oms *usecase) AddToBasket(ctx context.Context, userID models.UserID, item models.Item) (*models.Basket, error) {
+	const api = "orders_management_system.usecase.AddToBasket"
+
+	if err := validateBasketItem(item); err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	basket := &models.Basket{UserID: userID}
+	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error {
+		if err := oms.CheckoutStorage.AddItem(txCtx, userID, item); err != nil {
+			return err
+		}
+
+		// Количество суммируется с уже лежащим в корзине - лимиты проверяем по итоговой корзине
+		items, err := oms.CheckoutStorage.GetItems(txCtx, userID)
+		if err != nil {
+			return err
+		}
+		if err := validateBasket(items); err != nil {
+			return err
+		}
+
+		basket.Items = items
+		return nil
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return basket, nil
+}
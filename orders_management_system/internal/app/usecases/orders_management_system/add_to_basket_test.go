@@ -0,0 +1,135 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_AddToBasket(t *testing.T) {
+	var (
+		ctx  = context.Background() // dummy
+		item = models.Item{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}
+	)
+	type fields struct {
+		TransactionManager *mocks.TransactionManager
+		CheckoutStorage    *mocks.CheckoutStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	type args struct {
+		ctx    context.Context
+		userID models.UserID
+		item   models.Item
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *models.Basket
+		wantErr error
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name: "Test 1. Positive.",
+			args: args{ctx: ctx, userID: 1, item: item},
+			want: &models.Basket{
+				UserID: 1,
+				Items:  []models.BasketItem{{Item: item}},
+			},
+
+			on: func(f *fields) {
+				f.CheckoutStorage.On("AddItem", ctx, models.UserID(1), item).
+					Return(nil)
+				f.CheckoutStorage.On("GetItems", ctx, models.UserID(1)).
+					Return([]models.BasketItem{{Item: item}}, nil)
+			},
+		},
+		{
+			name: "Test 2. Positive. Warehouse is chosen at checkout.",
+			args: args{ctx: ctx, userID: 1, item: models.Item{SKU: models.SKU{ID: 2}, Quantity: 3}},
+			want: &models.Basket{
+				UserID: 1,
+				Items:  []models.BasketItem{{Item: models.Item{SKU: models.SKU{ID: 2}, Quantity: 3}}},
+			},
+
+			on: func(f *fields) {
+				f.CheckoutStorage.On("AddItem", ctx, models.UserID(1), models.Item{SKU: models.SKU{ID: 2}, Quantity: 3}).
+					Return(nil)
+				f.CheckoutStorage.On("GetItems", ctx, models.UserID(1)).
+					Return([]models.BasketItem{{Item: models.Item{SKU: models.SKU{ID: 2}, Quantity: 3}}}, nil)
+			},
+		},
+		{
+			name:    "Test 3. Negative. Zero quantity.",
+			args:    args{ctx: ctx, userID: 1, item: models.Item{SKU: models.SKU{ID: 2}, WarehouseID: 4}},
+			wantErr: models.ErrInvalidArgument,
+
+			assert: func(t *testing.T, f *fields) {
+				f.CheckoutStorage.AssertNumberOfCalls(t, "AddItem", 0)
+			},
+		},
+		{
+			name:    "Test 4. Negative. Quantity limit exceeded after merge.",
+			args:    args{ctx: ctx, userID: 1, item: item},
+			wantErr: models.ErrInvalidArgument,
+
+			on: func(f *fields) {
+				merged := item
+				merged.Quantity = maxBasketItemQuantity + 1
+				f.CheckoutStorage.On("AddItem", ctx, models.UserID(1), item).
+					Return(nil)
+				f.CheckoutStorage.On("GetItems", ctx, models.UserID(1)).
+					Return([]models.BasketItem{{Item: merged}}, nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager: mocks.NewTransactionManager(t),
+				CheckoutStorage:    mocks.NewCheckoutStorage(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction).
+				Maybe()
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager: f.TransactionManager,
+					CheckoutStorage:    f.CheckoutStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			got, err := oms.AddToBasket(tt.args.ctx, tt.args.userID, tt.args.item)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("usecase.AddToBasket() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// assert
+			assert.Equal(t, tt.want, got)
+
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package orders_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
+
+// allocateWarehouses - выбор складов для позиций без склада: доступные стоки берем у Inventory,
+// склады выбирает AllocationStrategy. Стоки могут измениться до резерва -
+// тогда резерв вернет models.ErrOutOfStock, как и при выборе склада клиентом
+func (oms *usecase) allocateWarehouses(
+	ctx context.Context,
+	items []models.Item,
+	deliveryVariantID models.DeliveryVariantID,
+) ([]models.Item, error) {
+	if !models.NeedsAllocation(items) {
+		return items, nil
+	}
+
+	// Стоки нужны и по SKU с выбранным складом: стратегия учитывает, что они уже заняты
+	stocks, err := oms.Inventory.GetStocks(ctx, models.UniqueSKUs(items), deliveryVariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	return oms.AllocationStrategy.Allocate(items, stocks)
+}
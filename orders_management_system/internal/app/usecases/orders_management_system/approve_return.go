@@ -0,0 +1,83 @@
+package orders_management_system
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// ApproveReturn - одобрение возврата
+func (oms *usecase) ApproveReturn(ctx context.Context, returnID models.ReturnID) (*models.Return, error) {
+	const api = "orders_management_system.usecase.ApproveReturn"
+
+	var ret *models.Return
+	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		var err error
+		if ret, err = oms.ReturnsStorage.LockReturn(txCtx, returnID); err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if err := ret.SetStatus(models.ReturnStatusApproved, now); err != nil {
+			return err
+		}
+		if err := oms.ReturnsStorage.UpdateReturn(txCtx, ret); err != nil {
+			return err
+		}
+
+		order, err := oms.OrdersStorage.GetOrder(txCtx, ret.OrderID)
+		if err != nil {
+			return err
+		}
+
+		// Публикуем событие в outbox табличке: по нему WMS вернет товары на склады
+		if err := oms.appendOrderEvent(txCtx, models.NewOrderReturnApprovedEvent(order, *ret)); err != nil {
+			return err
+		}
+
+		// Возврат денег последним шагом: если провайдер ответит ошибкой - одобрение откатится целиком
+		return oms.refund(txCtx, ret.OrderID, ret.Refund, ret.ID.String(), now)
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return ret, nil
+}
+
+// refund - возврат amount на списанный платеж заказа. refundID - ключ идемпотентности возврата у провайдера
+func (oms *usecase) refund(txCtx context.Context, orderID models.OrderID, amount models.Money, refundID string, at time.Time) error {
+	if amount.IsZero() {
+		return nil
+	}
+
+	payments, err := oms.PaymentsStorage.ListOrderPayments(txCtx, orderID)
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		if payment.Status != models.PaymentStatusCaptured {
+			continue
+		}
+
+		if err := payment.Refund(amount, at); err != nil {
+			return err
+		}
+		if err := oms.PaymentsStorage.UpdatePayment(txCtx, payment); err != nil {
+			return err
+		}
+		return oms.Payments.Refund(txCtx, payment.ProviderPaymentID, amount, refundID)
+	}
+
+	return fmt.Errorf("%w: order %s has no captured payment", models.ErrInvalidReturn, orderID)
+}
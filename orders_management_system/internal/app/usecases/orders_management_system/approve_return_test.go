@@ -0,0 +1,155 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_ApproveReturn(t *testing.T) {
+	var (
+		ctx      = context.Background() // dummy
+		orderID  = models.OrderID(uuid.New())
+		returnID = models.ReturnID(uuid.New())
+		rub      = func(amount int64) models.Money { return models.NewMoney(amount, models.CurrencyRUB) }
+		ret      = func(status models.ReturnStatus) *models.Return {
+			return &models.Return{
+				ID:      returnID,
+				OrderID: orderID,
+				Items:   []models.Item{{SKU: models.SKU{ID: 1}, Quantity: 1, WarehouseID: 10, Price: rub(1000)}},
+				Refund:  rub(1000),
+				Status:  status,
+			}
+		}
+		order = &models.Order{ID: orderID, UserID: 1, Status: models.OrderStatusDelivered}
+	)
+	type fields struct {
+		TransactionManager *mocks.TransactionManager
+		Payments           *mocks.Payments
+		PaymentsStorage    *mocks.PaymentsStorage
+		ReturnsStorage     *mocks.ReturnsStorage
+		OrdersStorage      *mocks.OrdersStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	tests := []struct {
+		name    string
+		wantErr error
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name: "Test 1. Positive. Partial refund of captured payment.",
+			on: func(f *fields) {
+				f.ReturnsStorage.On("LockReturn", ctx, returnID).Return(ret(models.ReturnStatusRequested), nil)
+				f.ReturnsStorage.On("UpdateReturn", ctx, mock.MatchedBy(func(ret *models.Return) bool {
+					return ret.Status == models.ReturnStatusApproved
+				})).Return(nil)
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order, nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventReturnApproved && event.Return.Items[0].WarehouseID == 10
+				})).Return(nil)
+				f.PaymentsStorage.On("ListOrderPayments", ctx, orderID).Return([]*models.Payment{
+					{OrderID: orderID, Amount: rub(3000), Status: models.PaymentStatusFailed, ProviderPaymentID: "p-1"},
+					{OrderID: orderID, Amount: rub(3000), Refunded: rub(500), Status: models.PaymentStatusCaptured, ProviderPaymentID: "p-2"},
+				}, nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.Refunded == rub(1500) && payment.Status == models.PaymentStatusCaptured
+				})).Return(nil)
+				f.Payments.On("Refund", ctx, "p-2", rub(1000), returnID.String()).Return(nil)
+			},
+		},
+		{
+			name:    "Test 2. Negative. Already approved.",
+			wantErr: models.ErrInvalidStatusTransition,
+			on: func(f *fields) {
+				f.ReturnsStorage.On("LockReturn", ctx, returnID).Return(ret(models.ReturnStatusApproved), nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.Payments.AssertNumberOfCalls(t, "Refund", 0)
+			},
+		},
+		{
+			name:    "Test 3. Negative. No captured payment.",
+			wantErr: models.ErrInvalidReturn,
+			on: func(f *fields) {
+				f.ReturnsStorage.On("LockReturn", ctx, returnID).Return(ret(models.ReturnStatusRequested), nil)
+				f.ReturnsStorage.On("UpdateReturn", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order, nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.PaymentsStorage.On("ListOrderPayments", ctx, orderID).Return(nil, nil)
+			},
+		},
+		{
+			name:    "Test 4. Negative. Payments returns error.",
+			wantErr: models.ErrServiceUnavailable,
+			on: func(f *fields) {
+				f.ReturnsStorage.On("LockReturn", ctx, returnID).Return(ret(models.ReturnStatusRequested), nil)
+				f.ReturnsStorage.On("UpdateReturn", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order, nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.PaymentsStorage.On("ListOrderPayments", ctx, orderID).Return([]*models.Payment{
+					{OrderID: orderID, Amount: rub(3000), Status: models.PaymentStatusCaptured, ProviderPaymentID: "p-1"},
+				}, nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.Anything).Return(nil)
+				f.Payments.On("Refund", ctx, "p-1", rub(1000), returnID.String()).Return(models.ErrServiceUnavailable)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager: mocks.NewTransactionManager(t),
+				Payments:           mocks.NewPayments(t),
+				PaymentsStorage:    mocks.NewPaymentsStorage(t),
+				ReturnsStorage:     mocks.NewReturnsStorage(t),
+				OrdersStorage:      mocks.NewOrdersStorage(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction)
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager: f.TransactionManager,
+					Payments:           f.Payments,
+					PaymentsStorage:    f.PaymentsStorage,
+					ReturnsStorage:     f.ReturnsStorage,
+					OrdersStorage:      f.OrdersStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			got, err := oms.ApproveReturn(ctx, returnID)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.ReturnStatusApproved, got.Status)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package orders_management_system
+
+import (
+	"fmt"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
+
+const (
+	// maxBasketItems - максимальное количество позиций в корзине
+	maxBasketItems = 100
+	// maxBasketItemQuantity - максимальное количество одного SKU в корзине
+	maxBasketItemQuantity = 1000
+)
+
+// validateBasketItem - проверка товара, который кладут в корзину.
+// Склад можно не указывать: его выберет allocateWarehouses при оформлении заказа
+func validateBasketItem(item models.Item) error {
+	if item.SKU.ID == 0 {
+		return fmt.Errorf("sku_id must be positive: %w", models.ErrInvalidArgument)
+	}
+	if item.Quantity == 0 {
+		return fmt.Errorf("sku %d: quantity must be positive: %w", item.SKU.ID, models.ErrInvalidArgument)
+	}
+	if item.Quantity > maxBasketItemQuantity {
+		return fmt.Errorf("sku %d: quantity must be at most %d: %w", item.SKU.ID, maxBasketItemQuantity, models.ErrInvalidArgument)
+	}
+	return nil
+}
+
+// validateBasket - проверка лимитов корзины после изменения
+func validateBasket(items []models.BasketItem) error {
+	if len(items) > maxBasketItems {
+		return fmt.Errorf("basket must contain at most %d items: %w", maxBasketItems, models.ErrInvalidArgument)
+	}
+	for i := range items {
+		if items[i].Quantity > maxBasketItemQuantity {
+			return fmt.Errorf("sku %d: quantity must be at most %d: %w", items[i].SKU.ID, maxBasketItemQuantity, models.ErrInvalidArgument)
+		}
+	}
+	return nil
+}
+
+// sameBasketItems - совпадает ли состав корзины (SKU, количество, склад)
+func sameBasketItems(a, b []models.BasketItem) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	items := make(map[models.SKUID]models.Item, len(a))
+	for i := range a {
+		items[a[i].SKU.ID] = a[i].Item
+	}
+	for i := range b {
+		item, ok := items[b[i].SKU.ID]
+		if !ok || item.Quantity != b[i].Quantity || item.WarehouseID != b[i].WarehouseID {
+			return false
+		}
+	}
+	return true
+}
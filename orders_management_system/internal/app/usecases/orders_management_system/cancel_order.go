@@ -0,0 +1,146 @@
+package orders_management_system
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// CancelOrder - отмена заказа
+func (oms *usecase) CancelOrder(ctx context.Context, orderID models.OrderID, reason string) (*models.Order, error) {
+	const api = "orders_management_system.usecase.CancelOrder"
+
+	var order *models.Order
+	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		var err error
+		if order, err = oms.OrdersStorage.GetOrder(txCtx, orderID); err != nil {
+			return err
+		}
+
+		return oms.cancelOrder(txCtx, order, reason)
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return order, nil
+}
+
+// cancelOrder - отмена заказа в транзакции txCtx: статус, событие в outbox, применение промокода и платежи в БД.
+// Снятие резерва и вызовы провайдера записываются в order_cancellations и выполняются после коммита
+// (CompleteCancellations): при откате отмены резерв и деньги остаются на месте
+func (oms *usecase) cancelOrder(txCtx context.Context, order *models.Order, reason string) error {
+	// Запоминаем до смены статуса: снимать резерв и отменять платеж нужно только если они были
+	var (
+		hasReservation = hasStocksReserved(order.Status) && !order.ReservationID.IsZero()
+		hasPayment     = hasActivePayment(order.Status)
+	)
+
+	// Отменить можно только еще не отгруженный заказ
+	transition, err := order.SetStatus(models.OrderStatusCancelled, time.Now().UTC())
+	if err != nil {
+		return err
+	}
+	if err := oms.OrdersStorage.UpdateOrderStatus(txCtx, *transition); err != nil {
+		return err
+	}
+
+	// Публикуем событие в outbox табличке, которое будет обработоно асинхронно позже
+	if err := oms.appendOrderEvent(txCtx, models.NewOrderCancelledEvent(order, *transition, reason)); err != nil {
+		return err
+	}
+
+	// Отмененный заказ не расходует лимиты промокода
+	if order.PromoCode != "" {
+		if err := oms.PromoStorage.DeleteRedemption(txCtx, order.ID); err != nil {
+			return err
+		}
+	}
+
+	cancellation := models.OrderCancellation{OrderID: order.ID, CreatedAt: transition.ChangedAt}
+	if hasPayment {
+		if err := oms.cancelPayments(txCtx, order.ID, &cancellation); err != nil {
+			return err
+		}
+	}
+	if hasReservation {
+		cancellation.ReservationID = order.ReservationID
+		cancellation.Warehouses, _ = models.GroupItemsByWarehouse(order.Items)
+	}
+	if cancellation.IsEmpty() {
+		return nil
+	}
+
+	return oms.CancellationsStorage.CreateOrderCancellation(txCtx, cancellation)
+}
+
+// hasStocksReserved - держит ли заказ в данном статусе резерв стоков на складах
+func hasStocksReserved(status models.OrderStatus) bool {
+	switch status {
+	case models.OrderStatusReserved, models.OrderStatusAwaitingPayment, models.OrderStatusPaymentFailed, models.OrderStatusPaid:
+		return true
+	default:
+		return false
+	}
+}
+
+// hasActivePayment - может ли у заказа в данном статусе быть незавершенный или списанный платеж
+func hasActivePayment(status models.OrderStatus) bool {
+	switch status {
+	case models.OrderStatusAwaitingPayment, models.OrderStatusPaid:
+		return true
+	default:
+		return false
+	}
+}
+
+// cancelPayments - отмена незавершенных платежей заказа и возврат списанных: статусы сохраняются сразу,
+// а запросы к провайдеру добавляются в cancellation
+func (oms *usecase) cancelPayments(txCtx context.Context, orderID models.OrderID, cancellation *models.OrderCancellation) error {
+	payments, err := oms.PaymentsStorage.ListOrderPayments(txCtx, orderID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	for _, payment := range payments {
+		switch payment.Status {
+		case models.PaymentStatusPending, models.PaymentStatusAuthorized:
+			if err := payment.SetStatus(models.PaymentStatusVoided, now); err != nil {
+				return err
+			}
+			if err := oms.PaymentsStorage.UpdatePayment(txCtx, payment); err != nil {
+				return err
+			}
+			// провайдер еще не ответил на Authorize - отменять у него нечего
+			if payment.ProviderPaymentID != "" {
+				cancellation.Voids = append(cancellation.Voids, payment.ProviderPaymentID)
+			}
+		case models.PaymentStatusCaptured:
+			amount := payment.Refundable()
+			if amount.IsZero() {
+				continue
+			}
+			if err := payment.Refund(amount, now); err != nil {
+				return err
+			}
+			if err := oms.PaymentsStorage.UpdatePayment(txCtx, payment); err != nil {
+				return err
+			}
+			cancellation.Refunds = append(cancellation.Refunds, models.PaymentRefund{
+				ProviderPaymentID: payment.ProviderPaymentID,
+				Amount:            amount,
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// CancelOrderItems - отмена части товаров заказа
+func (oms *usecase) CancelOrderItems(ctx context.Context, orderID models.OrderID, items []models.Item) (*CancelOrderItemsResult, error) {
+	const api = "orders_management_system.usecase.CancelOrderItems"
+
+	var res *CancelOrderItemsResult
+	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		// Блокируем заказ: параллельные отмены и оплата не должны видеть старый состав
+		order, err := oms.OrdersStorage.LockOrder(txCtx, orderID)
+		if err != nil {
+			return err
+		}
+		if !canCancelItems(order.Status) {
+			return fmt.Errorf("%w: order is %s", models.ErrInvalidItemsCancellation, order.Status)
+		}
+
+		now := time.Now().UTC()
+		total := order.Totals.Total
+		cancelled, changes, err := order.CancelItems(items, now)
+		if err != nil {
+			return err
+		}
+
+		if err := oms.OrdersStorage.UpdateOrderItems(txCtx, order, changes); err != nil {
+			return err
+		}
+
+		// Публикуем событие в outbox табличке, которое будет обработоно асинхронно позже
+		event := models.NewOrderItemsCancelledEvent(order, cancelled, now)
+		if err := oms.appendOrderEvent(txCtx, event); err != nil {
+			return err
+		}
+
+		// Внешние вызовы последним шагом: если WMS или провайдер ответят ошибкой - отмена откатится целиком.
+		// Уменьшение резерва идемпотентно, поэтому выполняется первым: повтор после отката ничего не сломает.
+		// Возврат - последним, с ключом по версии заказа: повтор после отката не вернет деньги второй раз
+		if !order.ReservationID.IsZero() {
+			if err := oms.shrinkStocks(txCtx, order.ReservationID, order.Items, cancelled); err != nil {
+				return err
+			}
+		}
+		var refund models.Money
+		if order.Status == models.OrderStatusPaid {
+			if refund, err = total.Sub(order.Totals.Total); err != nil {
+				return err
+			}
+			if err := oms.refund(txCtx, order.ID, refund, event.RefundID(), now); err != nil {
+				return err
+			}
+		}
+
+		res = &CancelOrderItemsResult{
+			Order:          order,
+			CancelledItems: cancelled,
+			Refund:         refund,
+		}
+		return nil
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return res, nil
+}
+
+// canCancelItems - можно ли в данном статусе отменить часть товаров заказа.
+// Во время оплаты нельзя: провайдер авторизует сумму заказа до отмены
+func canCancelItems(status models.OrderStatus) bool {
+	switch status {
+	case models.OrderStatusReserved, models.OrderStatusPaymentFailed, models.OrderStatusPaid:
+		return true
+	default:
+		return false
+	}
+}
+
+// shrinkStocks - уменьшение резерва заказа до items на складах, с которых отменены товары cancelled
+func (oms *usecase) shrinkStocks(ctx context.Context, reservationID models.ReservationID, items, cancelled []models.Item) error {
+	var (
+		warehouses, _ = models.GroupItemsByWarehouse(cancelled)
+		_, groups     = models.GroupItemsByWarehouse(items)
+
+		mu   sync.Mutex
+		errs []error
+	)
+	oms.forEachWarehouse(warehouses, func(warehouseID models.WarehouseID) {
+		if err := oms.WarehouseManagementSystem.ShrinkReservation(ctx, reservationID.ForWarehouse(warehouseID), groups[warehouseID]); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+	})
+	return errors.Join(errs...)
+}
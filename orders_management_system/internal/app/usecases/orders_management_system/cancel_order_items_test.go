@@ -0,0 +1,248 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_CancelOrderItems(t *testing.T) {
+	var (
+		ctx           = context.Background() // dummy
+		orderID       = models.OrderID(uuid.New())
+		reservationID = models.ReservationID(uuid.New())
+		rub           = func(amount int64) models.Money { return models.NewMoney(amount, models.CurrencyRUB) }
+		order         = func(status models.OrderStatus) *models.Order {
+			return &models.Order{
+				ID:     orderID,
+				UserID: 1,
+				Items: []models.Item{
+					{SKU: models.SKU{ID: 1}, Quantity: 2, WarehouseID: 10, Price: rub(1000)},
+					{SKU: models.SKU{ID: 2}, Quantity: 1, WarehouseID: 20, Price: rub(500)},
+				},
+				Totals:        models.OrderTotals{Subtotal: rub(2500), Delivery: rub(500), Total: rub(3000)},
+				Status:        status,
+				ReservationID: reservationID,
+				Version:       3,
+			}
+		}
+		cancel = []models.Item{{SKU: models.SKU{ID: 1}, Quantity: 1}}
+		// на складе 10 остается одна единица SKU 1
+		left = []models.Item{{SKU: models.SKU{ID: 1}, Quantity: 1, WarehouseID: 10, Price: rub(1000)}}
+		// событие отмены - четвертое в потоке заказа
+		refundID = orderID.String() + "-4"
+	)
+	type fields struct {
+		TransactionManager        *mocks.TransactionManager
+		WarehouseManagementSystem *mocks.WarehouseManagementSystem
+		Payments                  *mocks.Payments
+		PaymentsStorage           *mocks.PaymentsStorage
+		OrdersStorage             *mocks.OrdersStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	tests := []struct {
+		name       string
+		wantErr    error
+		wantRefund models.Money
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name: "Test 1. Positive. Reserved order: reservation shrinks only on affected warehouse.",
+			on: func(f *fields) {
+				f.OrdersStorage.On("LockOrder", ctx, orderID).Return(order(models.OrderStatusReserved), nil)
+				f.OrdersStorage.On("UpdateOrderItems", ctx, mock.MatchedBy(func(o *models.Order) bool {
+					return o.Totals.Total == rub(2000) && len(o.Items) == 2
+				}), mock.MatchedBy(func(changes []models.OrderItemChange) bool {
+					return len(changes) == 1 && changes[0].FromQuantity == 2 && changes[0].ToQuantity == 1
+				})).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventItemsCancelled && event.Items[0].Quantity == 1
+				})).Return(nil)
+				f.WarehouseManagementSystem.On("ShrinkReservation", ctx, reservationID.ForWarehouse(10), left).Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.PaymentsStorage.AssertNumberOfCalls(t, "ListOrderPayments", 0)
+			},
+		},
+		{
+			name:       "Test 2. Positive. Paid order: difference is refunded.",
+			wantRefund: rub(1000),
+			on: func(f *fields) {
+				f.OrdersStorage.On("LockOrder", ctx, orderID).Return(order(models.OrderStatusPaid), nil)
+				f.OrdersStorage.On("UpdateOrderItems", ctx, mock.Anything, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.PaymentsStorage.On("ListOrderPayments", ctx, orderID).Return([]*models.Payment{
+					{OrderID: orderID, Amount: rub(3000), Status: models.PaymentStatusCaptured, ProviderPaymentID: "p-1"},
+				}, nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.Refunded == rub(1000)
+				})).Return(nil)
+				f.Payments.On("Refund", ctx, "p-1", rub(1000), refundID).Return(nil)
+				f.WarehouseManagementSystem.On("ShrinkReservation", ctx, reservationID.ForWarehouse(10), left).Return(nil)
+			},
+		},
+		{
+			name:    "Test 3. Negative. Order is awaiting payment.",
+			wantErr: models.ErrInvalidItemsCancellation,
+			on: func(f *fields) {
+				f.OrdersStorage.On("LockOrder", ctx, orderID).Return(order(models.OrderStatusAwaitingPayment), nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.OrdersStorage.AssertNumberOfCalls(t, "UpdateOrderItems", 0)
+			},
+		},
+		{
+			name:    "Test 4. Negative. WMS is unavailable.",
+			wantErr: models.ErrServiceUnavailable,
+			on: func(f *fields) {
+				f.OrdersStorage.On("LockOrder", ctx, orderID).Return(order(models.OrderStatusPaymentFailed), nil)
+				f.OrdersStorage.On("UpdateOrderItems", ctx, mock.Anything, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.WarehouseManagementSystem.On("ShrinkReservation", ctx, mock.Anything, mock.Anything).Return(models.ErrServiceUnavailable)
+			},
+		},
+		{
+			name:    "Test 5. Negative. Paid order: WMS is unavailable, nothing is refunded.",
+			wantErr: models.ErrServiceUnavailable,
+			on: func(f *fields) {
+				f.OrdersStorage.On("LockOrder", ctx, orderID).Return(order(models.OrderStatusPaid), nil)
+				f.OrdersStorage.On("UpdateOrderItems", ctx, mock.Anything, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.WarehouseManagementSystem.On("ShrinkReservation", ctx, mock.Anything, mock.Anything).Return(models.ErrServiceUnavailable)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.PaymentsStorage.AssertNumberOfCalls(t, "ListOrderPayments", 0)
+				f.Payments.AssertNumberOfCalls(t, "Refund", 0)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager:        mocks.NewTransactionManager(t),
+				WarehouseManagementSystem: mocks.NewWarehouseManagementSystem(t),
+				Payments:                  mocks.NewPayments(t),
+				PaymentsStorage:           mocks.NewPaymentsStorage(t),
+				OrdersStorage:             mocks.NewOrdersStorage(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction)
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager:        f.TransactionManager,
+					WarehouseManagementSystem: f.WarehouseManagementSystem,
+					Payments:                  f.Payments,
+					PaymentsStorage:           f.PaymentsStorage,
+					OrdersStorage:             f.OrdersStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			got, err := oms.CancelOrderItems(ctx, orderID, cancel)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantRefund, got.Refund)
+				assert.Equal(t, rub(2000), got.Order.Totals.Total)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
+
+func Test_usecase_CancelOrderItems_Retry(t *testing.T) {
+	var (
+		ctx     = context.Background() // dummy
+		orderID = models.OrderID(uuid.New())
+		rub     = func(amount int64) models.Money { return models.NewMoney(amount, models.CurrencyRUB) }
+		cancel  = []models.Item{{SKU: models.SKU{ID: 1}, Quantity: 1}}
+	)
+
+	// возврат прошел, но транзакция откатилась: повтор отправляет возврат с тем же ключом
+	var (
+		transactionManager = mocks.NewTransactionManager(t)
+		wms                = mocks.NewWarehouseManagementSystem(t)
+		payments           = mocks.NewPayments(t)
+		paymentsStorage    = mocks.NewPaymentsStorage(t)
+		ordersStorage      = mocks.NewOrdersStorage(t)
+		refundIDs          []string
+	)
+	// каждая попытка читает заказ заново: после отката он не изменился
+	ordersStorage.On("LockOrder", ctx, orderID).Return(func(context.Context, models.OrderID) (*models.Order, error) {
+		return &models.Order{
+			ID:            orderID,
+			UserID:        1,
+			Items:         []models.Item{{SKU: models.SKU{ID: 1}, Quantity: 2, WarehouseID: 10, Price: rub(1000)}},
+			Totals:        models.OrderTotals{Subtotal: rub(2000), Total: rub(2000)},
+			Status:        models.OrderStatusPaid,
+			ReservationID: models.ReservationID(uuid.New()),
+			Version:       3,
+		}, nil
+	})
+	ordersStorage.On("UpdateOrderItems", ctx, mock.Anything, mock.Anything).Return(nil)
+	ordersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+	wms.On("ShrinkReservation", ctx, mock.Anything, mock.Anything).Return(nil)
+	paymentsStorage.On("ListOrderPayments", ctx, orderID).Return(func(context.Context, models.OrderID) ([]*models.Payment, error) {
+		return []*models.Payment{
+			{OrderID: orderID, Amount: rub(2000), Status: models.PaymentStatusCaptured, ProviderPaymentID: "p-1"},
+		}, nil
+	})
+	paymentsStorage.On("UpdatePayment", ctx, mock.Anything).Return(nil)
+	payments.On("Refund", ctx, "p-1", rub(1000), mock.Anything).
+		Run(func(args mock.Arguments) { refundIDs = append(refundIDs, args.String(3)) }).
+		Return(nil)
+	// первая попытка: возврат прошел, но коммит не удался
+	transactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+			return errors.Join(f(ctx), errors.New("commit failed"))
+		}).Once()
+	transactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+			return f(ctx)
+		}).Once()
+	oms := &usecase{
+		Deps: Deps{
+			TransactionManager:        transactionManager,
+			WarehouseManagementSystem: wms,
+			Payments:                  payments,
+			PaymentsStorage:           paymentsStorage,
+			OrdersStorage:             ordersStorage,
+		},
+	}
+
+	_, err := oms.CancelOrderItems(ctx, orderID, cancel)
+	assert.Error(t, err)
+	got, err := oms.CancelOrderItems(ctx, orderID, cancel)
+	assert.NoError(t, err)
+	assert.Equal(t, rub(1000), got.Refund)
+
+	// провайдер получил оба запроса с одним ключом и вернет деньги один раз
+	assert.Equal(t, []string{orderID.String() + "-4", orderID.String() + "-4"}, refundIDs)
+}
@@ -0,0 +1,258 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_CancelOrder(t *testing.T) {
+	var (
+		ctx           = context.Background() // dummy
+		orderID       = models.OrderID(uuid.New())
+		reservationID = models.ReservationID(uuid.New())
+		items         = []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}}
+		amount        = models.NewMoney(1000, models.CurrencyRUB)
+		reason        = "changed my mind"
+	)
+	type fields struct {
+		TransactionManager        *mocks.TransactionManager
+		WarehouseManagementSystem *mocks.WarehouseManagementSystem
+		Payments                  *mocks.Payments
+		PaymentsStorage           *mocks.PaymentsStorage
+		CancellationsStorage      *mocks.CancellationsStorage
+		PromoStorage              *mocks.PromoStorage
+		OrdersStorage             *mocks.OrdersStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+	// cancellation - действия отмены без времени создания
+	cancellation := func(want models.OrderCancellation) any {
+		return mock.MatchedBy(func(got models.OrderCancellation) bool {
+			got.CreatedAt = time.Time{}
+			return assert.ObjectsAreEqual(want, got)
+		})
+	}
+
+	tests := []struct {
+		name       string
+		wantStatus models.OrderStatus
+		wantErr    error
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name:       "Test 1. Positive. Reserved order releases stocks after commit.",
+			wantStatus: models.OrderStatusCancelled,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).
+					Return(&models.Order{ID: orderID, UserID: 1, Items: items, ReservationID: reservationID, Status: models.OrderStatusReserved}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.MatchedBy(func(transition models.OrderStatusTransition) bool {
+					return transition.OrderID == orderID &&
+						transition.From == models.OrderStatusReserved &&
+						transition.To == models.OrderStatusCancelled
+				})).
+					Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventCancelled &&
+						event.Order.ID == orderID &&
+						event.Transition.From == models.OrderStatusReserved &&
+						event.Reason == reason &&
+						event.Actor.Type == models.ActorSystem
+				})).
+					Return(nil)
+				f.CancellationsStorage.On("CreateOrderCancellation", ctx, cancellation(models.OrderCancellation{
+					OrderID:       orderID,
+					ReservationID: reservationID,
+					Warehouses:    []models.WarehouseID{4},
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Test 2. Positive. New order has nothing to release.",
+			wantStatus: models.OrderStatusCancelled,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).
+					Return(&models.Order{ID: orderID, UserID: 1, Items: items, ReservationID: reservationID, Status: models.OrderStatusNew}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.CancellationsStorage.AssertNumberOfCalls(t, "CreateOrderCancellation", 0)
+			},
+		},
+		{
+			name:    "Test 3. Negative. Shipped order can't be cancelled.",
+			wantErr: models.ErrInvalidStatusTransition,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).
+					Return(&models.Order{ID: orderID, UserID: 1, Items: items, ReservationID: reservationID, Status: models.OrderStatusShipped}, nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.OrdersStorage.AssertNumberOfCalls(t, "UpdateOrderStatus", 0)
+			},
+		},
+		{
+			name:    "Test 4. Negative. Cancellation is not saved.",
+			wantErr: models.ErrUnimplemented,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).
+					Return(&models.Order{ID: orderID, UserID: 1, Items: items, ReservationID: reservationID, Status: models.OrderStatusPaid}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.PaymentsStorage.On("ListOrderPayments", ctx, orderID).Return(nil, nil)
+				f.CancellationsStorage.On("CreateOrderCancellation", ctx, mock.Anything).Return(models.ErrUnimplemented)
+			},
+		},
+		{
+			name:       "Test 5. Positive. Paid order refunds captured payment after commit.",
+			wantStatus: models.OrderStatusCancelled,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).
+					Return(&models.Order{ID: orderID, UserID: 1, Items: items, ReservationID: reservationID, Status: models.OrderStatusPaid}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.PaymentsStorage.On("ListOrderPayments", ctx, orderID).Return([]*models.Payment{
+					{OrderID: orderID, Amount: amount, Status: models.PaymentStatusFailed, ProviderPaymentID: "p-1"},
+					{OrderID: orderID, Amount: amount, Status: models.PaymentStatusCaptured, ProviderPaymentID: "p-2"},
+				}, nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.ProviderPaymentID == "p-2" && payment.Status == models.PaymentStatusRefunded
+				})).Return(nil)
+				f.CancellationsStorage.On("CreateOrderCancellation", ctx, cancellation(models.OrderCancellation{
+					OrderID:       orderID,
+					ReservationID: reservationID,
+					Warehouses:    []models.WarehouseID{4},
+					Refunds:       []models.PaymentRefund{{ProviderPaymentID: "p-2", Amount: amount}},
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Test 6. Positive. Awaiting payment order voids pending payment after commit.",
+			wantStatus: models.OrderStatusCancelled,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).
+					Return(&models.Order{ID: orderID, UserID: 1, Items: items, ReservationID: reservationID, Status: models.OrderStatusAwaitingPayment}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.PaymentsStorage.On("ListOrderPayments", ctx, orderID).Return([]*models.Payment{
+					{OrderID: orderID, Amount: amount, Status: models.PaymentStatusPending, ProviderPaymentID: "p-1"},
+				}, nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.Status == models.PaymentStatusVoided
+				})).Return(nil)
+				f.CancellationsStorage.On("CreateOrderCancellation", ctx, cancellation(models.OrderCancellation{
+					OrderID:       orderID,
+					ReservationID: reservationID,
+					Warehouses:    []models.WarehouseID{4},
+					Voids:         []string{"p-1"},
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Test 7. Positive. Payment unknown to provider is voided only in OMS.",
+			wantStatus: models.OrderStatusCancelled,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).
+					Return(&models.Order{ID: orderID, UserID: 1, Items: items, ReservationID: reservationID, Status: models.OrderStatusAwaitingPayment}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.PaymentsStorage.On("ListOrderPayments", ctx, orderID).Return([]*models.Payment{
+					{OrderID: orderID, Amount: amount, Status: models.PaymentStatusPending},
+				}, nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.Anything).Return(nil)
+				f.CancellationsStorage.On("CreateOrderCancellation", ctx, cancellation(models.OrderCancellation{
+					OrderID:       orderID,
+					ReservationID: reservationID,
+					Warehouses:    []models.WarehouseID{4},
+				})).Return(nil)
+			},
+		},
+		{
+			name:       "Test 8. Positive. Promo code redemption is reverted.",
+			wantStatus: models.OrderStatusCancelled,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).
+					Return(&models.Order{ID: orderID, UserID: 1, Items: items, PromoCode: "SALE10", Status: models.OrderStatusNew}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.PromoStorage.On("DeleteRedemption", ctx, orderID).Return(nil)
+			},
+		},
+		{
+			name:    "Test 9. Negative. Promo code redemption is not reverted.",
+			wantErr: models.ErrUnimplemented,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).
+					Return(&models.Order{ID: orderID, UserID: 1, Items: items, PromoCode: "SALE10", Status: models.OrderStatusNew}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.PromoStorage.On("DeleteRedemption", ctx, orderID).Return(models.ErrUnimplemented)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager:        mocks.NewTransactionManager(t),
+				WarehouseManagementSystem: mocks.NewWarehouseManagementSystem(t),
+				Payments:                  mocks.NewPayments(t),
+				PaymentsStorage:           mocks.NewPaymentsStorage(t),
+				CancellationsStorage:      mocks.NewCancellationsStorage(t),
+				PromoStorage:              mocks.NewPromoStorage(t),
+				OrdersStorage:             mocks.NewOrdersStorage(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction)
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager:        f.TransactionManager,
+					WarehouseManagementSystem: f.WarehouseManagementSystem,
+					Payments:                  f.Payments,
+					PaymentsStorage:           f.PaymentsStorage,
+					CancellationsStorage:      f.CancellationsStorage,
+					PromoStorage:              f.PromoStorage,
+					OrdersStorage:             f.OrdersStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			got, err := oms.CancelOrder(ctx, orderID, reason)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantStatus, got.Status)
+			}
+
+			// внешние вызовы - только после коммита отмены (CompleteCancellations)
+			f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReleaseReservation", 0)
+			f.Payments.AssertNumberOfCalls(t, "Void", 0)
+			f.Payments.AssertNumberOfCalls(t, "Refund", 0)
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
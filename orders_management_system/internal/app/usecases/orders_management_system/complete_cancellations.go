@@ -0,0 +1,91 @@
+package orders_management_system
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+)
+
+const (
+	cancellationRetryBaseDelay = 10 * time.Second // пауза после первой неудачи
+	cancellationRetryMaxDelay  = time.Hour        // больше не ждем
+)
+
+// CompleteCancellations - внешние действия отмененных заказов
+func (oms *usecase) CompleteCancellations(ctx context.Context, limit uint64) (int, error) {
+	const api = "orders_management_system.usecase.CompleteCancellations"
+
+	var completed int
+	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		now := time.Now().UTC()
+
+		cancellations, err := oms.CancellationsStorage.ClaimOrderCancellations(txCtx, now, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, cancellation := range cancellations {
+			if err := oms.completeCancellation(txCtx, cancellation); err != nil {
+				// не получилось - попробуем позже, не задерживая остальные отмены
+				logger.ErrorKV(ctx, "failed to complete order cancellation",
+					"order_id", cancellation.OrderID.String(), "attempt", cancellation.Attempts+1, "error", err.Error())
+
+				nextAttemptAt := now.Add(cancellationRetryDelay(cancellation.Attempts))
+				if err := oms.CancellationsStorage.PostponeOrderCancellation(txCtx, cancellation.OrderID, nextAttemptAt, err.Error()); err != nil {
+					return err
+				}
+				continue
+			}
+
+			if err := oms.CancellationsStorage.DeleteOrderCancellation(txCtx, cancellation.OrderID); err != nil {
+				return err
+			}
+			completed++
+		}
+
+		return nil
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return 0, pkgerrors.Wrap(api, err)
+	}
+
+	return completed, nil
+}
+
+// completeCancellation - снятие резерва, отмена авторизаций и возвраты. Все вызовы идемпотентны:
+// после частичной неудачи отмена целиком повторяется
+func (oms *usecase) completeCancellation(ctx context.Context, cancellation models.OrderCancellation) error {
+	if !cancellation.ReservationID.IsZero() {
+		if err := oms.releaseWarehouses(ctx, cancellation.ReservationID, cancellation.Warehouses); err != nil {
+			return err
+		}
+	}
+
+	for _, providerPaymentID := range cancellation.Voids {
+		if err := oms.Payments.Void(ctx, providerPaymentID); err != nil {
+			return err
+		}
+	}
+
+	for _, refund := range cancellation.Refunds {
+		if err := oms.Payments.Refund(ctx, refund.ProviderPaymentID, refund.Amount, cancellation.RefundID()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// cancellationRetryDelay - пауза перед следующей попыткой: удваивается с каждой неудачей
+func cancellationRetryDelay(attempts uint32) time.Duration {
+	return min(cancellationRetryBaseDelay<<min(attempts, 16), cancellationRetryMaxDelay)
+}
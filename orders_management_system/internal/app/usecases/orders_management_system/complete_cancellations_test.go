@@ -0,0 +1,133 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_CompleteCancellations(t *testing.T) {
+	var (
+		ctx    = context.Background() // dummy
+		amount = models.NewMoney(1000, models.CurrencyRUB)
+		paid   = models.OrderCancellation{
+			OrderID:       models.OrderID(uuid.New()),
+			ReservationID: models.ReservationID(uuid.New()),
+			Warehouses:    []models.WarehouseID{4},
+			Refunds:       []models.PaymentRefund{{ProviderPaymentID: "p-1", Amount: amount}},
+		}
+		awaiting = models.OrderCancellation{
+			OrderID:       models.OrderID(uuid.New()),
+			ReservationID: models.ReservationID(uuid.New()),
+			Warehouses:    []models.WarehouseID{4, 5},
+			Voids:         []string{"p-2"},
+			Attempts:      2,
+		}
+	)
+	type fields struct {
+		TransactionManager        *mocks.TransactionManager
+		WarehouseManagementSystem *mocks.WarehouseManagementSystem
+		Payments                  *mocks.Payments
+		CancellationsStorage      *mocks.CancellationsStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+
+		on func(*fields)
+	}{
+		{
+			name: "Test 1. Positive. Stocks are released, payments are voided and refunded.",
+			want: 2,
+			on: func(f *fields) {
+				f.CancellationsStorage.On("ClaimOrderCancellations", ctx, mock.Anything, uint64(10)).
+					Return([]models.OrderCancellation{paid, awaiting}, nil)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, paid.ReservationID.ForWarehouse(4)).Return(nil)
+				f.Payments.On("Refund", ctx, "p-1", amount, "cancel-"+paid.OrderID.String()).Return(nil)
+				f.CancellationsStorage.On("DeleteOrderCancellation", ctx, paid.OrderID).Return(nil)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, awaiting.ReservationID.ForWarehouse(4)).Return(nil)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, awaiting.ReservationID.ForWarehouse(5)).Return(nil)
+				f.Payments.On("Void", ctx, "p-2").Return(nil)
+				f.CancellationsStorage.On("DeleteOrderCancellation", ctx, awaiting.OrderID).Return(nil)
+			},
+		},
+		{
+			name: "Test 2. Negative. Failed cancellation is postponed, others are completed.",
+			want: 1,
+			on: func(f *fields) {
+				claimedAt := time.Now().UTC()
+				f.CancellationsStorage.On("ClaimOrderCancellations", ctx, mock.Anything, uint64(10)).
+					Return([]models.OrderCancellation{awaiting, paid}, nil)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, awaiting.ReservationID.ForWarehouse(4)).Return(nil)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, awaiting.ReservationID.ForWarehouse(5)).Return(models.ErrServiceUnavailable)
+				f.CancellationsStorage.On("PostponeOrderCancellation", ctx, awaiting.OrderID, mock.MatchedBy(func(next time.Time) bool {
+					// третья неудача: 10s * 2^2
+					return !next.Before(claimedAt.Add(40*time.Second)) && next.Before(claimedAt.Add(time.Minute))
+				}), mock.Anything).Return(nil)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, paid.ReservationID.ForWarehouse(4)).Return(nil)
+				f.Payments.On("Refund", ctx, "p-1", amount, "cancel-"+paid.OrderID.String()).Return(nil)
+				f.CancellationsStorage.On("DeleteOrderCancellation", ctx, paid.OrderID).Return(nil)
+			},
+		},
+		{
+			name:    "Test 3. Negative. Claim failed.",
+			wantErr: true,
+			on: func(f *fields) {
+				f.CancellationsStorage.On("ClaimOrderCancellations", ctx, mock.Anything, uint64(10)).
+					Return(nil, errors.New("db is down"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager:        mocks.NewTransactionManager(t),
+				WarehouseManagementSystem: mocks.NewWarehouseManagementSystem(t),
+				Payments:                  mocks.NewPayments(t),
+				CancellationsStorage:      mocks.NewCancellationsStorage(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction)
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager:        f.TransactionManager,
+					WarehouseManagementSystem: f.WarehouseManagementSystem,
+					Payments:                  f.Payments,
+					CancellationsStorage:      f.CancellationsStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			got, err := oms.CompleteCancellations(ctx, 10)
+
+			// assert
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -2,35 +2,81 @@ package orders_management_system
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
 	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
 	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/workflow"
 )
 
 // CreateOrder - создание заказа
 func (oms *usecase) CreateOrder(ctx context.Context, userID models.UserID, info CreateOrderInfo) (*models.Order, error) {
 	const api = "orders_management_system.usecase.CreateOrder"
 
-	// TODO: ключ идемпотентности
+	// Повтор запроса с тем же ключом идемпотентности - отдаем ранее созданный заказ
+	var fingerprint string
+	if info.IdempotencyKey != "" {
+		fingerprint = createOrderFingerprint(userID, info)
 
-	// comunda, temporal
-	//
-	// workflow := NewWorkflow()
-	// workflow.Add(func())
-	// workflow.Add(func())
-	// err := workflow.Do()
+		order, err := oms.replayCreateOrder(ctx, userID, info.IdempotencyKey, fingerprint)
+		if err != nil {
+			return nil, pkgerrors.Wrap(api, err)
+		}
+		if order != nil {
+			return order, nil
+		}
+	}
+
+	order, err := oms.placeOrder(ctx, userID, info, fingerprint, nil)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return order, nil
+}
+
+// placeOrder - резерв стоков и создание заказа (сага).
+// Если basket не nil - заказ формируется из корзины: в транзакции проверяем, что корзина не изменилась
+func (oms *usecase) placeOrder(ctx context.Context, userID models.UserID, info CreateOrderInfo, fingerprint string, basket *models.Basket) (*models.Order, error) {
+	// Выбираем склады для позиций, где клиент их не указал
+	items, err := oms.allocateWarehouses(ctx, info.Items, info.DeliveryOrderInfo.DeliveryVariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Фиксируем цены на момент заказа
+	items, totals, err := oms.priceOrder(ctx, items, info.DeliveryOrderInfo.DeliveryVariantID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Непримененный промокод отсекаем до резерва стоков. Погашается промокод в транзакции создания заказа
+	if info.PromoCode != "" {
+		totals, err = oms.promoTotals(ctx, oms.PromoStorage.GetPromoCode, userID, info.PromoCode, items, totals.Delivery)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	// Формируем запись о заказе
 	var (
+		now     = time.Now().UTC()
 		orderID = models.OrderID(uuid.New())
 		order   = &models.Order{
 			ID:                orderID,
 			UserID:            userID,
-			Items:             info.Items,
+			Items:             items,
 			DeliveryOrderInfo: info.DeliveryOrderInfo,
+			Totals:            totals,
+			PromoCode:         info.PromoCode,
+			Status:            models.OrderStatusNew,
+			ReservationID:     models.ReservationID(uuid.New()),
+			StatusChangedAt:   now,
+			CreatedAt:         now,
 		}
 	)
 
@@ -74,57 +120,122 @@ func (oms *usecase) CreateOrder(ctx context.Context, userID models.UserID, info
 		tx.Commit()
 		//
 	*/
-	// Резервируем стоки на складах
-	if err := oms.WarehouseManagementSystem.ReserveStocks(ctx, userID, info.Items); err != nil {
-		return nil, pkgerrors.Wrap(api, err)
+	// Транзакция в БД - второй шаг саги, после резервирования стоков на складах
+	createOrder := func(ctx context.Context, commit workflow.Func) error {
+		return oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+			// Корзина могла измениться, пока резервировали стоки - блокируем и сверяем со снимком
+			if basket != nil {
+				locked, err := oms.CheckoutStorage.LockItems(txCtx, userID)
+				if err != nil {
+					return err
+				}
+				if !sameBasketItems(basket.Items, locked) {
+					return models.ErrBasketChanged
+				}
+			}
+
+			// Лимиты промокода перепроверяем под блокировкой: параллельные заказы могли его исчерпать
+			if info.PromoCode != "" {
+				totals, err := oms.promoTotals(txCtx, oms.PromoStorage.LockPromoCode, userID, info.PromoCode, order.Items, order.Totals.Delivery)
+				if err != nil {
+					return err
+				}
+				order.Totals = totals
+			}
+
+			// Создаем заказ в БД
+			if err := oms.OrdersStorage.CreateOrder(txCtx, order); err != nil {
+				return err
+			}
+
+			// Погашаем промокод в той же транзакции, что и создание заказа
+			if info.PromoCode != "" {
+				err := oms.PromoStorage.CreateRedemption(txCtx, models.PromoRedemption{
+					Code:       info.PromoCode,
+					UserID:     userID,
+					OrderID:    orderID,
+					Discount:   order.Totals.Discount,
+					RedeemedAt: time.Now().UTC(),
+				})
+				if err != nil {
+					return err
+				}
+			}
+
+			// Запоминаем ключ идемпотентности вместе с результатом в той же транзакции
+			if info.IdempotencyKey != "" {
+				err := oms.OrdersStorage.CreateIdempotencyKey(txCtx, models.IdempotencyKey{
+					UserID:      userID,
+					Key:         info.IdempotencyKey,
+					RequestHash: fingerprint,
+					OrderID:     orderID,
+				})
+				if errors.Is(err, models.ErrAlreadyExists) {
+					return errIdempotencyKeyTaken
+				}
+				if err != nil {
+					return err
+				}
+			}
+
+			// Стоки уже зарезервированы - переводим заказ в статус reserved
+			transition, err := order.SetStatus(models.OrderStatusReserved, time.Now().UTC())
+			if err != nil {
+				return err
+			}
+			if err := oms.OrdersStorage.UpdateOrderStatus(txCtx, *transition); err != nil {
+				return err
+			}
+
+			// Публикуем событие в outbox табличке, которое будет обработоно асинхронно позже
+			if err := oms.appendOrderEvent(txCtx, models.NewOrderCreatedEvent(order)); err != nil {
+				return err
+			}
+
+			// Удаляем товары из корзины
+			if err := oms.CheckoutStorage.DeleteItems(txCtx, userID, info.Items); err != nil {
+				return err
+			}
+
+			// Сага завершается в этой же транзакции: созданный заказ компенсация уже не тронет
+			return commit(txCtx)
+		},
+			postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+			postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+			postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+		)
 	}
 
-	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
-		// Создаем заказ в БД
-		if err := oms.OrdersStorage.CreateOrder(txCtx, order); err != nil {
-			return err
-		}
+	// Сага: временный резерв стоков -> транзакция. Если транзакция упала - резерв снимается компенсацией.
+	// Неоплаченный вовремя заказ отменяет ExpireReservations.
+	// Прогресс саги сохраняется, поэтому после падения процесса до создания заказа резерв снимет RecoverSagas
+	saga, err := oms.newCreateOrderSaga(createOrderSagaPayload{
+		OrderID:       uuid.UUID(orderID),
+		ReservationID: uuid.UUID(order.ReservationID),
+		UserID:        userID,
+		Items:         items,
+	}, createOrder)
+	if err != nil {
+		return nil, err
+	}
 
-		// Публикуем сообщение в outbox табличке, которое будет обработона асинхронно позже
-		if err := oms.OrdersStorage.CreateOutboxMessage(txCtx, order); err != nil {
-			return err
+	err = saga.Do(ctx)
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		// Параллельный запрос с тем же ключом успел создать заказ: наш резерв уже снят компенсацией
+		replayed, err := oms.replayCreateOrder(ctx, userID, info.IdempotencyKey, fingerprint)
+		if err != nil {
+			return nil, err
 		}
-
-		// Удаляем товары из корзины
-		if err := oms.CheckoutStorage.DeleteItems(txCtx, userID, info.Items); err != nil {
-			return err
+		if replayed == nil {
+			return nil, errIdempotencyKeyTaken
 		}
-
-		return nil
-	},
-		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
-		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
-		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
-	)
+		return replayed, nil
+	}
 	if err != nil {
-		return nil, pkgerrors.Wrap(api, err)
+		return nil, err
 	}
 
-	// // outbox process
-	// go func() {
-	// 	for {
-	// 		_ = oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOP
-	// 			order, err := oms.OrdersStorage.GetOutboxMessage(txCtx)
-	// 			if err != nil {
-	// 				return err
-	// 			}
-
-	// 			if err := kafka.Produce(order); err != nil {
-	// 				return err
-	// 			}
-
-	// 			if err := oms.OrdersStorage.DeleteOutboxMessage(txCtx, order); err != nil {
-	// 				return err
-	// 			}
-	// 			return nil
-	// 		})
-	// 	}
-	// }()
+	// Сообщения из outbox публикует фоновый процесс workers/outbox_relay
 
 	return order, nil
 }
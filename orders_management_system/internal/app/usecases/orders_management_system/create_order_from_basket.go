@@ -0,0 +1,50 @@
+package orders_management_system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// CreateOrderFromBasket - создание заказа из корзины
+func (oms *usecase) CreateOrderFromBasket(ctx context.Context, userID models.UserID, info CreateOrderFromBasketInfo) (*models.Order, error) {
+	const api = "orders_management_system.usecase.CreateOrderFromBasket"
+
+	// Повтор запроса с тем же ключом идемпотентности - отдаем ранее созданный заказ
+	// (корзина к этому моменту уже очищена)
+	var fingerprint string
+	if info.IdempotencyKey != "" {
+		fingerprint = createOrderFromBasketFingerprint(userID, info)
+
+		order, err := oms.replayCreateOrder(ctx, userID, info.IdempotencyKey, fingerprint)
+		if err != nil {
+			return nil, pkgerrors.Wrap(api, err)
+		}
+		if order != nil {
+			return order, nil
+		}
+	}
+
+	// Снимок корзины: по нему резервируем стоки, в транзакции создания заказа сверяемся с ним
+	basket, err := oms.GetBasket(ctx, userID)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+	if len(basket.Items) == 0 {
+		return nil, pkgerrors.Wrap(api, fmt.Errorf("basket is empty: %w", models.ErrInvalidArgument))
+	}
+
+	order, err := oms.placeOrder(ctx, userID, CreateOrderInfo{
+		Items:             basket.OrderItems(),
+		DeliveryOrderInfo: info.DeliveryOrderInfo,
+		IdempotencyKey:    info.IdempotencyKey,
+		PromoCode:         info.PromoCode,
+	}, fingerprint, basket)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return order, nil
+}
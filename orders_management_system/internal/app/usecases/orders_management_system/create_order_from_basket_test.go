@@ -0,0 +1,198 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_CreateOrderFromBasket(t *testing.T) {
+	var (
+		ctx  = context.Background() // dummy
+		date = time.Now()
+		item = models.Item{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}
+
+		price  = models.NewMoney(10000, models.CurrencyRUB)
+		priced = models.Item{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4, Price: price}
+	)
+	type fields struct {
+		TransactionManager        *mocks.TransactionManager
+		WarehouseManagementSystem *mocks.WarehouseManagementSystem
+		Pricing                   *mocks.Pricing
+		OrdersStorage             *mocks.OrdersStorage
+		CheckoutStorage           *mocks.CheckoutStorage
+		SagaStorage               *mocks.SagaStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	type args struct {
+		ctx    context.Context
+		userID models.UserID
+		info   CreateOrderFromBasketInfo
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *models.Order
+		wantErr error
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name: "Test 1. Positive.",
+			args: args{
+				ctx:    ctx,
+				userID: 1,
+				info: CreateOrderFromBasketInfo{
+					DeliveryOrderInfo: models.DeliveryOrderInfo{DeliveryVariantID: 5, DeliveryDate: date},
+				},
+			},
+			want: &models.Order{
+				UserID:            1,
+				Items:             []models.Item{priced},
+				DeliveryOrderInfo: models.DeliveryOrderInfo{DeliveryVariantID: 5, DeliveryDate: date},
+				Totals: models.OrderTotals{
+					Subtotal: models.NewMoney(30000, models.CurrencyRUB),
+					Total:    models.NewMoney(30000, models.CurrencyRUB),
+				},
+				Status: models.OrderStatusReserved,
+			},
+
+			on: func(f *fields) {
+				f.CheckoutStorage.On("GetItems", ctx, models.UserID(1)).
+					Return([]models.BasketItem{{Item: item, UpdatedAt: date}}, nil)
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, mock.Anything, models.UserID(1), []models.Item{priced}).
+					Return(nil)
+				f.CheckoutStorage.On("LockItems", ctx, models.UserID(1)).
+					Return([]models.BasketItem{{Item: item, UpdatedAt: date}}, nil)
+				f.OrdersStorage.On("CreateOrder", ctx, mock.Anything).
+					Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).
+					Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).
+					Return(nil)
+				f.CheckoutStorage.On("DeleteItems", ctx, models.UserID(1), []models.Item{item}).
+					Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReleaseReservation", 0)
+				f.CheckoutStorage.AssertNumberOfCalls(t, "DeleteItems", 1)
+			},
+		},
+		{
+			name: "Test 2. Negative. Basket is empty.",
+			args: args{
+				ctx:    ctx,
+				userID: 1,
+			},
+			wantErr: models.ErrInvalidArgument,
+
+			on: func(f *fields) {
+				f.CheckoutStorage.On("GetItems", ctx, models.UserID(1)).
+					Return([]models.BasketItem{}, nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReserveStocks", 0)
+			},
+		},
+		{
+			name: "Test 3. Negative. Basket changed while reserving stocks.",
+			args: args{
+				ctx:    ctx,
+				userID: 1,
+			},
+			wantErr: models.ErrBasketChanged,
+
+			on: func(f *fields) {
+				f.CheckoutStorage.On("GetItems", ctx, models.UserID(1)).
+					Return([]models.BasketItem{{Item: item}}, nil)
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, mock.Anything, models.UserID(1), []models.Item{priced}).
+					Return(nil)
+				changed := item
+				changed.Quantity = 10
+				f.CheckoutStorage.On("LockItems", ctx, models.UserID(1)).
+					Return([]models.BasketItem{{Item: changed}}, nil)
+				// компенсация саги: снимаем резерв
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, mock.Anything).
+					Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.OrdersStorage.AssertNumberOfCalls(t, "CreateOrder", 0)
+				f.CheckoutStorage.AssertNumberOfCalls(t, "DeleteItems", 0)
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReleaseReservation", 1)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager:        mocks.NewTransactionManager(t),
+				WarehouseManagementSystem: mocks.NewWarehouseManagementSystem(t),
+				Pricing:                   mocks.NewPricing(t),
+				OrdersStorage:             mocks.NewOrdersStorage(t),
+				CheckoutStorage:           mocks.NewCheckoutStorage(t),
+				SagaStorage:               mocks.NewSagaStorage(t),
+			}
+			f.SagaStorage.On("SaveWorkflowState", ctx, mock.Anything).
+				Return(nil).
+				Maybe()
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction).
+				Maybe()
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager:        f.TransactionManager,
+					WarehouseManagementSystem: f.WarehouseManagementSystem,
+					Pricing:                   f.Pricing,
+					OrdersStorage:             f.OrdersStorage,
+					CheckoutStorage:           f.CheckoutStorage,
+					SagaStorage:               f.SagaStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+			f.Pricing.On("GetPrices", ctx, []models.SKUID{2}).
+				Return(map[models.SKUID]models.Money{2: price}, nil).
+				Maybe()
+			f.Pricing.On("GetDeliveryPrice", ctx, mock.Anything).
+				Return(models.Money{}, nil).
+				Maybe()
+
+			// act
+			got, err := oms.CreateOrderFromBasket(tt.args.ctx, tt.args.userID, tt.args.info)
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("usecase.CreateOrderFromBasket() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// assert
+			if got != nil { // зануляем так как не можем проверить
+				got.ID = models.OrderID{}
+				got.ReservationID = models.ReservationID{}
+				got.StatusChangedAt = time.Time{}
+				got.CreatedAt = time.Time{}
+			}
+			assert.Equal(t, tt.want, got)
+
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
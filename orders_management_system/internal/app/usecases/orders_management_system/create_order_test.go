@@ -9,20 +9,44 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/allocation"
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/workflow"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
 func Test_usecase_CreateOrder(t *testing.T) {
 	var (
-		ctx  = context.Background() // dummy
-		date = time.Now()
+		ctx     = context.Background() // dummy
+		date    = time.Now()
+		orderID = models.OrderID(uuid.New())
+
+		price         = models.NewMoney(10000, models.CurrencyRUB)
+		deliveryPrice = models.NewMoney(300, models.CurrencyRUB)
+		totals        = models.OrderTotals{
+			Subtotal: models.NewMoney(30000, models.CurrencyRUB),
+			Delivery: deliveryPrice,
+			Total:    models.NewMoney(30300, models.CurrencyRUB),
+		}
 	)
 	type fields struct {
+		TransactionManager        *mocks.TransactionManager
 		WarehouseManagementSystem *mocks.WarehouseManagementSystem
+		Inventory                 *mocks.Inventory
+		Pricing                   *mocks.Pricing
+		PromoStorage              *mocks.PromoStorage
 		OrdersStorage             *mocks.OrdersStorage
+		CheckoutStorage           *mocks.CheckoutStorage
+		SagaStorage               *mocks.SagaStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
 	}
 
 	type args struct {
@@ -65,21 +89,25 @@ func Test_usecase_CreateOrder(t *testing.T) {
 						SKU:         models.SKU{ID: 2, Name: "Item 2"},
 						Quantity:    3,
 						WarehouseID: 4,
+						Price:       price,
 					},
 				},
 				DeliveryOrderInfo: models.DeliveryOrderInfo{
 					DeliveryVariantID: 5,
 					DeliveryDate:      date,
 				},
+				Totals: totals,
+				Status: models.OrderStatusReserved,
 			},
 			wantErr: false,
 
 			on: func(f *fields) {
-				f.WarehouseManagementSystem.On("ReserveStocks", ctx, models.UserID(1), []models.Item{
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, mock.Anything, models.UserID(1), []models.Item{
 					{
 						SKU:         models.SKU{ID: 2, Name: "Item 2"},
 						Quantity:    3,
 						WarehouseID: 4,
+						Price:       price,
 					},
 				}).
 					Return(nil)
@@ -91,6 +119,7 @@ func Test_usecase_CreateOrder(t *testing.T) {
 								SKU:         models.SKU{ID: 2, Name: "Item 2"},
 								Quantity:    3,
 								WarehouseID: 4,
+								Price:       price,
 							},
 						}) &&
 						reflect.DeepEqual(
@@ -99,13 +128,31 @@ func Test_usecase_CreateOrder(t *testing.T) {
 								DeliveryDate:      date,
 							},
 						) &&
-						order.ID != models.OrderID{} // not empty
+						order.ID != models.OrderID{} && // not empty
+						order.Status == models.OrderStatusNew &&
+						!order.CreatedAt.IsZero() && order.CreatedAt.Equal(order.StatusChangedAt)
+				})).
+					Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.MatchedBy(func(transition models.OrderStatusTransition) bool {
+					return transition.From == models.OrderStatusNew &&
+						transition.To == models.OrderStatusReserved
 				})).
 					Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventCreated &&
+						event.Version == models.OrderEventVersion &&
+						event.Order.Status == models.OrderStatusReserved
+				})).
+					Return(nil)
+				f.CheckoutStorage.On("DeleteItems", ctx, models.UserID(1), mock.Anything).
+					Return(nil)
 			},
 			assert: func(t *testing.T, f *fields) {
 				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReserveStocks", 1)
 				f.OrdersStorage.AssertNumberOfCalls(t, "CreateOrder", 1)
+				f.OrdersStorage.AssertNumberOfCalls(t, "UpdateOrderStatus", 1)
+				f.OrdersStorage.AssertNumberOfCalls(t, "AppendOrderEvent", 1)
+				f.CheckoutStorage.AssertNumberOfCalls(t, "DeleteItems", 1)
 			},
 		},
 		{
@@ -131,17 +178,19 @@ func Test_usecase_CreateOrder(t *testing.T) {
 			wantErr: true,
 
 			on: func(f *fields) {
-				f.WarehouseManagementSystem.On("ReserveStocks", ctx, models.UserID(1), []models.Item{
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, mock.Anything, models.UserID(1), []models.Item{
 					{
 						SKU:         models.SKU{ID: 2, Name: "Item 2"},
 						Quantity:    3,
 						WarehouseID: 4,
+						Price:       price,
 					},
 				}).
 					Return(errors.New("some error"))
 			},
 			assert: func(t *testing.T, f *fields) {
 				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReserveStocks", 1)
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReleaseReservation", 0)
 				f.OrdersStorage.AssertNumberOfCalls(t, "CreateOrder", 0)
 			},
 		},
@@ -168,11 +217,12 @@ func Test_usecase_CreateOrder(t *testing.T) {
 			wantErr: true,
 
 			on: func(f *fields) {
-				f.WarehouseManagementSystem.On("ReserveStocks", ctx, models.UserID(1), []models.Item{
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, mock.Anything, models.UserID(1), []models.Item{
 					{
 						SKU:         models.SKU{ID: 2, Name: "Item 2"},
 						Quantity:    3,
 						WarehouseID: 4,
+						Price:       price,
 					},
 				}).
 					Return(nil)
@@ -184,6 +234,7 @@ func Test_usecase_CreateOrder(t *testing.T) {
 								SKU:         models.SKU{ID: 2, Name: "Item 2"},
 								Quantity:    3,
 								WarehouseID: 4,
+								Price:       price,
 							},
 						}) &&
 						reflect.DeepEqual(
@@ -195,10 +246,251 @@ func Test_usecase_CreateOrder(t *testing.T) {
 						order.ID != models.OrderID{} // not empty
 				})).
 					Return(models.ErrAlreadyExists)
+				// компенсация саги: снимаем резерв
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, mock.Anything).
+					Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReserveStocks", 1)
+				f.OrdersStorage.AssertNumberOfCalls(t, "CreateOrder", 1)
+				f.OrdersStorage.AssertNumberOfCalls(t, "UpdateOrderStatus", 0)
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReleaseReservation", 1)
+				f.SagaStorage.AssertCalled(t, "SaveWorkflowState", ctx, mock.MatchedBy(func(state workflow.State) bool {
+					return state.Name == createOrderSagaName && state.Status == workflow.StatusCompensated
+				}))
+			},
+		},
+		{
+			name: "Test 4. Positive. Replay with the same idempotency key.",
+			args: args{
+				ctx:    ctx, // dumm
+				userID: 1,
+				info: CreateOrderInfo{
+					Items:          []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}},
+					IdempotencyKey: "key",
+				},
+			},
+			want: &models.Order{
+				UserID: 1,
+				Items:  []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}},
+				Status: models.OrderStatusReserved,
+			},
+			wantErr: false,
+
+			on: func(f *fields) {
+				fingerprint := createOrderFingerprint(1, CreateOrderInfo{
+					Items: []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}},
+				})
+				f.OrdersStorage.On("GetIdempotencyKey", ctx, models.UserID(1), "key").
+					Return(&models.IdempotencyKey{UserID: 1, Key: "key", RequestHash: fingerprint, OrderID: orderID}, nil)
+				f.OrdersStorage.On("GetOrder", ctx, orderID).
+					Return(&models.Order{
+						ID:     orderID,
+						UserID: 1,
+						Items:  []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}},
+						Status: models.OrderStatusReserved,
+					}, nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReserveStocks", 0)
+				f.OrdersStorage.AssertNumberOfCalls(t, "CreateOrder", 0)
+			},
+		},
+		{
+			name: "Test 5. Negative. Idempotency key reused with another request.",
+			args: args{
+				ctx:    ctx, // dumm
+				userID: 1,
+				info: CreateOrderInfo{
+					Items:          []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 5, WarehouseID: 4}},
+					IdempotencyKey: "key",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetIdempotencyKey", ctx, models.UserID(1), "key").
+					Return(&models.IdempotencyKey{UserID: 1, Key: "key", RequestHash: "other", OrderID: orderID}, nil)
 			},
 			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReserveStocks", 0)
+				f.OrdersStorage.AssertNumberOfCalls(t, "GetOrder", 0)
+			},
+		},
+		{
+			name: "Test 6. Positive. Warehouse is allocated when omitted.",
+			args: args{
+				ctx:    ctx, // dumm
+				userID: 1,
+				info: CreateOrderInfo{
+					Items: []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3}},
+					DeliveryOrderInfo: models.DeliveryOrderInfo{
+						DeliveryVariantID: 5,
+					},
+				},
+			},
+			want: &models.Order{
+				UserID: 1,
+				Items:  []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 7, Price: price}},
+				DeliveryOrderInfo: models.DeliveryOrderInfo{
+					DeliveryVariantID: 5,
+				},
+				Totals: totals,
+				Status: models.OrderStatusReserved,
+			},
+			wantErr: false,
+
+			on: func(f *fields) {
+				f.Inventory.On("GetStocks", ctx, []models.SKUID{2}, models.DeliveryVariantID(5)).
+					Return([]models.WarehouseStocks{
+						{WarehouseID: 4, Stocks: map[models.SKUID]uint32{2: 1}},
+						{WarehouseID: 7, Stocks: map[models.SKUID]uint32{2: 10}},
+					}, nil)
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, mock.Anything, models.UserID(1),
+					[]models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 7, Price: price}}).
+					Return(nil)
+				f.OrdersStorage.On("CreateOrder", ctx, mock.Anything).
+					Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).
+					Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).
+					Return(nil)
+				f.CheckoutStorage.On("DeleteItems", ctx, models.UserID(1), mock.Anything).
+					Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.Inventory.AssertNumberOfCalls(t, "GetStocks", 1)
 				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReserveStocks", 1)
-				f.OrdersStorage.AssertNumberOfCalls(t, "CreateOrder", 3)
+			},
+		},
+		{
+			name: "Test 7. Negative. Not enough stocks to allocate.",
+			args: args{
+				ctx:    ctx, // dumm
+				userID: 1,
+				info: CreateOrderInfo{
+					Items: []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3}},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+
+			on: func(f *fields) {
+				f.Inventory.On("GetStocks", ctx, []models.SKUID{2}, models.DeliveryVariantID(0)).
+					Return([]models.WarehouseStocks{
+						{WarehouseID: 4, Stocks: map[models.SKUID]uint32{2: 1}},
+					}, nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReserveStocks", 0)
+				f.OrdersStorage.AssertNumberOfCalls(t, "CreateOrder", 0)
+			},
+		},
+		{
+			name: "Test 8. Negative. No price for SKU.",
+			args: args{
+				ctx:    ctx, // dumm
+				userID: 1,
+				info: CreateOrderInfo{
+					Items: []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}},
+				},
+			},
+			want:    nil,
+			wantErr: true,
+
+			on: func(f *fields) {
+				f.Pricing.On("GetPrices", ctx, []models.SKUID{2}).
+					Return(map[models.SKUID]models.Money{}, nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReserveStocks", 0)
+				f.OrdersStorage.AssertNumberOfCalls(t, "CreateOrder", 0)
+			},
+		},
+		{
+			name: "Test 9. Positive. Promo code is redeemed in order transaction.",
+			args: args{
+				ctx:    ctx, // dumm
+				userID: 1,
+				info: CreateOrderInfo{
+					Items:     []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}},
+					PromoCode: "SALE10",
+				},
+			},
+			want: &models.Order{
+				UserID: 1,
+				Items:  []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4, Price: price}},
+				Totals: models.OrderTotals{
+					Subtotal: models.NewMoney(30000, models.CurrencyRUB),
+					Discount: models.NewMoney(3000, models.CurrencyRUB),
+					Delivery: deliveryPrice,
+					Total:    models.NewMoney(27300, models.CurrencyRUB),
+				},
+				PromoCode: "SALE10",
+				Status:    models.OrderStatusReserved,
+			},
+			wantErr: false,
+
+			on: func(f *fields) {
+				promo := &models.PromoCode{
+					Code:         "SALE10",
+					Rule:         models.DiscountRule{Type: models.DiscountPercentOff, PercentOff: 10},
+					PerUserLimit: 1,
+					Active:       true,
+				}
+				f.PromoStorage.On("GetPromoCode", ctx, "SALE10").
+					Return(promo, nil)
+				f.PromoStorage.On("LockPromoCode", ctx, "SALE10").
+					Return(promo, nil)
+				f.PromoStorage.On("CountUserRedemptions", ctx, "SALE10", models.UserID(1)).
+					Return(uint32(0), nil)
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, mock.Anything, models.UserID(1), mock.Anything).
+					Return(nil)
+				f.OrdersStorage.On("CreateOrder", ctx, mock.MatchedBy(func(order *models.Order) bool {
+					return order.PromoCode == "SALE10" && order.Totals.Discount.Amount == 3000
+				})).
+					Return(nil)
+				f.PromoStorage.On("CreateRedemption", ctx, mock.MatchedBy(func(redemption models.PromoRedemption) bool {
+					return redemption.Code == "SALE10" &&
+						redemption.UserID == 1 &&
+						redemption.Discount == models.NewMoney(3000, models.CurrencyRUB)
+				})).
+					Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).
+					Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).
+					Return(nil)
+				f.CheckoutStorage.On("DeleteItems", ctx, models.UserID(1), mock.Anything).
+					Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.PromoStorage.AssertNumberOfCalls(t, "LockPromoCode", 1)
+				f.PromoStorage.AssertNumberOfCalls(t, "CreateRedemption", 1)
+			},
+		},
+		{
+			name: "Test 10. Negative. Promo code limit reached before reservation.",
+			args: args{
+				ctx:    ctx, // dumm
+				userID: 1,
+				info: CreateOrderInfo{
+					Items:     []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}},
+					PromoCode: "SALE10",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+
+			on: func(f *fields) {
+				f.PromoStorage.On("GetPromoCode", ctx, "SALE10").
+					Return(&models.PromoCode{Code: "SALE10", PerUserLimit: 1, Active: true}, nil)
+				f.PromoStorage.On("CountUserRedemptions", ctx, "SALE10", models.UserID(1)).
+					Return(uint32(1), nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReserveStocks", 0)
+				f.PromoStorage.AssertNumberOfCalls(t, "CreateRedemption", 0)
 			},
 		},
 	}
@@ -206,18 +498,44 @@ func Test_usecase_CreateOrder(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// arrange
 			f := &fields{
+				TransactionManager:        mocks.NewTransactionManager(t),
 				WarehouseManagementSystem: mocks.NewWarehouseManagementSystem(t),
+				Inventory:                 mocks.NewInventory(t),
+				Pricing:                   mocks.NewPricing(t),
+				PromoStorage:              mocks.NewPromoStorage(t),
 				OrdersStorage:             mocks.NewOrdersStorage(t),
+				CheckoutStorage:           mocks.NewCheckoutStorage(t),
+				SagaStorage:               mocks.NewSagaStorage(t),
 			}
+			f.SagaStorage.On("SaveWorkflowState", ctx, mock.Anything).
+				Return(nil).
+				Maybe()
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction).
+				Maybe()
 			oms := &usecase{
 				Deps: Deps{
+					TransactionManager:        f.TransactionManager,
 					WarehouseManagementSystem: f.WarehouseManagementSystem,
+					Inventory:                 f.Inventory,
+					AllocationStrategy:        allocation.FewestSplits{},
+					Pricing:                   f.Pricing,
+					PromoStorage:              f.PromoStorage,
 					OrdersStorage:             f.OrdersStorage,
+					CheckoutStorage:           f.CheckoutStorage,
+					SagaStorage:               f.SagaStorage,
 				},
 			}
 			if tt.on != nil {
 				tt.on(f)
 			}
+			// цены по умолчанию, если тест не задал свои
+			f.Pricing.On("GetPrices", ctx, mock.Anything).
+				Return(map[models.SKUID]models.Money{2: price}, nil).
+				Maybe()
+			f.Pricing.On("GetDeliveryPrice", ctx, mock.Anything).
+				Return(deliveryPrice, nil).
+				Maybe()
 
 			// act
 			got, err := oms.CreateOrder(tt.args.ctx, tt.args.userID, tt.args.info)
@@ -229,6 +547,9 @@ func Test_usecase_CreateOrder(t *testing.T) {
 			// assert
 			if got != nil { // зануляем так как не можем проверить
 				got.ID = models.OrderID{}
+				got.ReservationID = models.ReservationID{}
+				got.StatusChangedAt = time.Time{}
+				got.CreatedAt = time.Time{}
 			}
 			assert.Equal(t, tt.want, got)
 
@@ -238,3 +559,72 @@ func Test_usecase_CreateOrder(t *testing.T) {
 		})
 	}
 }
+
+func Test_usecase_CreateOrder_SagaCompletedInTransaction(t *testing.T) {
+	type txKey struct{}
+	var (
+		ctx     = context.Background() // dummy
+		errBoom = errors.New("db is down")
+		price   = models.NewMoney(10000, models.CurrencyRUB)
+
+		transactionManager        = mocks.NewTransactionManager(t)
+		warehouseManagementSystem = mocks.NewWarehouseManagementSystem(t)
+		pricing                   = mocks.NewPricing(t)
+		ordersStorage             = mocks.NewOrdersStorage(t)
+		checkoutStorage           = mocks.NewCheckoutStorage(t)
+		sagaStorage               = mocks.NewSagaStorage(t)
+
+		committed bool              // транзакция создания заказа закоммичена
+		saved     []workflow.Status // статусы саги, сохраненные в транзакции
+	)
+
+	// arrange: после коммита сохранить прогресс саги уже нельзя
+	transactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+			if err := f(context.WithValue(ctx, txKey{}, true)); err != nil {
+				return err
+			}
+			committed = true
+			return nil
+		})
+	sagaStorage.On("SaveWorkflowState", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, state workflow.State) error {
+			if committed {
+				return errBoom
+			}
+			if ctx.Value(txKey{}) != nil {
+				saved = append(saved, state.Status)
+			}
+			return nil
+		})
+	pricing.On("GetPrices", ctx, mock.Anything).Return(map[models.SKUID]models.Money{2: price}, nil)
+	pricing.On("GetDeliveryPrice", ctx, mock.Anything).Return(models.NewMoney(300, models.CurrencyRUB), nil)
+	warehouseManagementSystem.On("ReserveStocks", ctx, mock.Anything, models.UserID(1), mock.Anything).Return(nil)
+	ordersStorage.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+	ordersStorage.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(nil)
+	ordersStorage.On("AppendOrderEvent", mock.Anything, mock.Anything).Return(nil)
+	checkoutStorage.On("DeleteItems", mock.Anything, models.UserID(1), mock.Anything).Return(nil)
+
+	oms := &usecase{
+		Deps: Deps{
+			TransactionManager:        transactionManager,
+			WarehouseManagementSystem: warehouseManagementSystem,
+			AllocationStrategy:        allocation.FewestSplits{},
+			Pricing:                   pricing,
+			OrdersStorage:             ordersStorage,
+			CheckoutStorage:           checkoutStorage,
+			SagaStorage:               sagaStorage,
+		},
+	}
+
+	// act
+	got, err := oms.CreateOrder(ctx, 1, CreateOrderInfo{
+		Items: []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}},
+	})
+
+	// assert: сага завершена вместе с заказом, резерв созданного заказа не снимается
+	assert.NoError(t, err)
+	assert.NotNil(t, got)
+	assert.Equal(t, []workflow.Status{workflow.StatusCompleted}, saved)
+	warehouseManagementSystem.AssertNotCalled(t, "ReleaseReservation", mock.Anything, mock.Anything)
+}
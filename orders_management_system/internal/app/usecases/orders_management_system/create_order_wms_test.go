@@ -0,0 +1,133 @@
+//go:build test
+
+package orders_management_system_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/services/pricing"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/services/warehouses_management_system"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	oms "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/fake_wms"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// CreateOrder с настоящим gRPC клиентом WMS поверх fake_wms в памяти процесса
+func Test_usecase_CreateOrder_FakeWMS(t *testing.T) {
+	var (
+		ctx  = context.Background() // dummy
+		seed = fake_wms.Seed{
+			Warehouses: []fake_wms.SeedWarehouse{
+				{ID: 4, Stocks: []fake_wms.SeedStock{{SKUID: 2, Quantity: 5}}},
+			},
+		}
+	)
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	tests := []struct {
+		name    string
+		items   []models.Item
+		wantErr error
+
+		on                          func(*mocks.OrdersStorage, *mocks.CheckoutStorage)
+		wantAvailable, wantReserved uint64
+	}{
+		{
+			name:  "Test 1. Positive. Stock is reserved.",
+			items: []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}},
+
+			on: func(s *mocks.OrdersStorage, c *mocks.CheckoutStorage) {
+				s.On("CreateOrder", mock.Anything, mock.Anything).Return(nil)
+				s.On("UpdateOrderStatus", mock.Anything, mock.Anything).Return(nil)
+				s.On("AppendOrderEvent", mock.Anything, mock.Anything).Return(nil)
+				c.On("DeleteItems", mock.Anything, models.UserID(1), mock.Anything).Return(nil)
+			},
+			wantAvailable: 2,
+			wantReserved:  3,
+		},
+		{
+			name:    "Test 2. Negative. Out of stock.",
+			items:   []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 6, WarehouseID: 4}},
+			wantErr: models.ErrOutOfStock,
+
+			wantAvailable: 5,
+		},
+		{
+			name:    "Test 3. Negative. Unknown warehouse.",
+			items:   []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 1, WarehouseID: 5}},
+			wantErr: models.ErrUnknownWarehouse,
+
+			wantAvailable: 5,
+		},
+		{
+			name:    "Test 4. Negative. Transaction failed, reserve is released by compensation.",
+			items:   []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}},
+			wantErr: models.ErrAlreadyExists,
+
+			on: func(s *mocks.OrdersStorage, _ *mocks.CheckoutStorage) {
+				s.On("CreateOrder", mock.Anything, mock.Anything).Return(models.ErrAlreadyExists)
+			},
+			wantAvailable: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			wms := fake_wms.NewServer(fake_wms.Config{}, fake_wms.NewLedger(seed))
+			conn, stop, err := fake_wms.StartBufconn(wms)
+			require.NoError(t, err)
+			defer stop()
+
+			var (
+				txManager       = mocks.NewTransactionManager(t)
+				ordersStorage   = mocks.NewOrdersStorage(t)
+				checkoutStorage = mocks.NewCheckoutStorage(t)
+				sagaStorage     = mocks.NewSagaStorage(t)
+			)
+			txManager.On("RunTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction).
+				Maybe()
+			sagaStorage.On("SaveWorkflowState", mock.Anything, mock.Anything).
+				Return(nil).
+				Maybe()
+			if tt.on != nil {
+				tt.on(ordersStorage, checkoutStorage)
+			}
+
+			usecase := oms.NewUsecase(oms.Deps{
+				TransactionManager:        txManager,
+				WarehouseManagementSystem: warehouses_management_system.NewClient(conn),
+				Pricing: pricing.NewInMemory(pricing.PriceList{
+					Currency: models.CurrencyRUB,
+					SKUs:     map[uint64]int64{2: 10000},
+					Delivery: map[uint64]int64{0: 0},
+				}),
+				OrdersStorage:   ordersStorage,
+				CheckoutStorage: checkoutStorage,
+				SagaStorage:     sagaStorage,
+			})
+
+			// act
+			_, err = usecase.CreateOrder(ctx, 1, oms.CreateOrderInfo{Items: tt.items})
+
+			// assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("usecase.CreateOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			available, reserved := wms.Ledger().Stock(4, 2)
+			assert.Equal(t, tt.wantAvailable, available)
+			assert.Equal(t, tt.wantReserved, reserved)
+		})
+	}
+}
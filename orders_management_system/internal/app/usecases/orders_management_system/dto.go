@@ -1,9 +1,69 @@
 package orders_management_system
 
-import "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+import (
+	"time"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
 
 // CreateOrderInputInfo - DTO заказа (для создания заказа)
 type CreateOrderInfo struct {
 	Items             []models.Item            // Товары в заказе
 	DeliveryOrderInfo models.DeliveryOrderInfo // Информация о доставке
+	IdempotencyKey    string                   // Ключ идемпотентности, пустой - запрос не идемпотентен
+	PromoCode         string                   // Промокод, пустой - без скидки
+}
+
+// CreateOrderFromBasketInfo - DTO заказа из корзины (товары берутся из корзины)
+type CreateOrderFromBasketInfo struct {
+	DeliveryOrderInfo models.DeliveryOrderInfo // Информация о доставке
+	IdempotencyKey    string                   // Ключ идемпотентности, пустой - запрос не идемпотентен
+	PromoCode         string                   // Промокод, пустой - без скидки
+}
+
+// PreviewOrderInfo - DTO предпросмотра стоимости заказа
+type PreviewOrderInfo struct {
+	Items             []models.Item            // Товары (склад не нужен)
+	DeliveryVariantID models.DeliveryVariantID // Способ доставки
+	PromoCode         string                   // Промокод, пустой - без скидки
+}
+
+// OrderPreview - DTO стоимости заказа до его создания
+type OrderPreview struct {
+	Items  []models.Item      // Товары с текущими ценами
+	Totals models.OrderTotals // Стоимость заказа (со скидкой по промокоду)
+}
+
+// PayOrderResult - DTO оплаты заказа
+type PayOrderResult struct {
+	Order   *models.Order   // Заказ в статусе awaiting_payment
+	Payment *models.Payment // Платеж, результат авторизации придет от провайдера в HandlePaymentCallback
+}
+
+// CancelOrderItemsResult - DTO отмены части товаров заказа
+type CancelOrderItemsResult struct {
+	Order          *models.Order // Заказ с оставшимися товарами и новой стоимостью
+	CancelledItems []models.Item // Отмененные товары (склад и цена из заказа)
+	Refund         models.Money  // Возвращено покупателю (только для оплаченного заказа)
+}
+
+// RequestReturnInfo - DTO заявки на возврат
+type RequestReturnInfo struct {
+	Items  []models.Item // Возвращаемые товары: SKU и количество (склад и цену берем из заказа)
+	Reason string        // Причина возврата
+}
+
+// ListOrdersFilter - DTO фильтра списка заказов
+type ListOrdersFilter struct {
+	Statuses         []models.OrderStatus // Статусы заказов, пустой - любой статус
+	DeliveryDateFrom time.Time            // Срок доставки не раньше, zero - без ограничения
+	DeliveryDateTo   time.Time            // Срок доставки не позже, zero - без ограничения
+	PageSize         uint32               // Размер страницы, 0 - размер по умолчанию
+	PageToken        string               // Токен страницы из предыдущего ответа, пустой - первая страница
+}
+
+// ListOrdersResult - DTO страницы списка заказов
+type ListOrdersResult struct {
+	Orders        []*models.Order // Заказы на странице
+	NextPageToken string          // Токен следующей страницы, пустой - страниц больше нет
 }
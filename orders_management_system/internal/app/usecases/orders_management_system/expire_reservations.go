@@ -0,0 +1,55 @@
+package orders_management_system
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+)
+
+const (
+	// expiredReservationReason - причина отмены заказа, не оплаченного вовремя
+	expiredReservationReason = "reservation expired"
+	// expiryRetryDelay - пауза перед повторной попыткой отменить заказ после неудачи
+	expiryRetryDelay = time.Minute
+)
+
+// ExpireReservations - отмена неоплаченных вовремя заказов со снятием резерва
+func (oms *usecase) ExpireReservations(ctx context.Context, expiredBefore time.Time, limit uint64) (int, error) {
+	const api = "orders_management_system.usecase.ExpireReservations"
+
+	// Заказ, который не удалось отменить, повторяем не раньше чем через expiryRetryDelay
+	// и после остальных: такие заказы не забивают собой каждую пачку
+	now := time.Now().UTC()
+	orders, err := oms.OrdersStorage.ListUnpaidOrders(ctx, expiredBefore, now.Add(-expiryRetryDelay), limit)
+	if err != nil {
+		return 0, pkgerrors.Wrap(api, err)
+	}
+
+	var expired int
+	for _, order := range orders {
+		// Каждый заказ в своей транзакции: ошибка по одному заказу не блокирует остальные.
+		// Если заказ успели оплатить или отменить - UpdateOrderStatus не найдет его в прежнем статусе
+		err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+			return oms.cancelOrder(txCtx, order, expiredReservationReason)
+		},
+			postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+			postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+			postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+		)
+		if err != nil {
+			// не получилось - попробуем позже
+			logger.ErrorKV(ctx, "failed to expire reservation", "order_id", order.ID.String(), "error", err.Error())
+			if err := oms.OrdersStorage.PostponeOrderExpiry(ctx, order.ID, now, err.Error()); err != nil {
+				logger.ErrorKV(ctx, "failed to postpone reservation expiry", "order_id", order.ID.String(), "error", err.Error())
+			}
+			continue
+		}
+		expired++
+	}
+
+	return expired, nil
+}
@@ -0,0 +1,179 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_ExpireReservations(t *testing.T) {
+	var (
+		ctx    = context.Background() // dummy
+		before = time.Now()
+		items  = []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}}
+	)
+	type fields struct {
+		TransactionManager   *mocks.TransactionManager
+		CancellationsStorage *mocks.CancellationsStorage
+		PromoStorage         *mocks.PromoStorage
+		OrdersStorage        *mocks.OrdersStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	newOrder := func() *models.Order {
+		return &models.Order{
+			ID:            models.OrderID(uuid.New()),
+			UserID:        1,
+			Items:         items,
+			ReservationID: models.ReservationID(uuid.New()),
+			Status:        models.OrderStatusReserved,
+		}
+	}
+
+	// attemptedBefore - заказы с неудачной попыткой отмены за последние expiryRetryDelay пропускаются
+	attemptedBefore := mock.MatchedBy(func(at time.Time) bool {
+		return time.Since(at) >= expiryRetryDelay
+	})
+
+	// cancellationOf - действия отмены заказа order
+	cancellationOf := func(order *models.Order) any {
+		return mock.MatchedBy(func(c models.OrderCancellation) bool {
+			return c.OrderID == order.ID && c.ReservationID == order.ReservationID
+		})
+	}
+
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name: "Test 1. Positive. Expired orders are cancelled and reservations released.",
+			want: 2,
+			on: func(f *fields) {
+				first, second := newOrder(), newOrder()
+				f.OrdersStorage.On("ListUnpaidOrders", ctx, before, attemptedBefore, uint64(10)).
+					Return([]*models.Order{first, second}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.MatchedBy(func(transition models.OrderStatusTransition) bool {
+					return transition.To == models.OrderStatusCancelled
+				})).
+					Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventCancelled &&
+						event.Reason == expiredReservationReason &&
+						event.Actor.Type == models.ActorSystem
+				})).Return(nil)
+				f.CancellationsStorage.On("CreateOrderCancellation", ctx, cancellationOf(first)).Return(nil)
+				f.CancellationsStorage.On("CreateOrderCancellation", ctx, cancellationOf(second)).Return(nil)
+			},
+		},
+		{
+			name: "Test 2. Negative. Failed order is postponed.",
+			want: 1,
+			on: func(f *fields) {
+				first, second := newOrder(), newOrder()
+				f.OrdersStorage.On("ListUnpaidOrders", ctx, before, attemptedBefore, uint64(10)).
+					Return([]*models.Order{first, second}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.CancellationsStorage.On("CreateOrderCancellation", ctx, cancellationOf(first)).Return(errors.New("db is down"))
+				f.CancellationsStorage.On("CreateOrderCancellation", ctx, cancellationOf(second)).Return(nil)
+				f.OrdersStorage.On("PostponeOrderExpiry", ctx, first.ID, mock.Anything, "db is down").Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.OrdersStorage.AssertNumberOfCalls(t, "PostponeOrderExpiry", 1)
+			},
+		},
+		{
+			name: "Test 3. Negative. Order paid concurrently is not cancelled.",
+			want: 0,
+			on: func(f *fields) {
+				f.OrdersStorage.On("ListUnpaidOrders", ctx, before, attemptedBefore, uint64(10)).
+					Return([]*models.Order{newOrder()}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(models.ErrInvalidStatusTransition)
+				f.OrdersStorage.On("PostponeOrderExpiry", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.CancellationsStorage.AssertNotCalled(t, "CreateOrderCancellation", mock.Anything, mock.Anything)
+			},
+		},
+		{
+			name:    "Test 4. Negative. List failed.",
+			wantErr: true,
+			on: func(f *fields) {
+				f.OrdersStorage.On("ListUnpaidOrders", ctx, before, attemptedBefore, uint64(10)).Return(nil, errors.New("db is down"))
+			},
+		},
+		{
+			name: "Test 5. Positive. Promo code redemption of expired order is reverted.",
+			want: 1,
+			on: func(f *fields) {
+				order := newOrder()
+				order.PromoCode = "SALE10"
+				f.OrdersStorage.On("ListUnpaidOrders", ctx, before, attemptedBefore, uint64(10)).
+					Return([]*models.Order{order}, nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.PromoStorage.On("DeleteRedemption", ctx, order.ID).Return(nil)
+				f.CancellationsStorage.On("CreateOrderCancellation", ctx, cancellationOf(order)).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager:   mocks.NewTransactionManager(t),
+				CancellationsStorage: mocks.NewCancellationsStorage(t),
+				PromoStorage:         mocks.NewPromoStorage(t),
+				OrdersStorage:        mocks.NewOrdersStorage(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction).
+				Maybe()
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager:   f.TransactionManager,
+					CancellationsStorage: f.CancellationsStorage,
+					PromoStorage:         f.PromoStorage,
+					OrdersStorage:        f.OrdersStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			got, err := oms.ExpireReservations(ctx, before, 10)
+
+			// assert
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package orders_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// GetBasket - корзина пользователя
+func (oms *usecase) GetBasket(ctx context.Context, userID models.UserID) (*models.Basket, error) {
+	const api = "orders_management_system.usecase.GetBasket"
+
+	items, err := oms.CheckoutStorage.GetItems(ctx, userID)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return &models.Basket{
+		UserID: userID,
+		Items:  items,
+	}, nil
+}
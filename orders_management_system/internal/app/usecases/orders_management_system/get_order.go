@@ -0,0 +1,20 @@
+package orders_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// GetOrder - получение заказа
+func (oms *usecase) GetOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error) {
+	const api = "orders_management_system.usecase.GetOrder"
+
+	order, err := oms.OrdersStorage.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return order, nil
+}
@@ -0,0 +1,21 @@
+package orders_management_system
+
+import (
+	"context"
+	"time"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// GetOrderAt - заказ в состоянии на момент at
+func (oms *usecase) GetOrderAt(ctx context.Context, orderID models.OrderID, at time.Time) (*models.Order, error) {
+	const api = "orders_management_system.usecase.GetOrderAt"
+
+	order, err := oms.OrdersStorage.GetOrderAt(ctx, orderID, at)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return order, nil
+}
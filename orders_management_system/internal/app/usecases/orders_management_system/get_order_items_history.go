@@ -0,0 +1,24 @@
+package orders_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// GetOrderItemsHistory - история позиций заказа
+func (oms *usecase) GetOrderItemsHistory(ctx context.Context, orderID models.OrderID) ([]models.OrderItemChange, error) {
+	const api = "orders_management_system.usecase.GetOrderItemsHistory"
+
+	changes, err := oms.OrdersStorage.ListOrderItemChanges(ctx, orderID)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+	// у каждого заказа в истории есть хотя бы исходный состав
+	if len(changes) == 0 {
+		return nil, pkgerrors.Wrap(api, models.ErrNotFound)
+	}
+
+	return changes, nil
+}
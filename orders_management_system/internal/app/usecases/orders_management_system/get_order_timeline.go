@@ -0,0 +1,24 @@
+package orders_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// GetOrderTimeline - журнал событий заказа
+func (oms *usecase) GetOrderTimeline(ctx context.Context, orderID models.OrderID) ([]models.OrderTimelineEntry, error) {
+	const api = "orders_management_system.usecase.GetOrderTimeline"
+
+	entries, err := oms.OrdersStorage.ListOrderEvents(ctx, orderID)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+	// у каждого заказа в журнале есть хотя бы событие создания
+	if len(entries) == 0 {
+		return nil, pkgerrors.Wrap(api, models.ErrNotFound)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,149 @@
+package orders_management_system
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// paymentProviderActor - инициатор изменений заказа по уведомлениям провайдера
+var paymentProviderActor = models.Actor{Type: models.ActorPaymentProvider}
+
+// HandlePaymentCallback - результат авторизации платежа от провайдера
+func (oms *usecase) HandlePaymentCallback(ctx context.Context, callback models.PaymentCallback) error {
+	const api = "orders_management_system.usecase.HandlePaymentCallback"
+
+	// Метод публичный: принимаем только уведомления, подписанные провайдером
+	if err := oms.Payments.VerifyCallback(ctx, callback); err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		payment, err := oms.PaymentsStorage.LockPayment(txCtx, callback.PaymentID)
+		if err != nil {
+			return err
+		}
+		// Уведомление о чужом платеже у провайдера. ID еще не сохранен (уведомление пришло раньше ответа
+		// Authorize) - берем его из подписанного уведомления
+		if payment.ProviderPaymentID != "" && payment.ProviderPaymentID != callback.ProviderPaymentID {
+			return fmt.Errorf("%w: provider payment id %q, expected %q",
+				models.ErrInvalidArgument, callback.ProviderPaymentID, payment.ProviderPaymentID)
+		}
+		// Повторное уведомление: платеж уже обработан
+		if payment.Status != models.PaymentStatusPending {
+			return nil
+		}
+		payment.ProviderPaymentID = callback.ProviderPaymentID
+
+		order, err := oms.OrdersStorage.GetOrder(txCtx, payment.OrderID)
+		if err != nil {
+			return err
+		}
+
+		switch callback.Result {
+		case models.PaymentResultAuthorized:
+			return oms.paymentAuthorized(txCtx, order, payment)
+		case models.PaymentResultFailed:
+			return oms.paymentFailed(txCtx, order, payment, callback.FailureReason)
+		default:
+			return fmt.Errorf("%w: unknown payment result %q", models.ErrInvalidArgument, callback.Result)
+		}
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return pkgerrors.Wrap(api, err)
+	}
+
+	return nil
+}
+
+// paymentAuthorized - деньги заблокированы: списываем их и переводим заказ в paid.
+// Если заказ успели отменить - отменяем авторизацию
+func (oms *usecase) paymentAuthorized(txCtx context.Context, order *models.Order, payment *models.Payment) error {
+	now := time.Now().UTC()
+
+	if order.Status != models.OrderStatusAwaitingPayment {
+		if err := payment.SetStatus(models.PaymentStatusVoided, now); err != nil {
+			return err
+		}
+		if err := oms.PaymentsStorage.UpdatePayment(txCtx, payment); err != nil {
+			return err
+		}
+		return oms.Payments.Void(txCtx, payment.ProviderPaymentID)
+	}
+
+	if err := payment.SetStatus(models.PaymentStatusAuthorized, now); err != nil {
+		return err
+	}
+	if err := payment.SetStatus(models.PaymentStatusCaptured, now); err != nil {
+		return err
+	}
+	if err := oms.PaymentsStorage.UpdatePayment(txCtx, payment); err != nil {
+		return err
+	}
+
+	transition, err := order.SetStatus(models.OrderStatusPaid, now)
+	if err != nil {
+		return err
+	}
+	if err := oms.OrdersStorage.UpdateOrderStatus(txCtx, *transition); err != nil {
+		return err
+	}
+
+	// Публикуем событие в outbox табличке, которое будет обработоно асинхронно позже.
+	// Заказ меняет уведомление провайдера, а не вызывающий API
+	event := models.NewOrderPaidEvent(order, *transition, *payment)
+	event.Actor = paymentProviderActor
+	if err := oms.appendOrderEvent(txCtx, event); err != nil {
+		return err
+	}
+
+	// Внешние вызовы последним шагом: при ошибке транзакция откатится, а провайдер повторит уведомление.
+	// Подтверждение резерва и списание идемпотентны
+	if !order.ReservationID.IsZero() {
+		if err := oms.confirmStocks(txCtx, order.ReservationID, order.Items); err != nil {
+			return err
+		}
+	}
+	return oms.Payments.Capture(txCtx, payment.ProviderPaymentID, payment.Amount)
+}
+
+// paymentFailed - авторизация не прошла: заказ переходит в payment_failed и его можно оплатить повторно.
+// Резерв стоков остается до повторной оплаты, отмены или истечения срока (ExpireReservations)
+func (oms *usecase) paymentFailed(txCtx context.Context, order *models.Order, payment *models.Payment, reason string) error {
+	now := time.Now().UTC()
+
+	if err := payment.SetStatus(models.PaymentStatusFailed, now); err != nil {
+		return err
+	}
+	payment.FailureReason = reason
+	if err := oms.PaymentsStorage.UpdatePayment(txCtx, payment); err != nil {
+		return err
+	}
+
+	// Заказ успели отменить - менять нечего
+	if order.Status != models.OrderStatusAwaitingPayment {
+		return nil
+	}
+
+	transition, err := order.SetStatus(models.OrderStatusPaymentFailed, now)
+	if err != nil {
+		return err
+	}
+	if err := oms.OrdersStorage.UpdateOrderStatus(txCtx, *transition); err != nil {
+		return err
+	}
+
+	// Публикуем событие в outbox табличке, которое будет обработоно асинхронно позже
+	event := models.NewOrderPaymentFailedEvent(order, *transition, *payment)
+	event.Actor = paymentProviderActor
+	return oms.appendOrderEvent(txCtx, event)
+}
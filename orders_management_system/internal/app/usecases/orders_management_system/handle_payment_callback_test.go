@@ -0,0 +1,231 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_HandlePaymentCallback(t *testing.T) {
+	var (
+		ctx           = context.Background() // dummy
+		orderID       = models.OrderID(uuid.New())
+		paymentID     = models.PaymentID(uuid.New())
+		reservationID = models.ReservationID(uuid.New())
+		amount        = models.NewMoney(3800, models.CurrencyRUB)
+		items         = []models.Item{
+			{SKU: models.SKU{ID: 1}, Quantity: 1, WarehouseID: 10},
+			{SKU: models.SKU{ID: 2}, Quantity: 1, WarehouseID: 20},
+		}
+		order = func(status models.OrderStatus) *models.Order {
+			return &models.Order{ID: orderID, UserID: 1, Items: items, ReservationID: reservationID, Status: status}
+		}
+		payment = func(status models.PaymentStatus) *models.Payment {
+			return &models.Payment{ID: paymentID, OrderID: orderID, Amount: amount, Status: status, ProviderPaymentID: "provider-1"}
+		}
+		authorized = models.PaymentCallback{PaymentID: paymentID, ProviderPaymentID: "provider-1", Result: models.PaymentResultAuthorized}
+		failed     = models.PaymentCallback{PaymentID: paymentID, ProviderPaymentID: "provider-1", Result: models.PaymentResultFailed, FailureReason: "declined"}
+		foreign    = models.PaymentCallback{PaymentID: paymentID, ProviderPaymentID: "provider-2", Result: models.PaymentResultAuthorized}
+	)
+	type fields struct {
+		TransactionManager        *mocks.TransactionManager
+		WarehouseManagementSystem *mocks.WarehouseManagementSystem
+		Payments                  *mocks.Payments
+		PaymentsStorage           *mocks.PaymentsStorage
+		OrdersStorage             *mocks.OrdersStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	tests := []struct {
+		name      string
+		callback  models.PaymentCallback
+		verifyErr error
+		wantErr   error
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name:     "Test 1. Positive. Authorized payment is captured, order is paid.",
+			callback: authorized,
+			on: func(f *fields) {
+				f.PaymentsStorage.On("LockPayment", ctx, paymentID).Return(payment(models.PaymentStatusPending), nil)
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusAwaitingPayment), nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.Status == models.PaymentStatusCaptured
+				})).Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.MatchedBy(func(transition models.OrderStatusTransition) bool {
+					return transition.From == models.OrderStatusAwaitingPayment && transition.To == models.OrderStatusPaid
+				})).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventPaid && event.Payment.ID == paymentID &&
+						event.Actor.Type == models.ActorPaymentProvider
+				})).Return(nil)
+				f.WarehouseManagementSystem.On("ConfirmReservation", ctx, reservationID.ForWarehouse(10)).Return(nil)
+				f.WarehouseManagementSystem.On("ConfirmReservation", ctx, reservationID.ForWarehouse(20)).Return(nil)
+				f.Payments.On("Capture", ctx, "provider-1", amount).Return(nil)
+			},
+		},
+		{
+			name:     "Test 2. Positive. Failed payment, order can be paid again.",
+			callback: failed,
+			on: func(f *fields) {
+				f.PaymentsStorage.On("LockPayment", ctx, paymentID).Return(payment(models.PaymentStatusPending), nil)
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusAwaitingPayment), nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.Status == models.PaymentStatusFailed && payment.FailureReason == "declined"
+				})).Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.MatchedBy(func(transition models.OrderStatusTransition) bool {
+					return transition.To == models.OrderStatusPaymentFailed
+				})).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventPaymentFailed && event.Reason == "declined" &&
+						event.Actor.Type == models.ActorPaymentProvider
+				})).Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReleaseReservation", 0)
+			},
+		},
+		{
+			name:     "Test 3. Positive. Repeated callback is ignored.",
+			callback: authorized,
+			on: func(f *fields) {
+				f.PaymentsStorage.On("LockPayment", ctx, paymentID).Return(payment(models.PaymentStatusCaptured), nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.Payments.AssertNumberOfCalls(t, "Capture", 0)
+				f.OrdersStorage.AssertNumberOfCalls(t, "UpdateOrderStatus", 0)
+			},
+		},
+		{
+			name:     "Test 4. Positive. Order cancelled before authorization - payment is voided.",
+			callback: authorized,
+			on: func(f *fields) {
+				f.PaymentsStorage.On("LockPayment", ctx, paymentID).Return(payment(models.PaymentStatusPending), nil)
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusCancelled), nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.Status == models.PaymentStatusVoided
+				})).Return(nil)
+				f.Payments.On("Void", ctx, "provider-1").Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.Payments.AssertNumberOfCalls(t, "Capture", 0)
+				f.OrdersStorage.AssertNumberOfCalls(t, "UpdateOrderStatus", 0)
+			},
+		},
+		{
+			name:     "Test 5. Positive. Callback came before Authorize response - provider payment id is taken from it.",
+			callback: authorized,
+			on: func(f *fields) {
+				unsaved := payment(models.PaymentStatusPending)
+				unsaved.ProviderPaymentID = ""
+				f.PaymentsStorage.On("LockPayment", ctx, paymentID).Return(unsaved, nil)
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusAwaitingPayment), nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.Status == models.PaymentStatusCaptured && payment.ProviderPaymentID == "provider-1"
+				})).Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.WarehouseManagementSystem.On("ConfirmReservation", ctx, mock.Anything).Return(nil)
+				f.Payments.On("Capture", ctx, "provider-1", amount).Return(nil)
+			},
+		},
+		{
+			name:     "Test 6. Negative. Unknown payment.",
+			callback: authorized,
+			wantErr:  models.ErrNotFound,
+			on: func(f *fields) {
+				f.PaymentsStorage.On("LockPayment", ctx, paymentID).Return(nil, models.ErrNotFound)
+			},
+		},
+		{
+			name:     "Test 7. Negative. Capture fails - provider will retry callback.",
+			callback: authorized,
+			wantErr:  models.ErrServiceUnavailable,
+			on: func(f *fields) {
+				f.PaymentsStorage.On("LockPayment", ctx, paymentID).Return(payment(models.PaymentStatusPending), nil)
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusAwaitingPayment), nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.WarehouseManagementSystem.On("ConfirmReservation", ctx, mock.Anything).Return(nil)
+				f.Payments.On("Capture", ctx, "provider-1", amount).Return(models.ErrServiceUnavailable)
+			},
+		},
+		{
+			name:      "Test 8. Negative. Callback is not signed by provider.",
+			callback:  authorized,
+			verifyErr: models.ErrUnauthenticated,
+			wantErr:   models.ErrUnauthenticated,
+		},
+		{
+			name:     "Test 9. Negative. Callback is about another provider payment.",
+			callback: foreign,
+			wantErr:  models.ErrInvalidArgument,
+			on: func(f *fields) {
+				f.PaymentsStorage.On("LockPayment", ctx, paymentID).Return(payment(models.PaymentStatusPending), nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.Payments.AssertNumberOfCalls(t, "Capture", 0)
+				f.PaymentsStorage.AssertNumberOfCalls(t, "UpdatePayment", 0)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager:        mocks.NewTransactionManager(t),
+				WarehouseManagementSystem: mocks.NewWarehouseManagementSystem(t),
+				Payments:                  mocks.NewPayments(t),
+				PaymentsStorage:           mocks.NewPaymentsStorage(t),
+				OrdersStorage:             mocks.NewOrdersStorage(t),
+			}
+			f.Payments.On("VerifyCallback", ctx, tt.callback).Return(tt.verifyErr)
+			if tt.verifyErr == nil {
+				f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					Return(runTransaction)
+			}
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager:        f.TransactionManager,
+					WarehouseManagementSystem: f.WarehouseManagementSystem,
+					Payments:                  f.Payments,
+					PaymentsStorage:           f.PaymentsStorage,
+					OrdersStorage:             f.OrdersStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			err := oms.HandlePaymentCallback(ctx, tt.callback)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package orders_management_system
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
+
+// errIdempotencyKeyTaken - ключ успел сохранить параллельный запрос
+var errIdempotencyKeyTaken = errors.New("idempotency key taken by concurrent request")
+
+// createOrderFingerprint - отпечаток запроса на создание заказа (без ключа идемпотентности)
+func createOrderFingerprint(userID models.UserID, info CreateOrderInfo) string {
+	info.IdempotencyKey = ""
+
+	b, _ := json.Marshal(struct {
+		UserID models.UserID
+		Info   CreateOrderInfo
+	}{
+		UserID: userID,
+		Info:   info,
+	})
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// createOrderFromBasketFingerprint - отпечаток запроса на создание заказа из корзины (без ключа идемпотентности).
+// Отличается от отпечатка CreateOrder, поэтому ключ нельзя переиспользовать между методами
+func createOrderFromBasketFingerprint(userID models.UserID, info CreateOrderFromBasketInfo) string {
+	info.IdempotencyKey = ""
+
+	b, _ := json.Marshal(struct {
+		UserID models.UserID
+		Basket CreateOrderFromBasketInfo
+	}{
+		UserID: userID,
+		Basket: info,
+	})
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// replayCreateOrder - ищет результат предыдущего запроса с тем же ключом идемпотентности.
+// Возвращает nil, nil если ключ еще не использовался.
+func (oms *usecase) replayCreateOrder(ctx context.Context, userID models.UserID, key, fingerprint string) (*models.Order, error) {
+	stored, err := oms.OrdersStorage.GetIdempotencyKey(ctx, userID, key)
+	if err != nil {
+		if errors.Is(err, models.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if stored.RequestHash != fingerprint {
+		return nil, fmt.Errorf("key %q: %w", key, models.ErrIdempotencyKeyReused)
+	}
+
+	return oms.OrdersStorage.GetOrder(ctx, stored.OrderID)
+}
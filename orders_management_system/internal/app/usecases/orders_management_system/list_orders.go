@@ -0,0 +1,57 @@
+package orders_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// ListOrders - список заказов пользователя (постранично)
+func (oms *usecase) ListOrders(ctx context.Context, userID models.UserID, filter ListOrdersFilter) (*ListOrdersResult, error) {
+	const api = "orders_management_system.usecase.ListOrders"
+
+	after, err := decodePageToken(filter.PageToken)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	pageSize := uint64(filter.PageSize)
+	switch {
+	case pageSize == 0:
+		pageSize = defaultPageSize
+	case pageSize > maxPageSize:
+		pageSize = maxPageSize
+	}
+
+	// Запрашиваем на один заказ больше, чтобы понять есть ли следующая страница
+	orders, err := oms.OrdersStorage.ListOrders(ctx, models.OrdersFilter{
+		UserID:           userID,
+		Statuses:         filter.Statuses,
+		DeliveryDateFrom: filter.DeliveryDateFrom,
+		DeliveryDateTo:   filter.DeliveryDateTo,
+		After:            after,
+		Limit:            pageSize + 1,
+	})
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	result := &ListOrdersResult{Orders: orders}
+	if uint64(len(orders)) > pageSize {
+		result.Orders = orders[:pageSize]
+
+		last := result.Orders[pageSize-1]
+		result.NextPageToken = encodePageToken(models.OrdersCursor{
+			CreatedAt: last.CreatedAt,
+			ID:        last.ID,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,67 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_usecase_ListOrders(t *testing.T) {
+	var (
+		ctx       = context.Background() // dummy
+		createdAt = time.Date(2024, 10, 1, 12, 0, 0, 0, time.UTC)
+		order1    = &models.Order{ID: models.OrderID(uuid.New()), UserID: 1, CreatedAt: createdAt}
+		order2    = &models.Order{ID: models.OrderID(uuid.New()), UserID: 1, CreatedAt: createdAt.Add(-time.Hour)}
+		order3    = &models.Order{ID: models.OrderID(uuid.New()), UserID: 1, CreatedAt: createdAt.Add(-2 * time.Hour)}
+	)
+
+	t.Run("Test 1. Positive. Has next page.", func(t *testing.T) {
+		storage := mocks.NewOrdersStorage(t)
+		oms := &usecase{Deps: Deps{OrdersStorage: storage}}
+
+		storage.On("ListOrders", ctx, models.OrdersFilter{UserID: 1, Limit: 3}).
+			Return([]*models.Order{order1, order2, order3}, nil)
+
+		got, err := oms.ListOrders(ctx, 1, ListOrdersFilter{PageSize: 2})
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Order{order1, order2}, got.Orders)
+		require.NotEmpty(t, got.NextPageToken)
+
+		cursor, err := decodePageToken(got.NextPageToken)
+		require.NoError(t, err)
+		assert.Equal(t, order2.ID, cursor.ID)
+		assert.True(t, order2.CreatedAt.Equal(cursor.CreatedAt))
+	})
+
+	t.Run("Test 2. Positive. Last page.", func(t *testing.T) {
+		storage := mocks.NewOrdersStorage(t)
+		oms := &usecase{Deps: Deps{OrdersStorage: storage}}
+
+		after := &models.OrdersCursor{CreatedAt: order2.CreatedAt, ID: order2.ID}
+		storage.On("ListOrders", ctx, models.OrdersFilter{UserID: 1, After: after, Limit: defaultPageSize + 1}).
+			Return([]*models.Order{order3}, nil)
+
+		got, err := oms.ListOrders(ctx, 1, ListOrdersFilter{PageToken: encodePageToken(*after)})
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Order{order3}, got.Orders)
+		assert.Empty(t, got.NextPageToken)
+	})
+
+	t.Run("Test 3. Negative. Invalid page token.", func(t *testing.T) {
+		storage := mocks.NewOrdersStorage(t)
+		oms := &usecase{Deps: Deps{OrdersStorage: storage}}
+
+		_, err := oms.ListOrders(ctx, 1, ListOrdersFilter{PageToken: "not a token"})
+		assert.True(t, errors.Is(err, models.ErrInvalidArgument))
+		storage.AssertNumberOfCalls(t, "ListOrders", 0)
+	})
+}
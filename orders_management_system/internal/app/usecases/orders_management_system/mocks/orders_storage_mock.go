@@ -1,11 +1,10 @@
-//go:build test
 // Code generated by mockery. DO NOT EDIT.
 
-
 package mocks
 
 import (
 	context "context"
+	time "time"
 
 	models "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
 	mock "github.com/stretchr/testify/mock"
@@ -16,6 +15,42 @@ type OrdersStorage struct {
 	mock.Mock
 }
 
+// AppendOrderEvent provides a mock function with given fields: ctx, event
+func (_m *OrdersStorage) AppendOrderEvent(ctx context.Context, event models.OrderEvent) error {
+	ret := _m.Called(ctx, event)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AppendOrderEvent")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateIdempotencyKey provides a mock function with given fields: ctx, key
+func (_m *OrdersStorage) CreateIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateIdempotencyKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.IdempotencyKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CreateOrder provides a mock function with given fields: ctx, order
 func (_m *OrdersStorage) CreateOrder(ctx context.Context, order *models.Order) error {
 	ret := _m.Called(ctx, order)
@@ -34,17 +69,293 @@ func (_m *OrdersStorage) CreateOrder(ctx context.Context, order *models.Order) e
 	return r0
 }
 
-// CreateOutboxMessage provides a mock function with given fields: ctx, order
-func (_m *OrdersStorage) CreateOutboxMessage(ctx context.Context, order *models.Order) error {
-	ret := _m.Called(ctx, order)
+// GetIdempotencyKey provides a mock function with given fields: ctx, userID, key
+func (_m *OrdersStorage) GetIdempotencyKey(ctx context.Context, userID models.UserID, key string) (*models.IdempotencyKey, error) {
+	ret := _m.Called(ctx, userID, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetIdempotencyKey")
+	}
+
+	var r0 *models.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserID, string) (*models.IdempotencyKey, error)); ok {
+		return rf(ctx, userID, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.UserID, string) *models.IdempotencyKey); ok {
+		r0 = rf(ctx, userID, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.UserID, string) error); ok {
+		r1 = rf(ctx, userID, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrder provides a mock function with given fields: ctx, orderID
+func (_m *OrdersStorage) GetOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrder")
+	}
+
+	var r0 *models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID) (*models.Order, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID) *models.Order); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.OrderID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOrderAt provides a mock function with given fields: ctx, orderID, at
+func (_m *OrdersStorage) GetOrderAt(ctx context.Context, orderID models.OrderID, at time.Time) (*models.Order, error) {
+	ret := _m.Called(ctx, orderID, at)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetOrderAt")
+	}
+
+	var r0 *models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID, time.Time) (*models.Order, error)); ok {
+		return rf(ctx, orderID, at)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID, time.Time) *models.Order); ok {
+		r0 = rf(ctx, orderID, at)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.OrderID, time.Time) error); ok {
+		r1 = rf(ctx, orderID, at)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOrderEvents provides a mock function with given fields: ctx, orderID
+func (_m *OrdersStorage) ListOrderEvents(ctx context.Context, orderID models.OrderID) ([]models.OrderTimelineEntry, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrderEvents")
+	}
+
+	var r0 []models.OrderTimelineEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID) ([]models.OrderTimelineEntry, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID) []models.OrderTimelineEntry); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OrderTimelineEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.OrderID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOrderItemChanges provides a mock function with given fields: ctx, orderID
+func (_m *OrdersStorage) ListOrderItemChanges(ctx context.Context, orderID models.OrderID) ([]models.OrderItemChange, error) {
+	ret := _m.Called(ctx, orderID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateOutboxMessage")
+		panic("no return value specified for ListOrderItemChanges")
+	}
+
+	var r0 []models.OrderItemChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID) ([]models.OrderItemChange, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID) []models.OrderItemChange); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.OrderItemChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.OrderID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListOrders provides a mock function with given fields: ctx, filter
+func (_m *OrdersStorage) ListOrders(ctx context.Context, filter models.OrdersFilter) ([]*models.Order, error) {
+	ret := _m.Called(ctx, filter)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListOrders")
+	}
+
+	var r0 []*models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrdersFilter) ([]*models.Order, error)); ok {
+		return rf(ctx, filter)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrdersFilter) []*models.Order); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.OrdersFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListUnpaidOrders provides a mock function with given fields: ctx, createdBefore, attemptedBefore, limit
+func (_m *OrdersStorage) ListUnpaidOrders(ctx context.Context, createdBefore time.Time, attemptedBefore time.Time, limit uint64) ([]*models.Order, error) {
+	ret := _m.Called(ctx, createdBefore, attemptedBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListUnpaidOrders")
+	}
+
+	var r0 []*models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint64) ([]*models.Order, error)); ok {
+		return rf(ctx, createdBefore, attemptedBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time, uint64) []*models.Order); ok {
+		r0 = rf(ctx, createdBefore, attemptedBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time, uint64) error); ok {
+		r1 = rf(ctx, createdBefore, attemptedBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// LockOrder provides a mock function with given fields: ctx, orderID
+func (_m *OrdersStorage) LockOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error) {
+	ret := _m.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockOrder")
+	}
+
+	var r0 *models.Order
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID) (*models.Order, error)); ok {
+		return rf(ctx, orderID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID) *models.Order); ok {
+		r0 = rf(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Order)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.OrderID) error); ok {
+		r1 = rf(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostponeOrderExpiry provides a mock function with given fields: ctx, orderID, attemptedAt, lastError
+func (_m *OrdersStorage) PostponeOrderExpiry(ctx context.Context, orderID models.OrderID, attemptedAt time.Time, lastError string) error {
+	ret := _m.Called(ctx, orderID, attemptedAt, lastError)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostponeOrderExpiry")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Order) error); ok {
-		r0 = rf(ctx, order)
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderID, time.Time, string) error); ok {
+		r0 = rf(ctx, orderID, attemptedAt, lastError)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOrderItems provides a mock function with given fields: ctx, order, changes
+func (_m *OrdersStorage) UpdateOrderItems(ctx context.Context, order *models.Order, changes []models.OrderItemChange) error {
+	ret := _m.Called(ctx, order, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderItems")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Order, []models.OrderItemChange) error); ok {
+		r0 = rf(ctx, order, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateOrderStatus provides a mock function with given fields: ctx, transition
+func (_m *OrdersStorage) UpdateOrderStatus(ctx context.Context, transition models.OrderStatusTransition) error {
+	ret := _m.Called(ctx, transition)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderStatus")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.OrderStatusTransition) error); ok {
+		r0 = rf(ctx, transition)
 	} else {
 		r0 = ret.Error(0)
 	}
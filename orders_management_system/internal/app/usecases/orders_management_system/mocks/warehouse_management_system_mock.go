@@ -14,17 +14,71 @@ type WarehouseManagementSystem struct {
 	mock.Mock
 }
 
-// ReserveStocks provides a mock function with given fields: ctx, userID, items
-func (_m *WarehouseManagementSystem) ReserveStocks(ctx context.Context, userID models.UserID, items []models.Item) error {
-	ret := _m.Called(ctx, userID, items)
+// ConfirmReservation provides a mock function with given fields: ctx, reservationID
+func (_m *WarehouseManagementSystem) ConfirmReservation(ctx context.Context, reservationID models.ReservationID) error {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ConfirmReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReservationID) error); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReleaseReservation provides a mock function with given fields: ctx, reservationID
+func (_m *WarehouseManagementSystem) ReleaseReservation(ctx context.Context, reservationID models.ReservationID) error {
+	ret := _m.Called(ctx, reservationID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReservationID) error); ok {
+		r0 = rf(ctx, reservationID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReserveStocks provides a mock function with given fields: ctx, reservationID, userID, items
+func (_m *WarehouseManagementSystem) ReserveStocks(ctx context.Context, reservationID models.ReservationID, userID models.UserID, items []models.Item) error {
+	ret := _m.Called(ctx, reservationID, userID, items)
 
 	if len(ret) == 0 {
 		panic("no return value specified for ReserveStocks")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.UserID, []models.Item) error); ok {
-		r0 = rf(ctx, userID, items)
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReservationID, models.UserID, []models.Item) error); ok {
+		r0 = rf(ctx, reservationID, userID, items)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ShrinkReservation provides a mock function with given fields: ctx, reservationID, items
+func (_m *WarehouseManagementSystem) ShrinkReservation(ctx context.Context, reservationID models.ReservationID, items []models.Item) error {
+	ret := _m.Called(ctx, reservationID, items)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ShrinkReservation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ReservationID, []models.Item) error); ok {
+		r0 = rf(ctx, reservationID, items)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -0,0 +1,46 @@
+package orders_management_system
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
+
+// pageToken - содержимое токена страницы (непрозрачен для клиента)
+type pageToken struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"i"`
+}
+
+func encodePageToken(cursor models.OrdersCursor) string {
+	b, _ := json.Marshal(pageToken{
+		CreatedAt: cursor.CreatedAt,
+		ID:        uuid.UUID(cursor.ID),
+	})
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodePageToken(token string) (*models.OrdersCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("page token: %w", models.ErrInvalidArgument)
+	}
+
+	var t pageToken
+	if err := json.Unmarshal(b, &t); err != nil {
+		return nil, fmt.Errorf("page token: %w", models.ErrInvalidArgument)
+	}
+
+	return &models.OrdersCursor{
+		CreatedAt: t.CreatedAt,
+		ID:        models.OrderID(t.ID),
+	}, nil
+}
@@ -0,0 +1,103 @@
+package orders_management_system
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// PayOrder - оплата заказа
+func (oms *usecase) PayOrder(ctx context.Context, orderID models.OrderID, paymentMethod string) (*PayOrderResult, error) {
+	const api = "orders_management_system.usecase.PayOrder"
+
+	var (
+		order   *models.Order
+		payment *models.Payment
+	)
+	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		var err error
+		if order, err = oms.OrdersStorage.GetOrder(txCtx, orderID); err != nil {
+			return err
+		}
+
+		// Оплатить можно заказ с резервом (reserved) или после неудачной оплаты (payment_failed)
+		transition, err := order.SetStatus(models.OrderStatusAwaitingPayment, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+
+		payment = &models.Payment{
+			ID:        models.PaymentID(uuid.New()),
+			OrderID:   order.ID,
+			Amount:    order.Totals.Total,
+			Method:    paymentMethod,
+			Status:    models.PaymentStatusPending,
+			CreatedAt: transition.ChangedAt,
+			UpdatedAt: transition.ChangedAt,
+		}
+		// Уникальный индекс по незавершенному платежу заказа защищает от двойной оплаты
+		if err := oms.PaymentsStorage.CreatePayment(txCtx, payment); err != nil {
+			return err
+		}
+
+		if err := oms.OrdersStorage.UpdateOrderStatus(txCtx, *transition); err != nil {
+			return err
+		}
+
+		// Публикуем событие в outbox табличке, которое будет обработоно асинхронно позже
+		return oms.appendOrderEvent(txCtx, models.NewOrderStatusChangedEvent(order, *transition))
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	// Запрос к провайдеру вне транзакции: заказ и платеж не заблокированы на время сетевого вызова.
+	// Платеж уже закоммичен - уведомление, пришедшее раньше ответа, найдет его в БД
+	providerPaymentID, authErr := oms.Payments.Authorize(ctx, *payment)
+
+	err = oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		locked, err := oms.PaymentsStorage.LockPayment(txCtx, payment.ID)
+		if err != nil {
+			return err
+		}
+		payment = locked
+		// Уведомление провайдера уже обработано (HandlePaymentCallback): платеж завершен
+		if payment.Status != models.PaymentStatusPending {
+			return nil
+		}
+
+		// Провайдер платеж не принял: заказ можно оплатить повторно (Authorize идемпотентен по payment.ID)
+		if authErr != nil {
+			current, err := oms.OrdersStorage.GetOrder(txCtx, payment.OrderID)
+			if err != nil {
+				return err
+			}
+			return oms.paymentFailed(txCtx, current, payment, authErr.Error())
+		}
+
+		payment.ProviderPaymentID = providerPaymentID
+		return oms.PaymentsStorage.UpdatePayment(txCtx, payment)
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if authErr != nil {
+		return nil, pkgerrors.Wrap(api, fmt.Errorf("authorize payment %s: %w", payment.ID, authErr))
+	}
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return &PayOrderResult{Order: order, Payment: payment}, nil
+}
@@ -0,0 +1,221 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_PayOrder(t *testing.T) {
+	var (
+		ctx     = context.Background() // dummy
+		orderID = models.OrderID(uuid.New())
+		total   = models.NewMoney(3800, models.CurrencyRUB)
+		order   = func(status models.OrderStatus) *models.Order {
+			return &models.Order{ID: orderID, UserID: 1, Totals: models.OrderTotals{Total: total}, Status: status}
+		}
+	)
+	type fields struct {
+		TransactionManager *mocks.TransactionManager
+		Payments           *mocks.Payments
+		PaymentsStorage    *mocks.PaymentsStorage
+		OrdersStorage      *mocks.OrdersStorage
+
+		created        *models.Payment // платеж из CreatePayment
+		inTransaction  bool            // сейчас выполняется функция транзакции
+		authorizedInTx bool            // Authorize вызван внутри транзакции
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(fs *fields) func(context.Context, func(context.Context) error, ...transaction_manager.TransactionOption) error {
+		return func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+			fs.inTransaction = true
+			defer func() { fs.inTransaction = false }()
+			return f(ctx)
+		}
+	}
+	// createPayment - запоминает созданный платеж
+	createPayment := func(f *fields) func(mock.Arguments) {
+		return func(args mock.Arguments) { f.created = args.Get(1).(*models.Payment) }
+	}
+	// lockPayment - копия созданного платежа, с которой его видит вторая транзакция
+	lockPayment := func(f *fields, change func(*models.Payment)) func(context.Context, models.PaymentID) (*models.Payment, error) {
+		return func(_ context.Context, id models.PaymentID) (*models.Payment, error) {
+			payment := *f.created
+			if payment.ID != id {
+				return nil, models.ErrNotFound
+			}
+			if change != nil {
+				change(&payment)
+			}
+			return &payment, nil
+		}
+	}
+
+	tests := []struct {
+		name              string
+		wantPaymentStatus models.PaymentStatus
+		wantErr           error
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name:              "Test 1. Positive. Reserved order awaits payment.",
+			wantPaymentStatus: models.PaymentStatusPending,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusReserved), nil)
+				f.PaymentsStorage.On("CreatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.OrderID == orderID &&
+						payment.Amount == total &&
+						payment.Method == "card" &&
+						payment.Status == models.PaymentStatusPending
+				})).Run(createPayment(f)).Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.MatchedBy(func(transition models.OrderStatusTransition) bool {
+					return transition.From == models.OrderStatusReserved && transition.To == models.OrderStatusAwaitingPayment
+				})).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventStatusChanged && event.Transition.To == models.OrderStatusAwaitingPayment
+				})).Return(nil)
+				f.Payments.On("Authorize", ctx, mock.Anything).Run(func(mock.Arguments) {
+					f.authorizedInTx = f.inTransaction
+				}).Return("provider-1", nil)
+				f.PaymentsStorage.On("LockPayment", ctx, mock.Anything).Return(lockPayment(f, nil), nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.ProviderPaymentID == "provider-1" && payment.Status == models.PaymentStatusPending
+				})).Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				assert.False(t, f.authorizedInTx, "provider is called inside transaction")
+			},
+		},
+		{
+			name:              "Test 2. Positive. Retry after failed payment.",
+			wantPaymentStatus: models.PaymentStatusPending,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusPaymentFailed), nil)
+				f.PaymentsStorage.On("CreatePayment", ctx, mock.Anything).Run(createPayment(f)).Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.Payments.On("Authorize", ctx, mock.Anything).Return("provider-2", nil)
+				f.PaymentsStorage.On("LockPayment", ctx, mock.Anything).Return(lockPayment(f, nil), nil)
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.Anything).Return(nil)
+			},
+		},
+		{
+			name:              "Test 3. Positive. Callback was handled before Authorize response.",
+			wantPaymentStatus: models.PaymentStatusCaptured,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusReserved), nil)
+				f.PaymentsStorage.On("CreatePayment", ctx, mock.Anything).Run(createPayment(f)).Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.Anything).Return(nil)
+				f.Payments.On("Authorize", ctx, mock.Anything).Return("provider-1", nil)
+				f.PaymentsStorage.On("LockPayment", ctx, mock.Anything).Return(lockPayment(f, func(p *models.Payment) {
+					p.Status, p.ProviderPaymentID = models.PaymentStatusCaptured, "provider-1"
+				}), nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.PaymentsStorage.AssertNumberOfCalls(t, "UpdatePayment", 0)
+			},
+		},
+		{
+			name:    "Test 4. Negative. Order already paid.",
+			wantErr: models.ErrInvalidStatusTransition,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusPaid), nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.Payments.AssertNumberOfCalls(t, "Authorize", 0)
+			},
+		},
+		{
+			name:    "Test 5. Negative. Payment already in progress.",
+			wantErr: models.ErrAlreadyExists,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusReserved), nil)
+				f.PaymentsStorage.On("CreatePayment", ctx, mock.Anything).Return(models.ErrAlreadyExists)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.Payments.AssertNumberOfCalls(t, "Authorize", 0)
+			},
+		},
+		{
+			name:    "Test 6. Negative. Payments returns error - order can be paid again.",
+			wantErr: models.ErrServiceUnavailable,
+			on: func(f *fields) {
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusReserved), nil).Once()
+				f.PaymentsStorage.On("CreatePayment", ctx, mock.Anything).Run(createPayment(f)).Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.MatchedBy(func(transition models.OrderStatusTransition) bool {
+					return transition.To == models.OrderStatusAwaitingPayment
+				})).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventStatusChanged
+				})).Return(nil)
+				f.Payments.On("Authorize", ctx, mock.Anything).Return("", models.ErrServiceUnavailable)
+				f.PaymentsStorage.On("LockPayment", ctx, mock.Anything).Return(lockPayment(f, nil), nil)
+				f.OrdersStorage.On("GetOrder", ctx, orderID).Return(order(models.OrderStatusAwaitingPayment), nil).Once()
+				f.PaymentsStorage.On("UpdatePayment", ctx, mock.MatchedBy(func(payment *models.Payment) bool {
+					return payment.Status == models.PaymentStatusFailed
+				})).Return(nil)
+				f.OrdersStorage.On("UpdateOrderStatus", ctx, mock.MatchedBy(func(transition models.OrderStatusTransition) bool {
+					return transition.From == models.OrderStatusAwaitingPayment && transition.To == models.OrderStatusPaymentFailed
+				})).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventPaymentFailed
+				})).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager: mocks.NewTransactionManager(t),
+				Payments:           mocks.NewPayments(t),
+				PaymentsStorage:    mocks.NewPaymentsStorage(t),
+				OrdersStorage:      mocks.NewOrdersStorage(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction(f))
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager: f.TransactionManager,
+					Payments:           f.Payments,
+					PaymentsStorage:    f.PaymentsStorage,
+					OrdersStorage:      f.OrdersStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			got, err := oms.PayOrder(ctx, orderID, "card")
+
+			// assert
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, models.OrderStatusAwaitingPayment, got.Order.Status)
+				assert.Equal(t, tt.wantPaymentStatus, got.Payment.Status)
+				assert.NotEmpty(t, got.Payment.ProviderPaymentID)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
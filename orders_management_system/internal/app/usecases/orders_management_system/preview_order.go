@@ -0,0 +1,30 @@
+package orders_management_system
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// PreviewOrder - стоимость заказа до его создания
+func (oms *usecase) PreviewOrder(ctx context.Context, userID models.UserID, info PreviewOrderInfo) (*OrderPreview, error) {
+	const api = "orders_management_system.usecase.PreviewOrder"
+
+	items, totals, err := oms.priceOrder(ctx, info.Items, info.DeliveryVariantID)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	if info.PromoCode != "" {
+		totals, err = oms.promoTotals(ctx, oms.PromoStorage.GetPromoCode, userID, info.PromoCode, items, totals.Delivery)
+		if err != nil {
+			return nil, pkgerrors.Wrap(api, err)
+		}
+	}
+
+	return &OrderPreview{
+		Items:  items,
+		Totals: totals,
+	}, nil
+}
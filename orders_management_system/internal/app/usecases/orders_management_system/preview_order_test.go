@@ -0,0 +1,111 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_usecase_PreviewOrder(t *testing.T) {
+	var (
+		ctx   = context.Background() // dummy
+		rub   = func(amount int64) models.Money { return models.NewMoney(amount, models.CurrencyRUB) }
+		items = []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3}}
+	)
+	type fields struct {
+		Pricing      *mocks.Pricing
+		PromoStorage *mocks.PromoStorage
+	}
+
+	tests := []struct {
+		name    string
+		info    PreviewOrderInfo
+		want    *OrderPreview
+		wantErr error
+
+		on func(*fields)
+	}{
+		{
+			name: "Test 1. Positive. Without promo code.",
+			info: PreviewOrderInfo{Items: items, DeliveryVariantID: 1},
+			want: &OrderPreview{
+				Items:  []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, Price: rub(1000)}},
+				Totals: models.OrderTotals{Subtotal: rub(3000), Delivery: rub(300), Total: rub(3300)},
+			},
+		},
+		{
+			name: "Test 2. Positive. Free delivery promo code.",
+			info: PreviewOrderInfo{Items: items, DeliveryVariantID: 1, PromoCode: "FREESHIP"},
+			want: &OrderPreview{
+				Items:  []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, Price: rub(1000)}},
+				Totals: models.OrderTotals{Subtotal: rub(3000), Discount: rub(300), Delivery: rub(300), Total: rub(3000)},
+			},
+
+			on: func(f *fields) {
+				f.PromoStorage.On("GetPromoCode", ctx, "FREESHIP").
+					Return(&models.PromoCode{Code: "FREESHIP", Rule: models.DiscountRule{Type: models.DiscountFreeDelivery}, Active: true}, nil)
+				f.PromoStorage.On("CountUserRedemptions", ctx, "FREESHIP", models.UserID(1)).
+					Return(uint32(0), nil)
+			},
+		},
+		{
+			name:    "Test 3. Negative. Unknown promo code.",
+			info:    PreviewOrderInfo{Items: items, DeliveryVariantID: 1, PromoCode: "NOPE"},
+			wantErr: models.ErrInvalidPromoCode,
+
+			on: func(f *fields) {
+				f.PromoStorage.On("GetPromoCode", ctx, "NOPE").
+					Return(nil, models.ErrNotFound)
+			},
+		},
+		{
+			name:    "Test 4. Negative. Global limit reached.",
+			info:    PreviewOrderInfo{Items: items, DeliveryVariantID: 1, PromoCode: "SALE"},
+			wantErr: models.ErrPromoCodeExhausted,
+
+			on: func(f *fields) {
+				f.PromoStorage.On("GetPromoCode", ctx, "SALE").
+					Return(&models.PromoCode{Code: "SALE", GlobalLimit: 100, Redemptions: 100, Active: true}, nil)
+				f.PromoStorage.On("CountUserRedemptions", ctx, "SALE", models.UserID(1)).
+					Return(uint32(0), nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				Pricing:      mocks.NewPricing(t),
+				PromoStorage: mocks.NewPromoStorage(t),
+			}
+			f.Pricing.On("GetPrices", ctx, []models.SKUID{2}).
+				Return(map[models.SKUID]models.Money{2: rub(1000)}, nil)
+			f.Pricing.On("GetDeliveryPrice", ctx, models.DeliveryVariantID(1)).
+				Return(rub(300), nil)
+			if tt.on != nil {
+				tt.on(f)
+			}
+			oms := &usecase{
+				Deps: Deps{
+					Pricing:      f.Pricing,
+					PromoStorage: f.PromoStorage,
+				},
+			}
+
+			// act
+			got, err := oms.PreviewOrder(ctx, 1, tt.info)
+
+			// assert
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("usecase.PreviewOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package orders_management_system
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
+
+// priceOrder - снимок цен на момент заказа: цена за единицу в каждой позиции и стоимость заказа.
+// Дальнейшие изменения цен в Pricing на заказ не влияют
+func (oms *usecase) priceOrder(
+	ctx context.Context,
+	items []models.Item,
+	deliveryVariantID models.DeliveryVariantID,
+) ([]models.Item, models.OrderTotals, error) {
+	prices, err := oms.Pricing.GetPrices(ctx, models.UniqueSKUs(items))
+	if err != nil {
+		return nil, models.OrderTotals{}, err
+	}
+
+	priced := make([]models.Item, len(items))
+	for i, item := range items {
+		price, ok := prices[item.SKU.ID]
+		if !ok {
+			return nil, models.OrderTotals{}, fmt.Errorf("%w: sku %d", models.ErrPriceNotFound, item.SKU.ID)
+		}
+		item.Price = price
+		priced[i] = item
+	}
+
+	delivery, err := oms.Pricing.GetDeliveryPrice(ctx, deliveryVariantID)
+	if err != nil {
+		return nil, models.OrderTotals{}, err
+	}
+
+	// Скидок пока нет
+	totals, err := models.CalculateTotals(priced, delivery, models.Money{})
+	if err != nil {
+		return nil, models.OrderTotals{}, err
+	}
+
+	return priced, totals, nil
+}
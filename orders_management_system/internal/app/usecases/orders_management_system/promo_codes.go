@@ -0,0 +1,45 @@
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+)
+
+// promoTotals - стоимость заказа со скидкой по промокоду code.
+// Промокод читается через getPromoCode: PromoStorage.GetPromoCode для предпросмотра,
+// PromoStorage.LockPromoCode в транзакции создания заказа (лимиты проверяются под блокировкой)
+func (oms *usecase) promoTotals(
+	ctx context.Context,
+	getPromoCode func(ctx context.Context, code string) (*models.PromoCode, error),
+	userID models.UserID,
+	code string,
+	items []models.Item,
+	delivery models.Money,
+) (models.OrderTotals, error) {
+	promo, err := getPromoCode(ctx, code)
+	if errors.Is(err, models.ErrNotFound) {
+		return models.OrderTotals{}, fmt.Errorf("%w: %q not found", models.ErrInvalidPromoCode, code)
+	}
+	if err != nil {
+		return models.OrderTotals{}, err
+	}
+
+	used, err := oms.PromoStorage.CountUserRedemptions(ctx, promo.Code, userID)
+	if err != nil {
+		return models.OrderTotals{}, err
+	}
+	if err := promo.Check(used, time.Now().UTC()); err != nil {
+		return models.OrderTotals{}, err
+	}
+
+	discount, err := promo.Rule.Discount(items, delivery)
+	if err != nil {
+		return models.OrderTotals{}, err
+	}
+
+	return models.CalculateTotals(items, delivery, discount)
+}
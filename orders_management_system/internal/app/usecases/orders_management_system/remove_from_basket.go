@@ -0,0 +1,40 @@
+package orders_management_system
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// RemoveFromBasket - удаление товара из корзины (удаление отсутствующего товара - не ошибка)
+func (oms *usecase) RemoveFromBasket(ctx context.Context, userID models.UserID, skuID models.SKUID) (*models.Basket, error) {
+	const api = "orders_management_system.usecase.RemoveFromBasket"
+
+	basket := &models.Basket{UserID: userID}
+	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error {
+		items := []models.Item{{SKU: models.SKU{ID: skuID}}}
+		if err := oms.CheckoutStorage.DeleteItems(txCtx, userID, items); err != nil {
+			return err
+		}
+
+		basketItems, err := oms.CheckoutStorage.GetItems(txCtx, userID)
+		if err != nil {
+			return err
+		}
+
+		basket.Items = basketItems
+		return nil
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return basket, nil
+}
@@ -0,0 +1,71 @@
+package orders_management_system
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+)
+
+// RequestReturn - заявка на возврат
+func (oms *usecase) RequestReturn(ctx context.Context, orderID models.OrderID, info RequestReturnInfo) (*models.Return, error) {
+	const api = "orders_management_system.usecase.RequestReturn"
+
+	var ret *models.Return
+	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		// Блокируем заказ: параллельные заявки не должны вернуть больше, чем было заказано
+		order, err := oms.OrdersStorage.LockOrder(txCtx, orderID)
+		if err != nil {
+			return err
+		}
+		if order.Status != models.OrderStatusDelivered {
+			return fmt.Errorf("%w: order is %s, not delivered", models.ErrInvalidReturn, order.Status)
+		}
+
+		returns, err := oms.ReturnsStorage.ListOrderReturns(txCtx, orderID)
+		if err != nil {
+			return err
+		}
+		items, err := models.ReturnItems(order, returns, info.Items)
+		if err != nil {
+			return err
+		}
+		refund, err := models.RefundAmount(order.Totals, items)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		ret = &models.Return{
+			ID:        models.ReturnID(uuid.New()),
+			OrderID:   order.ID,
+			UserID:    order.UserID,
+			Items:     items,
+			Reason:    info.Reason,
+			Refund:    refund,
+			Status:    models.ReturnStatusRequested,
+			CreatedAt: now,
+			UpdatedAt: now,
+		}
+		if err := oms.ReturnsStorage.CreateReturn(txCtx, ret); err != nil {
+			return err
+		}
+
+		// Публикуем событие в outbox табличке, которое будет обработоно асинхронно позже
+		return oms.appendOrderEvent(txCtx, models.NewOrderReturnRequestedEvent(order, *ret))
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return nil, pkgerrors.Wrap(api, err)
+	}
+
+	return ret, nil
+}
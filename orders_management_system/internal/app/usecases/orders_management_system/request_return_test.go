@@ -0,0 +1,150 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_RequestReturn(t *testing.T) {
+	var (
+		ctx     = context.Background() // dummy
+		orderID = models.OrderID(uuid.New())
+		rub     = func(amount int64) models.Money { return models.NewMoney(amount, models.CurrencyRUB) }
+		order   = func(status models.OrderStatus) *models.Order {
+			return &models.Order{
+				ID:     orderID,
+				UserID: 1,
+				Items: []models.Item{
+					{SKU: models.SKU{ID: 1}, Quantity: 2, WarehouseID: 10, Price: rub(1000)},
+					{SKU: models.SKU{ID: 2}, Quantity: 1, WarehouseID: 20, Price: rub(550)},
+				},
+				Totals: models.OrderTotals{Subtotal: rub(2550), Delivery: rub(300), Total: rub(2850)},
+				Status: status,
+			}
+		}
+		info = RequestReturnInfo{
+			Items:  []models.Item{{SKU: models.SKU{ID: 1}, Quantity: 1}},
+			Reason: "broken",
+		}
+	)
+	type fields struct {
+		TransactionManager *mocks.TransactionManager
+		ReturnsStorage     *mocks.ReturnsStorage
+		OrdersStorage      *mocks.OrdersStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	tests := []struct {
+		name    string
+		want    *models.Return
+		wantErr error
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name: "Test 1. Positive. Refund by price snapshot.",
+			want: &models.Return{
+				OrderID: orderID,
+				UserID:  1,
+				Items:   []models.Item{{SKU: models.SKU{ID: 1}, Quantity: 1, WarehouseID: 10, Price: rub(1000)}},
+				Reason:  "broken",
+				Refund:  rub(1000),
+				Status:  models.ReturnStatusRequested,
+			},
+			on: func(f *fields) {
+				f.OrdersStorage.On("LockOrder", ctx, orderID).Return(order(models.OrderStatusDelivered), nil)
+				f.ReturnsStorage.On("ListOrderReturns", ctx, orderID).Return(nil, nil)
+				f.ReturnsStorage.On("CreateReturn", ctx, mock.Anything).Return(nil)
+				f.OrdersStorage.On("AppendOrderEvent", ctx, mock.MatchedBy(func(event models.OrderEvent) bool {
+					return event.Type == models.OrderEventReturnRequested && event.Return.Refund == rub(1000)
+				})).Return(nil)
+			},
+		},
+		{
+			name:    "Test 2. Negative. Order is not delivered.",
+			wantErr: models.ErrInvalidReturn,
+			on: func(f *fields) {
+				f.OrdersStorage.On("LockOrder", ctx, orderID).Return(order(models.OrderStatusShipped), nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.ReturnsStorage.AssertNumberOfCalls(t, "CreateReturn", 0)
+			},
+		},
+		{
+			name:    "Test 3. Negative. Already returned.",
+			wantErr: models.ErrInvalidReturn,
+			on: func(f *fields) {
+				f.OrdersStorage.On("LockOrder", ctx, orderID).Return(order(models.OrderStatusDelivered), nil)
+				f.ReturnsStorage.On("ListOrderReturns", ctx, orderID).Return([]*models.Return{{
+					Status: models.ReturnStatusApproved,
+					Items:  []models.Item{{SKU: models.SKU{ID: 1}, Quantity: 2, WarehouseID: 10}},
+				}}, nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.ReturnsStorage.AssertNumberOfCalls(t, "CreateReturn", 0)
+			},
+		},
+		{
+			name:    "Test 4. Negative. Order not found.",
+			wantErr: models.ErrNotFound,
+			on: func(f *fields) {
+				f.OrdersStorage.On("LockOrder", ctx, orderID).Return(nil, models.ErrNotFound)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager: mocks.NewTransactionManager(t),
+				ReturnsStorage:     mocks.NewReturnsStorage(t),
+				OrdersStorage:      mocks.NewOrdersStorage(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction)
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager: f.TransactionManager,
+					ReturnsStorage:     f.ReturnsStorage,
+					OrdersStorage:      f.OrdersStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			got, err := oms.RequestReturn(ctx, orderID, info)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+				assert.Nil(t, got)
+			} else {
+				assert.NoError(t, err)
+				// ID и время генерируются внутри
+				tt.want.ID, tt.want.CreatedAt, tt.want.UpdatedAt = got.ID, got.CreatedAt, got.UpdatedAt
+				assert.Equal(t, tt.want, got)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
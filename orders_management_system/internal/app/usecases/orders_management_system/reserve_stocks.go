@@ -0,0 +1,121 @@
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+	"golang.org/x/sync/errgroup"
+)
+
+// maxParallelReservations - сколько складов резервируем одновременно
+const maxParallelReservations = 4
+
+// reserveStocks - резерв стоков заказа: отдельный запрос в WMS на каждый склад,
+// не больше maxParallelReservations одновременно. Если хотя бы один склад не зарезервировал -
+// резервы остальных снимаются и возвращается *models.ReservationError
+func (oms *usecase) reserveStocks(
+	ctx context.Context,
+	reservationID models.ReservationID,
+	userID models.UserID,
+	items []models.Item,
+) error {
+	warehouses, groups := models.GroupItemsByWarehouse(items)
+
+	var (
+		mu       sync.Mutex
+		reserved []models.WarehouseID
+		failures []models.ReservationFailure
+	)
+	oms.forEachWarehouse(warehouses, func(warehouseID models.WarehouseID) {
+		err := oms.WarehouseManagementSystem.ReserveStocks(ctx, reservationID.ForWarehouse(warehouseID), userID, groups[warehouseID])
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failures = append(failures, models.ReservationFailure{
+				WarehouseID: warehouseID,
+				SKUs:        models.UniqueSKUs(groups[warehouseID]),
+				Err:         err,
+			})
+			return
+		}
+		reserved = append(reserved, warehouseID)
+	})
+	if len(failures) == 0 {
+		return nil
+	}
+
+	// Откатываем успешные резервы. Снятие идемпотентно: если не получилось -
+	// резерв снимет компенсация саги при восстановлении
+	if err := oms.releaseWarehouses(ctx, reservationID, reserved); err != nil {
+		logger.ErrorKV(ctx, "failed to roll back stock reservation", "reservation_id", reservationID.String(), "error", err.Error())
+	}
+
+	// порядок складов как в заказе
+	order := make(map[models.WarehouseID]int, len(warehouses))
+	for i, warehouseID := range warehouses {
+		order[warehouseID] = i
+	}
+	slices.SortFunc(failures, func(a, b models.ReservationFailure) int {
+		return order[a.WarehouseID] - order[b.WarehouseID]
+	})
+
+	return &models.ReservationError{Failures: failures}
+}
+
+// releaseStocks - снятие резерва заказа на всех его складах
+func (oms *usecase) releaseStocks(ctx context.Context, reservationID models.ReservationID, items []models.Item) error {
+	warehouses, _ := models.GroupItemsByWarehouse(items)
+	return oms.releaseWarehouses(ctx, reservationID, warehouses)
+}
+
+// releaseWarehouses - снятие резерва на складах warehouses
+func (oms *usecase) releaseWarehouses(ctx context.Context, reservationID models.ReservationID, warehouses []models.WarehouseID) error {
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	oms.forEachWarehouse(warehouses, func(warehouseID models.WarehouseID) {
+		if err := oms.WarehouseManagementSystem.ReleaseReservation(ctx, reservationID.ForWarehouse(warehouseID)); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// confirmStocks - подтверждение резерва заказа на всех его складах (заказ оплачен)
+func (oms *usecase) confirmStocks(ctx context.Context, reservationID models.ReservationID, items []models.Item) error {
+	warehouses, _ := models.GroupItemsByWarehouse(items)
+
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	oms.forEachWarehouse(warehouses, func(warehouseID models.WarehouseID) {
+		if err := oms.WarehouseManagementSystem.ConfirmReservation(ctx, reservationID.ForWarehouse(warehouseID)); err != nil {
+			mu.Lock()
+			errs = append(errs, err)
+			mu.Unlock()
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// forEachWarehouse - выполняет fn по каждому складу, не больше maxParallelReservations одновременно
+func (oms *usecase) forEachWarehouse(warehouses []models.WarehouseID, fn func(warehouseID models.WarehouseID)) {
+	var g errgroup.Group
+	g.SetLimit(maxParallelReservations)
+	for _, warehouseID := range warehouses {
+		g.Go(func() error {
+			fn(warehouseID)
+			return nil
+		})
+	}
+	_ = g.Wait()
+}
@@ -0,0 +1,128 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_usecase_reserveStocks(t *testing.T) {
+	var (
+		ctx           = context.Background() // dummy
+		reservationID = models.ReservationID(uuid.New())
+		items         = []models.Item{
+			{SKU: models.SKU{ID: 1}, Quantity: 1, WarehouseID: 10},
+			{SKU: models.SKU{ID: 2}, Quantity: 2, WarehouseID: 20},
+			{SKU: models.SKU{ID: 3}, Quantity: 3, WarehouseID: 10},
+			{SKU: models.SKU{ID: 4}, Quantity: 4, WarehouseID: 30},
+		}
+		warehouse10 = []models.Item{items[0], items[2]}
+		warehouse20 = []models.Item{items[1]}
+		warehouse30 = []models.Item{items[3]}
+	)
+	type fields struct {
+		WarehouseManagementSystem *mocks.WarehouseManagementSystem
+	}
+
+	tests := []struct {
+		name         string
+		wantFailures []models.ReservationFailure
+		wantErr      error
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name: "Test 1. Positive. Each warehouse is reserved separately.",
+			on: func(f *fields) {
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, reservationID.ForWarehouse(10), models.UserID(1), warehouse10).Return(nil)
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, reservationID.ForWarehouse(20), models.UserID(1), warehouse20).Return(nil)
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, reservationID.ForWarehouse(30), models.UserID(1), warehouse30).Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReleaseReservation", 0)
+			},
+		},
+		{
+			name: "Test 2. Negative. Successful warehouses are rolled back.",
+			wantFailures: []models.ReservationFailure{
+				{WarehouseID: 10, SKUs: []models.SKUID{1, 3}, Err: models.ErrOutOfStock},
+				{WarehouseID: 30, SKUs: []models.SKUID{4}, Err: models.ErrUnknownWarehouse},
+			},
+			wantErr: models.ErrOutOfStock,
+			on: func(f *fields) {
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, reservationID.ForWarehouse(10), models.UserID(1), warehouse10).Return(models.ErrOutOfStock)
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, reservationID.ForWarehouse(20), models.UserID(1), warehouse20).Return(nil)
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, reservationID.ForWarehouse(30), models.UserID(1), warehouse30).Return(models.ErrUnknownWarehouse)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, reservationID.ForWarehouse(20)).Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.WarehouseManagementSystem.AssertNumberOfCalls(t, "ReleaseReservation", 1)
+			},
+		},
+		{
+			name: "Test 3. Negative. Failed rollback still returns reservation error.",
+			wantFailures: []models.ReservationFailure{
+				{WarehouseID: 20, SKUs: []models.SKUID{2}, Err: models.ErrServiceUnavailable},
+			},
+			wantErr: models.ErrServiceUnavailable,
+			on: func(f *fields) {
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, reservationID.ForWarehouse(10), models.UserID(1), warehouse10).Return(nil)
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, reservationID.ForWarehouse(20), models.UserID(1), warehouse20).Return(models.ErrServiceUnavailable)
+				f.WarehouseManagementSystem.On("ReserveStocks", ctx, reservationID.ForWarehouse(30), models.UserID(1), warehouse30).Return(nil)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, reservationID.ForWarehouse(10)).Return(models.ErrServiceUnavailable)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, reservationID.ForWarehouse(30)).Return(nil)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				WarehouseManagementSystem: mocks.NewWarehouseManagementSystem(t),
+			}
+			oms := &usecase{
+				Deps: Deps{
+					WarehouseManagementSystem: f.WarehouseManagementSystem,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			err := oms.reserveStocks(ctx, reservationID, 1, items)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr), "got error: %v", err)
+
+				var reservationErr *models.ReservationError
+				require.True(t, errors.As(err, &reservationErr))
+				assert.Equal(t, tt.wantFailures, reservationErr.Failures)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
+
+func TestReservationID_ForWarehouse(t *testing.T) {
+	reservationID := models.ReservationID(uuid.New())
+
+	assert.Equal(t, reservationID.ForWarehouse(1), reservationID.ForWarehouse(1))
+	assert.NotEqual(t, reservationID.ForWarehouse(1), reservationID.ForWarehouse(2))
+	assert.NotEqual(t, reservationID, reservationID.ForWarehouse(1))
+}
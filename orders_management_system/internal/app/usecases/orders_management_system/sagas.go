@@ -0,0 +1,110 @@
+package orders_management_system
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/workflow"
+)
+
+// Саги (workflow с компенсациями) и их шаги. Имена сохраняются в БД - не переименовывать!
+const (
+	createOrderSagaName = "create_order"
+
+	stepReserveStocks = "reserve_stocks"
+	stepCreateOrder   = "create_order"
+)
+
+// createOrderSagaPayload - данные саги создания заказа, из которых восстанавливаются шаги
+type createOrderSagaPayload struct {
+	OrderID       uuid.UUID     `json:"order_id"`
+	ReservationID uuid.UUID     `json:"reservation_id"`
+	UserID        models.UserID `json:"user_id"`
+	Items         []models.Item `json:"items"`
+}
+
+// newCreateOrderSaga - сага создания заказа: резерв стоков в WMS -> транзакция в БД (createOrder).
+// Если транзакция упала - резерв снимается. Транзакция сама завершает сагу (AddAtomic), поэтому резерв
+// созданного заказа не снимается никогда. createOrder может быть nil при восстановлении саги
+func (oms *usecase) newCreateOrderSaga(p createOrderSagaPayload, createOrder workflow.AtomicFunc) (*workflow.Workflow, error) {
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+
+	saga := workflow.NewWorkflow(createOrderSagaName, payload,
+		workflow.WithID(p.OrderID),
+		workflow.WithLog(oms.SagaStorage),
+	).
+		Add(stepReserveStocks,
+			func(ctx context.Context) error {
+				return oms.reserveStocks(ctx, models.ReservationID(p.ReservationID), p.UserID, p.Items)
+			},
+			func(ctx context.Context) error {
+				return oms.releaseStocks(ctx, models.ReservationID(p.ReservationID), p.Items)
+			},
+		).
+		AddAtomic(stepCreateOrder, createOrder, nil) // транзакция атомарна - откатывать нечего
+
+	return saga, nil
+}
+
+// restoreSaga - восстанавливает шаги саги по сохраненному состоянию
+func (oms *usecase) restoreSaga(state workflow.State) (*workflow.Workflow, error) {
+	switch state.Name {
+	case createOrderSagaName:
+		var p createOrderSagaPayload
+		if err := json.Unmarshal(state.Payload, &p); err != nil {
+			return nil, err
+		}
+		return oms.newCreateOrderSaga(p, nil)
+	default:
+		return nil, fmt.Errorf("unknown saga %q", state.Name)
+	}
+}
+
+// RecoverSagas - компенсация саг, прерванных падением процесса
+func (oms *usecase) RecoverSagas(ctx context.Context, updatedBefore time.Time, limit uint64) (int, error) {
+	const api = "orders_management_system.usecase.RecoverSagas"
+
+	var recovered int
+	err := oms.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		states, err := oms.SagaStorage.ClaimStuckSagas(txCtx, updatedBefore, limit)
+		if err != nil {
+			return err
+		}
+
+		for _, state := range states {
+			saga, err := oms.restoreSaga(state)
+			if err == nil {
+				// прогресс компенсации сохраняется в этой же транзакции
+				err = saga.Resume(txCtx, state)
+			}
+			if err != nil {
+				// не получилось - попробуем в следующий раз
+				logger.ErrorKV(ctx, "failed to recover saga", "saga_id", state.ID.String(), "saga", state.Name, "error", err.Error())
+				continue
+			}
+			recovered++
+		}
+
+		return nil
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return 0, pkgerrors.Wrap(api, err)
+	}
+
+	return recovered, nil
+}
@@ -0,0 +1,134 @@
+//go:build test
+
+package orders_management_system
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system/mocks"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/workflow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_usecase_RecoverSagas(t *testing.T) {
+	var (
+		ctx           = context.Background() // dummy
+		before        = time.Now()
+		reservationID = uuid.New()
+		items         = []models.Item{{SKU: models.SKU{ID: 2}, Quantity: 3, WarehouseID: 4}}
+	)
+	type fields struct {
+		TransactionManager        *mocks.TransactionManager
+		WarehouseManagementSystem *mocks.WarehouseManagementSystem
+		SagaStorage               *mocks.SagaStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	newState := func(completedSteps ...string) workflow.State {
+		payload, _ := json.Marshal(createOrderSagaPayload{OrderID: uuid.New(), ReservationID: reservationID, UserID: 1, Items: items})
+		return workflow.State{
+			ID:             uuid.New(),
+			Name:           createOrderSagaName,
+			Payload:        payload,
+			Status:         workflow.StatusRunning,
+			CompletedSteps: completedSteps,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+
+		on     func(*fields)
+		assert func(*testing.T, *fields)
+	}{
+		{
+			name: "Test 1. Positive. Reserved stocks of interrupted saga are released.",
+			want: 1,
+			on: func(f *fields) {
+				f.SagaStorage.On("ClaimStuckSagas", ctx, before, uint64(10)).
+					Return([]workflow.State{newState(stepReserveStocks)}, nil)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, models.ReservationID(reservationID).ForWarehouse(4)).Return(nil)
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.SagaStorage.AssertCalled(t, "SaveWorkflowState", ctx, mock.MatchedBy(func(state workflow.State) bool {
+					return state.Status == workflow.StatusCompensated && len(state.CompletedSteps) == 0
+				}))
+			},
+		},
+		{
+			name: "Test 2. Negative. Failed compensation is left for the next run.",
+			want: 1,
+			on: func(f *fields) {
+				f.SagaStorage.On("ClaimStuckSagas", ctx, before, uint64(10)).
+					Return([]workflow.State{newState(stepReserveStocks), newState()}, nil)
+				f.WarehouseManagementSystem.On("ReleaseReservation", ctx, models.ReservationID(reservationID).ForWarehouse(4)).Return(errors.New("wms is down"))
+			},
+			assert: func(t *testing.T, f *fields) {
+				f.SagaStorage.AssertCalled(t, "SaveWorkflowState", ctx, mock.MatchedBy(func(state workflow.State) bool {
+					return state.Status == workflow.StatusCompensating && len(state.CompletedSteps) == 1
+				}))
+			},
+		},
+		{
+			name:    "Test 3. Negative. Claim failed.",
+			wantErr: true,
+			on: func(f *fields) {
+				f.SagaStorage.On("ClaimStuckSagas", ctx, before, uint64(10)).Return(nil, errors.New("db is down"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager:        mocks.NewTransactionManager(t),
+				WarehouseManagementSystem: mocks.NewWarehouseManagementSystem(t),
+				SagaStorage:               mocks.NewSagaStorage(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction)
+			f.SagaStorage.On("SaveWorkflowState", ctx, mock.Anything).
+				Return(nil).
+				Maybe()
+			oms := &usecase{
+				Deps: Deps{
+					TransactionManager:        f.TransactionManager,
+					WarehouseManagementSystem: f.WarehouseManagementSystem,
+					SagaStorage:               f.SagaStorage,
+				},
+			}
+			if tt.on != nil {
+				tt.on(f)
+			}
+
+			// act
+			got, err := oms.RecoverSagas(ctx, before, 10)
+
+			// assert
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, got)
+
+			if tt.assert != nil {
+				tt.assert(t, f)
+			}
+		})
+	}
+}
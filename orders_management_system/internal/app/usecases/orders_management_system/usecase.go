@@ -3,9 +3,11 @@ package orders_management_system
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/workflow"
 )
 
 var (
@@ -17,8 +19,102 @@ var (
 type UsecaseInterface interface {
 	// CreateOrder - создание заказа
 	//
-	// @errors: ErrReserveStocks
+	// Повторный вызов с тем же info.IdempotencyKey возвращает ранее созданный заказ.
+	// Ошибка резерва - *models.ReservationError со складами и SKU, которые не удалось зарезервировать.
+	//
+	// @errors: models.ErrOutOfStock, models.ErrUnknownWarehouse, models.ErrServiceUnavailable, models.ErrIdempotencyKeyReused,
+	// models.ErrPriceNotFound, models.ErrCurrencyMismatch, models.ErrInvalidPromoCode, models.ErrPromoCodeExhausted
 	CreateOrder(ctx context.Context, userID models.UserID, info CreateOrderInfo) (*models.Order, error)
+	// GetOrder - получение заказа
+	//
+	// @errors: models.ErrNotFound
+	GetOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error)
+	// ListOrders - список заказов пользователя (постранично)
+	//
+	// @errors: models.ErrInvalidArgument
+	ListOrders(ctx context.Context, userID models.UserID, filter ListOrdersFilter) (*ListOrdersResult, error)
+	// CancelOrder - отмена заказа по причине reason. Незавершенный платеж отменяется, списанные деньги возвращаются
+	//
+	// @errors: models.ErrNotFound, models.ErrInvalidStatusTransition, models.ErrServiceUnavailable
+	CancelOrder(ctx context.Context, orderID models.OrderID, reason string) (*models.Order, error)
+	// CancelOrderItems - отмена части товаров заказа: items - SKU и количество (склад - только с этого склада).
+	// Резерв на складах уменьшается, стоимость пересчитывается, за оплаченный заказ разница возвращается.
+	// Отменить можно до отгрузки и не во время оплаты; отменить все товары нельзя - для этого есть CancelOrder.
+	// Исходный состав заказа и изменения сохраняются в истории позиций
+	//
+	// @errors: models.ErrNotFound, models.ErrInvalidItemsCancellation, models.ErrServiceUnavailable
+	CancelOrderItems(ctx context.Context, orderID models.OrderID, items []models.Item) (*CancelOrderItemsResult, error)
+	// GetOrderTimeline - журнал событий заказа: что, когда, кем и почему изменено
+	//
+	// @errors: models.ErrNotFound
+	GetOrderTimeline(ctx context.Context, orderID models.OrderID) ([]models.OrderTimelineEntry, error)
+	// GetOrderAt - заказ в состоянии на момент at (для разбора споров): восстанавливается из журнала событий
+	//
+	// @errors: models.ErrNotFound - заказа еще не было или его состояние на момент at не восстанавливается
+	GetOrderAt(ctx context.Context, orderID models.OrderID, at time.Time) (*models.Order, error)
+	// GetOrderItemsHistory - история позиций заказа: исходный состав и все изменения
+	//
+	// @errors: models.ErrNotFound
+	GetOrderItemsHistory(ctx context.Context, orderID models.OrderID) ([]models.OrderItemChange, error)
+	// PayOrder - оплата заказа способом paymentMethod: заказ переходит в awaiting_payment,
+	// провайдеру отправляется запрос на авторизацию. Результат приходит в HandlePaymentCallback.
+	// Если провайдер не принял запрос - заказ сразу переходит в payment_failed.
+	// Повторно оплатить можно заказ в статусе payment_failed
+	//
+	// @errors: models.ErrNotFound, models.ErrInvalidStatusTransition, models.ErrServiceUnavailable
+	PayOrder(ctx context.Context, orderID models.OrderID, paymentMethod string) (*PayOrderResult, error)
+	// HandlePaymentCallback - результат авторизации платежа от провайдера: заказ переходит в paid
+	// (деньги списываются, резерв стоков подтверждается) или в payment_failed.
+	// Повторное уведомление по уже обработанному платежу ничего не меняет
+	//
+	// @errors: models.ErrNotFound, models.ErrServiceUnavailable
+	HandlePaymentCallback(ctx context.Context, callback models.PaymentCallback) error
+	// RequestReturn - заявка на возврат части товаров доставленного заказа.
+	// Сумма к возврату считается по ценам на момент заказа
+	//
+	// @errors: models.ErrNotFound, models.ErrInvalidReturn
+	RequestReturn(ctx context.Context, orderID models.OrderID, info RequestReturnInfo) (*models.Return, error)
+	// ApproveReturn - одобрение возврата: деньги возвращаются на платеж заказа,
+	// в outbox публикуется событие для возврата товаров на склад
+	//
+	// @errors: models.ErrNotFound, models.ErrInvalidStatusTransition, models.ErrInvalidReturn, models.ErrServiceUnavailable
+	ApproveReturn(ctx context.Context, returnID models.ReturnID) (*models.Return, error)
+	// AddToBasket - добавление товара в корзину (количество суммируется с уже лежащим)
+	//
+	// @errors: models.ErrInvalidArgument
+	AddToBasket(ctx context.Context, userID models.UserID, item models.Item) (*models.Basket, error)
+	// UpdateBasketItem - изменение количества и склада товара в корзине
+	//
+	// @errors: models.ErrInvalidArgument, models.ErrNotFound
+	UpdateBasketItem(ctx context.Context, userID models.UserID, item models.Item) (*models.Basket, error)
+	// RemoveFromBasket - удаление товара из корзины
+	RemoveFromBasket(ctx context.Context, userID models.UserID, skuID models.SKUID) (*models.Basket, error)
+	// GetBasket - корзина пользователя
+	GetBasket(ctx context.Context, userID models.UserID) (*models.Basket, error)
+	// CreateOrderFromBasket - создание заказа из содержимого корзины.
+	// Заказ создается, а корзина очищается атомарно.
+	//
+	// Повторный вызов с тем же info.IdempotencyKey возвращает ранее созданный заказ.
+	// Ошибка резерва - *models.ReservationError со складами и SKU, которые не удалось зарезервировать.
+	//
+	// @errors: models.ErrOutOfStock, models.ErrUnknownWarehouse, models.ErrServiceUnavailable,
+	// models.ErrInvalidArgument, models.ErrBasketChanged, models.ErrIdempotencyKeyReused,
+	// models.ErrPriceNotFound, models.ErrCurrencyMismatch, models.ErrInvalidPromoCode, models.ErrPromoCodeExhausted
+	CreateOrderFromBasket(ctx context.Context, userID models.UserID, info CreateOrderFromBasketInfo) (*models.Order, error)
+	// PreviewOrder - цены и стоимость заказа без его создания и резерва стоков.
+	// С info.PromoCode - со скидкой по промокоду (промокод при этом не погашается)
+	//
+	// @errors: models.ErrPriceNotFound, models.ErrCurrencyMismatch, models.ErrInvalidPromoCode, models.ErrPromoCodeExhausted
+	PreviewOrder(ctx context.Context, userID models.UserID, info PreviewOrderInfo) (*OrderPreview, error)
+	// ExpireReservations - отмена заказов, не оплаченных до expiredBefore (по времени создания),
+	// со снятием резерва. Возвращает количество отмененных заказов
+	ExpireReservations(ctx context.Context, expiredBefore time.Time, limit uint64) (int, error)
+	// RecoverSagas - компенсация саг, прерванных падением процесса (не обновлялись с updatedBefore).
+	// Возвращает количество восстановленных саг
+	RecoverSagas(ctx context.Context, updatedBefore time.Time, limit uint64) (int, error)
+	// CompleteCancellations - снятие резерва, отмена авторизации и возврат денег по отмененным заказам
+	// (после коммита отмены). Неудавшиеся отмены повторяются позже. Возвращает количество выполненных
+	CompleteCancellations(ctx context.Context, limit uint64) (int, error)
 }
 
 // Бизнес логика не зависит ни от чего кроме доменных моделей!
@@ -26,13 +122,177 @@ type UsecaseInterface interface {
 // Задаем контракт поведения для адаптеров (порты)
 
 //go:generate mockery --name=WarehouseManagementSystem --filename=warehouse_management_system_mock.go --disable-version-string
+//go:generate mockery --name=Inventory --filename=inventory_mock.go --disable-version-string
+//go:generate mockery --name=Pricing --filename=pricing_mock.go --disable-version-string
+//go:generate mockery --name=PromoStorage --filename=promo_storage_mock.go --disable-version-string
+//go:generate mockery --name=Payments --filename=payments_mock.go --disable-version-string
+//go:generate mockery --name=PaymentsStorage --filename=payments_storage_mock.go --disable-version-string
+//go:generate mockery --name=ReturnsStorage --filename=returns_storage_mock.go --disable-version-string
+//go:generate mockery --name=CancellationsStorage --filename=cancellations_storage_mock.go --disable-version-string
 //go:generate mockery --name=OrdersStorage --filename=orders_storage_mock.go --disable-version-string
+//go:generate mockery --name=CheckoutStorage --filename=checkout_storage_mock.go --disable-version-string
+//go:generate mockery --name=SagaStorage --filename=saga_storage_mock.go --disable-version-string
+//go:generate mockery --srcpkg=github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager --name=TransactionManager --filename=transaction_manager_mock.go --disable-version-string
 
 type (
-	// WarehouseManagementSystem - то что отвечает за резервирование товаров на складе
+	// WarehouseManagementSystem - то что отвечает за резервирование товаров на складе.
+	// Резерв двухфазный: ReserveStocks -> ConfirmReservation (заказ оплачен) или ReleaseReservation.
+	// Часть резерва возвращается на склад через ShrinkReservation
 	WarehouseManagementSystem interface {
-		// ReserveStocks - резервация стоков на складах
-		ReserveStocks(ctx context.Context, userID models.UserID, items []models.Item) error
+		// ReserveStocks - временный резерв стоков на складах (все или ничего).
+		// reservationID выбирает OMS: повтор с тем же id не создает второй резерв.
+		// OMS резервирует каждый склад заказа отдельным вызовом (см. models.ReservationID.ForWarehouse)
+		//
+		// @errors: models.ErrOutOfStock, models.ErrUnknownWarehouse, models.ErrServiceUnavailable
+		ReserveStocks(ctx context.Context, reservationID models.ReservationID, userID models.UserID, items []models.Item) error
+		// ConfirmReservation - подтверждение резерва: сток списывается под заказ
+		//
+		// @errors: models.ErrUnknownReservation, models.ErrServiceUnavailable
+		ConfirmReservation(ctx context.Context, reservationID models.ReservationID) error
+		// ReleaseReservation - снятие резерва (снятие несуществующего резерва - не ошибка)
+		//
+		// @errors: models.ErrServiceUnavailable
+		ReleaseReservation(ctx context.Context, reservationID models.ReservationID) error
+		// ShrinkReservation - уменьшение резерва (в том числе подтвержденного) до items, сток сверх items
+		// возвращается на склад. Идемпотентен: повтор с теми же items ничего не меняет
+		//
+		// @errors: models.ErrUnknownReservation, models.ErrUnknownWarehouse, models.ErrServiceUnavailable
+		ShrinkReservation(ctx context.Context, reservationID models.ReservationID, items []models.Item) error
+	}
+
+	// Inventory - доступные стоки на складах
+	Inventory interface {
+		// GetStocks - доступные стоки skus по складам и параметры отправки со склада
+		// для способа доставки deliveryVariantID
+		//
+		// @errors: models.ErrServiceUnavailable
+		GetStocks(ctx context.Context, skus []models.SKUID, deliveryVariantID models.DeliveryVariantID) ([]models.WarehouseStocks, error)
+	}
+
+	// AllocationStrategy - выбор складов для позиций заказа без склада (см. internal/app/allocation)
+	AllocationStrategy interface {
+		// Allocate - позиции с выбранными складами (позиция может разделиться на несколько складов)
+		//
+		// @errors: models.ErrOutOfStock
+		Allocate(items []models.Item, stocks []models.WarehouseStocks) ([]models.Item, error)
+	}
+
+	// Pricing - текущие цены товаров и доставки. В заказ цены попадают снимком на момент создания
+	Pricing interface {
+		// GetPrices - цены за единицу skus
+		//
+		// @errors: models.ErrPriceNotFound, models.ErrServiceUnavailable
+		GetPrices(ctx context.Context, skus []models.SKUID) (map[models.SKUID]models.Money, error)
+		// GetDeliveryPrice - стоимость способа доставки deliveryVariantID
+		//
+		// @errors: models.ErrPriceNotFound, models.ErrServiceUnavailable
+		GetDeliveryPrice(ctx context.Context, deliveryVariantID models.DeliveryVariantID) (models.Money, error)
+	}
+
+	// PromoStorage - промокоды и их применения
+	PromoStorage interface {
+		// GetPromoCode - промокод по коду
+		//
+		// @errors: models.ErrNotFound
+		//
+		// SELECT ... FROM promo_codes WHERE code = code;
+		GetPromoCode(ctx context.Context, code string) (*models.PromoCode, error)
+		// LockPromoCode - промокод с блокировкой до конца транзакции: применения одного промокода идут по очереди
+		//
+		// @errors: models.ErrNotFound
+		//
+		// SELECT ... FROM promo_codes WHERE code = code FOR UPDATE;
+		LockPromoCode(ctx context.Context, code string) (*models.PromoCode, error)
+		// CountUserRedemptions - сколько раз пользователь применил промокод
+		//
+		// SELECT count(*) FROM promo_redemptions WHERE code = code AND user_id = userID;
+		CountUserRedemptions(ctx context.Context, code string, userID models.UserID) (uint32, error)
+		// CreateRedemption - запись применения промокода к заказу с увеличением счетчика применений
+		//
+		// @errors: models.ErrAlreadyExists
+		//
+		// INSERT INTO promo_redemptions (...) VALUES (...);
+		// UPDATE promo_codes SET redemptions = redemptions + 1 WHERE code = redemption.Code;
+		CreateRedemption(ctx context.Context, redemption models.PromoRedemption) error
+		// DeleteRedemption - отмена применения промокода к заказу с уменьшением счетчика применений
+		// (отмена уже отмененного применения - не ошибка)
+		//
+		// DELETE FROM promo_redemptions WHERE order_id = orderID RETURNING code;
+		// UPDATE promo_codes SET redemptions = redemptions - 1 WHERE code = code;
+		DeleteRedemption(ctx context.Context, orderID models.OrderID) error
+	}
+
+	// Payments - платежный провайдер. Авторизация асинхронная: результат приходит уведомлением
+	// models.PaymentCallback. Все методы идемпотентны по платежу
+	Payments interface {
+		// Authorize - запрос на блокировку payment.Amount на счете покупателя способом payment.Method.
+		// payment.ID передается провайдеру и возвращается в уведомлении. Возвращает ID платежа у провайдера
+		//
+		// @errors: models.ErrInvalidArgument, models.ErrServiceUnavailable
+		Authorize(ctx context.Context, payment models.Payment) (string, error)
+		// Capture - списание заблокированных денег
+		//
+		// @errors: models.ErrNotFound, models.ErrServiceUnavailable
+		Capture(ctx context.Context, providerPaymentID string, amount models.Money) error
+		// Refund - возврат списанных денег. refundID - ключ идемпотентности: повтор с тем же ключом
+		// не возвращает деньги второй раз
+		//
+		// @errors: models.ErrNotFound, models.ErrInvalidArgument, models.ErrServiceUnavailable
+		Refund(ctx context.Context, providerPaymentID string, amount models.Money, refundID string) error
+		// Void - отмена авторизации (деньги разблокируются)
+		//
+		// @errors: models.ErrNotFound, models.ErrServiceUnavailable
+		Void(ctx context.Context, providerPaymentID string) error
+		// VerifyCallback - проверка подписи уведомления: уведомление действительно отправил провайдер
+		//
+		// @errors: models.ErrUnauthenticated
+		VerifyCallback(ctx context.Context, callback models.PaymentCallback) error
+	}
+
+	// PaymentsStorage - платежи по заказам
+	PaymentsStorage interface {
+		// CreatePayment - создание записи платежа
+		//
+		// @errors: models.ErrAlreadyExists - у заказа уже есть незавершенный платеж
+		//
+		// INSERT INTO payments (...) VALUES (...);
+		CreatePayment(ctx context.Context, payment *models.Payment) error
+		// LockPayment - платеж с блокировкой до конца транзакции: уведомления по платежу обрабатываются по очереди
+		//
+		// @errors: models.ErrNotFound
+		//
+		// SELECT ... FROM payments WHERE id = paymentID FOR UPDATE;
+		LockPayment(ctx context.Context, paymentID models.PaymentID) (*models.Payment, error)
+		// UpdatePayment - сохранение статуса, ID у провайдера и причины отказа
+		//
+		// UPDATE payments SET status = ..., refunded_amount = ..., provider_payment_id = ..., ... WHERE id = payment.ID;
+		UpdatePayment(ctx context.Context, payment *models.Payment) error
+		// ListOrderPayments - платежи заказа от старых к новым
+		//
+		// SELECT ... FROM payments WHERE order_id = orderID ORDER BY created_at;
+		ListOrderPayments(ctx context.Context, orderID models.OrderID) ([]*models.Payment, error)
+	}
+
+	// ReturnsStorage - заявки на возврат
+	ReturnsStorage interface {
+		// CreateReturn - создание заявки на возврат
+		//
+		// INSERT INTO returns (...) VALUES (...);
+		CreateReturn(ctx context.Context, r *models.Return) error
+		// LockReturn - заявка с блокировкой до конца транзакции
+		//
+		// @errors: models.ErrNotFound
+		//
+		// SELECT ... FROM returns WHERE id = returnID FOR UPDATE;
+		LockReturn(ctx context.Context, returnID models.ReturnID) (*models.Return, error)
+		// UpdateReturn - сохранение статуса заявки
+		//
+		// UPDATE returns SET status = ..., updated_at = ... WHERE id = r.ID;
+		UpdateReturn(ctx context.Context, r *models.Return) error
+		// ListOrderReturns - заявки на возврат по заказу от старых к новым
+		//
+		// SELECT ... FROM returns WHERE order_id = orderID ORDER BY created_at;
+		ListOrderReturns(ctx context.Context, orderID models.OrderID) ([]*models.Return, error)
 	}
 
 	// OrdersStorage - репозиторий сервиса OMS
@@ -42,28 +302,166 @@ type (
 		// @errors: models.ErrAlreadyExists
 		//
 		// INSERT INTO orders (...) VALUES (...);
+		// COPY order_items (...) FROM STDIN;
+		// INSERT INTO orders_items_history (...) VALUES (...); -- исходный состав заказа
 		CreateOrder(ctx context.Context, order *models.Order) error
-		// CreateOutboxMessage - запись в Outbox сообщения по заказу
+		// AppendOrderEvent - запись доменного события заказа в журнал событий и в outbox для публикации.
+		// Вызывается в транзакции вместе с изменением заказа: журнал, аудит и публикация - из одной записи.
+		// Событие - следующее в потоке заказа (event.StreamVersion): optimistic concurrency по версии потока
+		//
+		// @errors: models.ErrConcurrentModification - заказ изменили параллельно
+		//
+		// INSERT INTO order_events (id, order_id, type, stream_version, ..., payload, data) VALUES (...);
+		// INSERT INTO orders_outbox_messages (order_id, event_id) VALUES (...);
+		// UPDATE orders SET version = event.StreamVersion WHERE id = ... AND version = event.StreamVersion - 1;
+		AppendOrderEvent(ctx context.Context, event models.OrderEvent) error
+		// ListOrderEvents - журнал событий заказа от старых к новым
+		//
+		// SELECT ... FROM order_events WHERE order_id = orderID ORDER BY seq;
+		ListOrderEvents(ctx context.Context, orderID models.OrderID) ([]models.OrderTimelineEntry, error)
+		// GetOrderAt - заказ в состоянии на момент at: последний снимок не позже at и события потока после него
+		//
+		// @errors: models.ErrNotFound
+		//
+		// SELECT state FROM order_snapshots WHERE order_id = orderID AND occurred_at <= at ORDER BY version DESC LIMIT 1;
+		// SELECT ... FROM order_events WHERE order_id = orderID AND stream_version > snapshot.version ORDER BY stream_version;
+		GetOrderAt(ctx context.Context, orderID models.OrderID, at time.Time) (*models.Order, error)
+		// GetOrder - получение заказа по ID
+		//
+		// @errors: models.ErrNotFound
+		//
+		// SELECT ... FROM orders WHERE id = orderID;
+		// SELECT ... FROM order_items WHERE order_id = orderID ORDER BY line_no;
+		GetOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error)
+		// LockOrder - получение заказа с блокировкой до конца транзакции
+		//
+		// @errors: models.ErrNotFound
+		//
+		// SELECT ... FROM orders WHERE id = orderID FOR UPDATE;
+		LockOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error)
+		// ListOrders - список заказов пользователя от новых к старым
 		//
+		// SELECT ... FROM orders WHERE user_id = filter.UserID AND (created_at, id) < (...)
+		// ORDER BY created_at DESC, id DESC LIMIT filter.Limit;
+		ListOrders(ctx context.Context, filter models.OrdersFilter) ([]*models.Order, error)
+		// UpdateOrderStatus - смена статуса заказа с записью в историю статусов
 		//
-		CreateOutboxMessage(ctx context.Context, order *models.Order) error
+		// @errors: models.ErrInvalidStatusTransition - заказ уже не в статусе transition.From
+		//
+		// UPDATE orders SET status = transition.To WHERE id = transition.OrderID AND status = transition.From;
+		// INSERT INTO orders_status_history (...) VALUES (...);
+		UpdateOrderStatus(ctx context.Context, transition models.OrderStatusTransition) error
+		// UpdateOrderItems - сохранение состава и стоимости заказа с записью изменений в историю позиций
+		//
+		// @errors: models.ErrNotFound
+		//
+		// UPDATE orders SET subtotal_amount = ..., ..., total_amount = ... WHERE id = order.ID;
+		// DELETE FROM order_items WHERE order_id = order.ID; INSERT INTO order_items (...) VALUES (...); -- одним pgx.Batch
+		// INSERT INTO orders_items_history (...) VALUES (...);
+		UpdateOrderItems(ctx context.Context, order *models.Order, changes []models.OrderItemChange) error
+		// ListOrderItemChanges - история позиций заказа от старых к новым
+		//
+		// SELECT ... FROM orders_items_history WHERE order_id = orderID ORDER BY id;
+		ListOrderItemChanges(ctx context.Context, orderID models.OrderID) ([]models.OrderItemChange, error)
+		// ListUnpaidOrders - заказы, которые держат резерв стоков, но не оплачены и созданы раньше createdBefore.
+		// Заказы с неудачной попыткой отмены после attemptedBefore пропускаются, с более ранней - идут последними
+		//
+		// SELECT ... FROM orders LEFT JOIN order_expiry_attempts a ON a.order_id = orders.id
+		// WHERE status IN ('reserved', 'awaiting_payment', 'payment_failed') AND created_at < createdBefore
+		// AND (a.last_attempt_at IS NULL OR a.last_attempt_at < attemptedBefore)
+		// ORDER BY a.last_attempt_at NULLS FIRST, created_at LIMIT limit;
+		ListUnpaidOrders(ctx context.Context, createdBefore, attemptedBefore time.Time, limit uint64) ([]*models.Order, error)
+		// PostponeOrderExpiry - запись неудачной попытки отменить неоплаченный заказ
+		//
+		// INSERT INTO order_expiry_attempts (...) VALUES (...)
+		// ON CONFLICT (order_id) DO UPDATE SET attempts = attempts + 1, last_attempt_at = attemptedAt, ...;
+		PostponeOrderExpiry(ctx context.Context, orderID models.OrderID, attemptedAt time.Time, lastError string) error
+		// GetIdempotencyKey - получение ключа идемпотентности пользователя
+		//
+		// @errors: models.ErrNotFound
+		GetIdempotencyKey(ctx context.Context, userID models.UserID, key string) (*models.IdempotencyKey, error)
+		// CreateIdempotencyKey - сохранение ключа идемпотентности вместе с результатом запроса
+		//
+		// @errors: models.ErrAlreadyExists
+		CreateIdempotencyKey(ctx context.Context, key models.IdempotencyKey) error
 	}
 
-	// CheckoutStorage
+	// CheckoutStorage - корзина пользователя
 	CheckoutStorage interface {
+		// GetItems - содержимое корзины пользователя
+		//
+		// SELECT ... FROM basket WHERE user_id = userID ORDER BY created_at, sku_id;
+		GetItems(ctx context.Context, userID models.UserID) ([]models.BasketItem, error)
+		// LockItems - содержимое корзины пользователя с блокировкой до конца транзакции
+		//
+		// SELECT ... FROM basket WHERE user_id = userID ORDER BY created_at, sku_id FOR UPDATE;
+		LockItems(ctx context.Context, userID models.UserID) ([]models.BasketItem, error)
+		// AddItem - добавление товара в корзину (количество суммируется с уже лежащим)
+		//
+		// INSERT INTO basket (...) VALUES (...) ON CONFLICT (user_id, sku_id) DO UPDATE SET quantity = basket.quantity + EXCLUDED.quantity, ...;
+		AddItem(ctx context.Context, userID models.UserID, item models.Item) error
+		// UpdateItem - изменение количества и склада товара в корзине
+		//
+		// @errors: models.ErrNotFound - товара нет в корзине
+		//
+		// UPDATE basket SET quantity = ..., warehouse_id = ... WHERE user_id = userID AND sku_id = ...;
+		UpdateItem(ctx context.Context, userID models.UserID, item models.Item) error
 		// DeleteItems - удаление товара (ов) из корпзины пользовталея
 		//
-		// DELETE FROM basket WHERE user_id = userID AND item_id in (...)
+		// DELETE FROM basket WHERE user_id = userID AND sku_id IN (...)
 		DeleteItems(ctx context.Context, userID models.UserID, items []models.Item) error
 	}
+
+	// CancellationsStorage - внешние действия отмены заказов, ожидающие выполнения
+	CancellationsStorage interface {
+		// CreateOrderCancellation - запись действий отмены заказа (в транзакции отмены)
+		//
+		// INSERT INTO order_cancellations (order_id, payload, attempts, next_attempt_at, created_at) VALUES (...);
+		CreateOrderCancellation(ctx context.Context, cancellation models.OrderCancellation) error
+		// ClaimOrderCancellations - захват до конца транзакции отмен, время следующей попытки которых наступило
+		//
+		// SELECT ... FROM order_cancellations WHERE next_attempt_at <= now
+		// ORDER BY next_attempt_at LIMIT limit FOR UPDATE SKIP LOCKED;
+		ClaimOrderCancellations(ctx context.Context, now time.Time, limit uint64) ([]models.OrderCancellation, error)
+		// DeleteOrderCancellation - удаление выполненной отмены
+		//
+		// DELETE FROM order_cancellations WHERE order_id = orderID;
+		DeleteOrderCancellation(ctx context.Context, orderID models.OrderID) error
+		// PostponeOrderCancellation - перенос неудавшейся отмены на nextAttemptAt
+		//
+		// UPDATE order_cancellations SET attempts = attempts + 1, next_attempt_at = ..., last_error = ... WHERE order_id = orderID;
+		PostponeOrderCancellation(ctx context.Context, orderID models.OrderID, nextAttemptAt time.Time, lastError string) error
+	}
+
+	// SagaStorage - журнал прогресса саг
+	SagaStorage interface {
+		// SaveWorkflowState - сохранение прогресса саги (вне бизнес транзакции!)
+		//
+		// INSERT INTO orders_sagas (...) VALUES (...) ON CONFLICT (id) DO UPDATE SET ...;
+		SaveWorkflowState(ctx context.Context, state workflow.State) error
+		// ClaimStuckSagas - захват незавершенных саг, которые не обновлялись с updatedBefore
+		//
+		// SELECT ... FROM orders_sagas WHERE status IN ('running', 'compensating') AND updated_at < updatedBefore
+		// ORDER BY updated_at LIMIT limit FOR UPDATE SKIP LOCKED;
+		ClaimStuckSagas(ctx context.Context, updatedBefore time.Time, limit uint64) ([]workflow.State, error)
+	}
 )
 
 // Deps - зависимости нашего usecase
 type Deps struct {
 	transaction_manager.TransactionManager
 	WarehouseManagementSystem
+	Inventory
+	AllocationStrategy
+	Pricing
+	PromoStorage
+	Payments
+	PaymentsStorage
+	ReturnsStorage
+	CancellationsStorage
 	OrdersStorage
 	CheckoutStorage
+	SagaStorage
 }
 
 // usecase - реализация
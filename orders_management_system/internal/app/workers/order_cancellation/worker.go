@@ -0,0 +1,65 @@
+package order_cancellation
+
+import (
+	"context"
+	"time"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/worker"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+)
+
+// CancellationCompleter - то, что умеет выполнять внешние действия отмененных заказов (usecase)
+type CancellationCompleter interface {
+	// CompleteCancellations - снятие резерва, отмена авторизации и возврат денег по отмененным заказам
+	CompleteCancellations(ctx context.Context, limit uint64) (int, error)
+}
+
+// Config - настройки worker
+type Config struct {
+	BatchSize    uint64        // Сколько отмен обрабатывать за один проход
+	PollInterval time.Duration // Пауза между проходами
+}
+
+// Worker - фоновый процесс, доводящий отмены заказов до конца после коммита отмены. Start и Stop - от worker.Periodic
+type Worker struct {
+	*worker.Periodic
+
+	completer CancellationCompleter
+	cfg       Config
+}
+
+// New - returns *Worker
+func New(cfg Config, completer CancellationCompleter) *Worker {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+
+	w := &Worker{
+		completer: completer,
+		cfg:       cfg,
+	}
+	w.Periodic = worker.NewPeriodic("order cancellation", cfg.PollInterval, w.completeCancellations)
+
+	return w
+}
+
+// completeCancellations - один проход: внешние действия отмен, которым подошло время попытки
+func (w *Worker) completeCancellations(ctx context.Context) error {
+	completed, err := w.completer.CompleteCancellations(ctx, w.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+	if completed > 0 {
+		logger.InfoKV(ctx, "order cancellation: cancellations completed", "count", completed)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package orders_projection
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+)
+
+const (
+	// Name - имя проекции в order_projections
+	Name = "orders"
+
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+)
+
+//go:generate mockery --name=ProjectionStorage --filename=projection_storage_mock.go --disable-version-string
+//go:generate mockery --srcpkg=github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager --name=TransactionManager --filename=transaction_manager_mock.go --disable-version-string
+
+// ProjectionStorage - журнал событий заказов и read model заказов
+type ProjectionStorage interface {
+	// OrderEventsWatermark - позиция журнала, до которой все транзакции записи завершены
+	//
+	// SELECT pg_advisory_xact_lock(...); SELECT max(seq) FROM order_events;
+	OrderEventsWatermark(ctx context.Context) (int64, error)
+	// LockProjection - позиция проекции в журнале с блокировкой до конца транзакции
+	//
+	// SELECT last_seq FROM order_projections WHERE name = name FOR UPDATE;
+	LockProjection(ctx context.Context, name string) (int64, error)
+	// ListOrderEventsBetween - события журнала с позициями (afterSeq, toSeq]
+	//
+	// SELECT ... FROM order_events WHERE seq > afterSeq AND seq <= toSeq ORDER BY seq LIMIT limit;
+	ListOrderEventsBetween(ctx context.Context, afterSeq, toSeq int64, limit uint64) ([]models.OrderEvent, error)
+	// GetOrder - заказ из read model
+	//
+	// @errors: models.ErrNotFound
+	GetOrder(ctx context.Context, orderID models.OrderID) (*models.Order, error)
+	// SaveOrderProjection - сохранение заказа в read model, если его версия там все еще fromVersion
+	//
+	// @errors: models.ErrConcurrentModification
+	SaveOrderProjection(ctx context.Context, order *models.Order, fromVersion uint64) error
+	// UpdateProjection - сдвиг позиции проекции в журнале
+	//
+	// UPDATE order_projections SET last_seq = lastSeq WHERE name = name;
+	UpdateProjection(ctx context.Context, name string, lastSeq int64) error
+}
+
+// Config - настройки проекции
+type Config struct {
+	BatchSize    uint64        // Сколько событий применять за одну транзакцию
+	PollInterval time.Duration // Пауза между опросами, если новых событий нет
+}
+
+// Deps - зависимости проекции
+type Deps struct {
+	transaction_manager.TransactionManager
+	ProjectionStorage
+}
+
+// Projection - фоновый процесс, который ведет read model заказов (orders, order_items) по журналу событий
+// в режиме event sourcing. Применение событий идемпотентно: уже примененные версии пропускаются
+type Projection struct {
+	Deps
+	cfg Config
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New - returns *Projection
+func New(cfg Config, d Deps) *Projection {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+
+	return &Projection{
+		Deps: d,
+		cfg:  cfg,
+	}
+}
+
+// Start - запускает проекцию в фоне. Остановка через Stop (например из closer)
+func (p *Projection) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.run(ctx)
+	}()
+}
+
+// Stop - останавливает проекцию и дожидается завершения текущей пачки
+func (p *Projection) Stop(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return pkgerrors.Wrap("orders_projection.Stop", ctx.Err())
+	}
+}
+
+func (p *Projection) run(ctx context.Context) {
+	logger.Info(ctx, "orders projection started")
+	defer logger.Info(ctx, "orders projection stopped")
+
+	for {
+		processed, err := p.ProcessBatch(ctx)
+		if err != nil {
+			logger.ErrorKV(ctx, "orders projection: process batch", "error", err.Error())
+		}
+
+		// Пачка была полной - скорее всего есть еще события, не ждем
+		if err == nil && uint64(processed) == p.cfg.BatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.cfg.PollInterval):
+		}
+	}
+}
+
+// ProcessBatch - применяет к read model одну пачку событий журнала и возвращает их количество.
+// Пачка и сдвиг позиции проекции - в одной транзакции: при ошибке пачка применится заново
+func (p *Projection) ProcessBatch(ctx context.Context) (int, error) {
+	const api = "orders_projection.ProcessBatch"
+
+	// Граница пачки - отдельной короткой транзакцией: она на мгновение останавливает запись в журнал
+	var watermark int64
+	err := p.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		var err error
+		watermark, err = p.ProjectionStorage.OrderEventsWatermark(txCtx)
+		return err
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return 0, pkgerrors.Wrap(api, err)
+	}
+
+	var processed int
+	err = p.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		lastSeq, err := p.ProjectionStorage.LockProjection(txCtx, Name)
+		if err != nil {
+			return err
+		}
+		if lastSeq >= watermark {
+			return nil
+		}
+
+		events, err := p.ProjectionStorage.ListOrderEventsBetween(txCtx, lastSeq, watermark, p.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+		if len(events) == 0 {
+			return nil
+		}
+
+		for _, event := range events {
+			if err := p.apply(txCtx, event); err != nil {
+				return err
+			}
+		}
+		processed = len(events)
+
+		return p.ProjectionStorage.UpdateProjection(txCtx, Name, events[len(events)-1].Seq)
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return 0, pkgerrors.Wrap(api, err)
+	}
+
+	return processed, nil
+}
+
+// apply - применяет событие к заказу в read model, если оно там еще не применено
+func (p *Projection) apply(txCtx context.Context, event models.OrderEvent) error {
+	order, err := p.ProjectionStorage.GetOrder(txCtx, event.Order.ID)
+	if errors.Is(err, models.ErrNotFound) {
+		order, err = &models.Order{ID: event.Order.ID}, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if event.StreamVersion <= order.Version {
+		return nil
+	}
+
+	fromVersion := order.Version
+	if err := order.Apply(event); err != nil {
+		return err
+	}
+
+	return p.ProjectionStorage.SaveOrderProjection(txCtx, order, fromVersion)
+}
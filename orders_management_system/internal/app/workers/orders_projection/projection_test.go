@@ -0,0 +1,137 @@
+//go:build test
+
+package orders_projection
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/workers/orders_projection/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestProjection_ProcessBatch(t *testing.T) {
+	var (
+		ctx     = context.Background() // dummy
+		now     = time.Now()
+		orderID = models.OrderID(uuid.New())
+		created = models.Order{ID: orderID, Status: models.OrderStatusNew, StatusChangedAt: now, Version: 1}
+		events  = []models.OrderEvent{
+			{Seq: 11, Type: models.OrderEventCreated, StreamVersion: 1, Order: created},
+			{
+				Seq:           12,
+				Type:          models.OrderEventStatusChanged,
+				StreamVersion: 2,
+				Order:         models.Order{ID: orderID},
+				Transition: &models.OrderStatusTransition{
+					OrderID: orderID, From: models.OrderStatusNew, To: models.OrderStatusReserved, ChangedAt: now,
+				},
+			},
+		}
+		reserved = models.Order{ID: orderID, Status: models.OrderStatusReserved, StatusChangedAt: now, Version: 2}
+		// readModel - копия заказа из read model: проекция меняет полученный заказ
+		readModel = func(o models.Order) *models.Order { return &o }
+	)
+	type fields struct {
+		TransactionManager *mocks.TransactionManager
+		ProjectionStorage  *mocks.ProjectionStorage
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+
+		on func(*fields)
+	}{
+		{
+			name: "Test 1. Positive. New order is created in read model and projection is moved.",
+			want: 2,
+			on: func(f *fields) {
+				f.ProjectionStorage.On("OrderEventsWatermark", ctx).Return(int64(12), nil)
+				f.ProjectionStorage.On("LockProjection", ctx, Name).Return(int64(10), nil)
+				f.ProjectionStorage.On("ListOrderEventsBetween", ctx, int64(10), int64(12), uint64(10)).Return(events, nil)
+				f.ProjectionStorage.On("GetOrder", ctx, orderID).Return(nil, models.ErrNotFound).Once()
+				f.ProjectionStorage.On("SaveOrderProjection", ctx, &created, uint64(0)).Return(nil)
+				f.ProjectionStorage.On("GetOrder", ctx, orderID).Return(readModel(created), nil).Once()
+				f.ProjectionStorage.On("SaveOrderProjection", ctx, &reserved, uint64(1)).Return(nil)
+				f.ProjectionStorage.On("UpdateProjection", ctx, Name, int64(12)).Return(nil)
+			},
+		},
+		{
+			name: "Test 2. Positive. Already applied events are skipped.",
+			want: 2,
+			on: func(f *fields) {
+				f.ProjectionStorage.On("OrderEventsWatermark", ctx).Return(int64(12), nil)
+				f.ProjectionStorage.On("LockProjection", ctx, Name).Return(int64(10), nil)
+				f.ProjectionStorage.On("ListOrderEventsBetween", ctx, int64(10), int64(12), uint64(10)).Return(events, nil)
+				f.ProjectionStorage.On("GetOrder", ctx, orderID).Return(readModel(reserved), nil).Twice()
+				f.ProjectionStorage.On("UpdateProjection", ctx, Name, int64(12)).Return(nil)
+			},
+		},
+		{
+			name: "Test 3. Positive. Projection is up to watermark.",
+			want: 0,
+			on: func(f *fields) {
+				f.ProjectionStorage.On("OrderEventsWatermark", ctx).Return(int64(12), nil)
+				f.ProjectionStorage.On("LockProjection", ctx, Name).Return(int64(12), nil)
+			},
+		},
+		{
+			name:    "Test 4. Negative. Read model was changed concurrently.",
+			want:    0,
+			wantErr: true,
+			on: func(f *fields) {
+				f.ProjectionStorage.On("OrderEventsWatermark", ctx).Return(int64(12), nil)
+				f.ProjectionStorage.On("LockProjection", ctx, Name).Return(int64(10), nil)
+				f.ProjectionStorage.On("ListOrderEventsBetween", ctx, int64(10), int64(12), uint64(10)).Return(events, nil)
+				f.ProjectionStorage.On("GetOrder", ctx, orderID).Return(nil, models.ErrNotFound)
+				f.ProjectionStorage.On("SaveOrderProjection", ctx, &created, uint64(0)).Return(models.ErrConcurrentModification)
+			},
+		},
+		{
+			name:    "Test 5. Negative. Watermark failed.",
+			want:    0,
+			wantErr: true,
+			on: func(f *fields) {
+				f.ProjectionStorage.On("OrderEventsWatermark", ctx).Return(int64(0), errors.New("db is down"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager: mocks.NewTransactionManager(t),
+				ProjectionStorage:  mocks.NewProjectionStorage(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction)
+			if tt.on != nil {
+				tt.on(f)
+			}
+			p := New(Config{BatchSize: 10}, Deps{
+				TransactionManager: f.TransactionManager,
+				ProjectionStorage:  f.ProjectionStorage,
+			})
+
+			// act
+			got, err := p.ProcessBatch(ctx)
+
+			// assert
+			assert.Equal(t, tt.wantErr, err != nil, "error: %v", err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
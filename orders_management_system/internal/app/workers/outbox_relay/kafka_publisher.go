@@ -0,0 +1,44 @@
+package outbox_relay
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/kafka"
+)
+
+const (
+	messageIDHeader = "x-outbox-message-id"
+	eventTypeHeader = "x-event-type" // тип события, чтобы потребитель мог отфильтровать ненужные без декодирования
+)
+
+// KafkaPublisher - Publisher, отправляющий сообщения в топик kafka.
+// Ключ сообщения - ID заказа: все события одного заказа попадают в одну партицию и читаются по порядку
+type KafkaPublisher struct {
+	producer kafka.Producer
+	topic    string
+}
+
+var _ Publisher = (*KafkaPublisher)(nil)
+
+// NewKafkaPublisher - returns *KafkaPublisher
+func NewKafkaPublisher(producer kafka.Producer, topic string) *KafkaPublisher {
+	return &KafkaPublisher{
+		producer: producer,
+		topic:    topic,
+	}
+}
+
+// Publish - отправляет сообщение в kafka. Значение - сериализованный pb.EventEnvelope
+func (p *KafkaPublisher) Publish(ctx context.Context, message models.OutboxMessage) error {
+	return p.producer.SendMessage(ctx, kafka.Message{
+		Topic: p.topic,
+		Key:   []byte(message.OrderID.String()),
+		Value: message.Payload,
+		Headers: map[string]string{
+			messageIDHeader: strconv.FormatInt(message.ID, 10),
+			eventTypeHeader: string(message.EventType),
+		},
+	})
+}
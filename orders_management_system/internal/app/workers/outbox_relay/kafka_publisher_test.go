@@ -0,0 +1,94 @@
+//go:build test
+
+package outbox_relay
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/workers/outbox_relay/mocks"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/kafka"
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/mocktracer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRelay_KafkaPublisher - outbox -> relay -> kafka (in-memory) -> consumer
+func TestRelay_KafkaPublisher(t *testing.T) {
+	tracer := mocktracer.New()
+	opentracing.SetGlobalTracer(tracer)
+	t.Cleanup(func() { opentracing.SetGlobalTracer(opentracing.NoopTracer{}) })
+
+	const topic = "orders.events"
+	var (
+		orderID  = models.OrderID(uuid.New())
+		messages = []models.OutboxMessage{
+			{ID: 1, OrderID: orderID, EventType: models.OrderEventCreated, Payload: []byte("created")},
+			{ID: 2, OrderID: orderID, EventType: models.OrderEventCancelled, Payload: []byte("cancelled")},
+			{ID: 3, OrderID: models.OrderID(uuid.New()), EventType: models.OrderEventCreated, Payload: []byte("created")},
+		}
+	)
+
+	broker := kafka.NewMemoryBroker(3)
+	defer broker.Close()
+
+	txManager := mocks.NewTransactionManager(t)
+	txManager.On("RunTransaction", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+			return f(ctx)
+		})
+	outboxStorage := mocks.NewOutboxStorage(t)
+	outboxStorage.On("ClaimOutboxMessages", mock.Anything, uint64(10)).Return(messages, nil)
+	outboxStorage.On("DeleteOutboxMessages", mock.Anything, []int64{1, 2, 3}).Return(nil)
+
+	relay := New(Config{BatchSize: 10}, Deps{
+		TransactionManager: txManager,
+		OutboxStorage:      outboxStorage,
+		Publisher:          NewKafkaPublisher(broker.Producer(), topic),
+	})
+
+	// act
+	span, ctx := opentracing.StartSpanFromContext(context.Background(), "test")
+	published, err := relay.ProcessBatch(ctx)
+	span.Finish()
+
+	// assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, published)
+
+	consumeCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var received []kafka.Message
+	err = broker.Consumer("test-group").Consume(consumeCtx, []string{topic}, func(ctx context.Context, message kafka.Message) error {
+		received = append(received, message)
+		assert.NotNil(t, opentracing.SpanFromContext(ctx))
+		if len(received) == len(messages) {
+			cancel()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, received, len(messages))
+
+	var orderMessages []kafka.Message
+	for _, message := range received {
+		assert.NotEmpty(t, message.Headers["mockpfx-ids-traceid"], "trace context must be propagated")
+		if string(message.Key) == orderID.String() {
+			orderMessages = append(orderMessages, message)
+		}
+	}
+	// события одного заказа - в одной партиции и по порядку
+	require.Len(t, orderMessages, 2)
+	assert.Equal(t, orderMessages[0].Partition, orderMessages[1].Partition)
+	assert.Equal(t, "1", orderMessages[0].Headers[messageIDHeader])
+	assert.Equal(t, "2", orderMessages[1].Headers[messageIDHeader])
+	assert.Equal(t, string(models.OrderEventCancelled), orderMessages[1].Headers[eventTypeHeader])
+	assert.Equal(t, []byte("cancelled"), orderMessages[1].Value)
+}
@@ -0,0 +1,23 @@
+package outbox_relay
+
+import (
+	"context"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+)
+
+// LoggerPublisher - Publisher, который только пишет сообщения в лог (пока нет брокера)
+type LoggerPublisher struct{}
+
+var _ Publisher = LoggerPublisher{}
+
+// Publish - пишет сообщение в лог
+func (LoggerPublisher) Publish(ctx context.Context, message models.OutboxMessage) error {
+	logger.InfoKV(ctx, "outbox message published",
+		"id", message.ID,
+		"order_id", message.OrderID.String(),
+		"event_type", message.EventType,
+	)
+	return nil
+}
@@ -0,0 +1,179 @@
+package outbox_relay
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	postgres_transaction_manager "github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager/postgres"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Second
+)
+
+//go:generate mockery --name=OutboxStorage --filename=outbox_storage_mock.go --disable-version-string
+//go:generate mockery --name=Publisher --filename=publisher_mock.go --disable-version-string
+//go:generate mockery --srcpkg=github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager --name=TransactionManager --filename=transaction_manager_mock.go --disable-version-string
+
+type (
+	// OutboxStorage - хранилище outbox сообщений
+	OutboxStorage interface {
+		// ClaimOutboxMessages - захват пачки сообщений до конца транзакции
+		//
+		// SELECT ... FROM orders_outbox_messages m JOIN order_events e ON e.id = m.event_id
+		// ORDER BY m.id LIMIT limit FOR UPDATE OF m SKIP LOCKED;
+		ClaimOutboxMessages(ctx context.Context, limit uint64) ([]models.OutboxMessage, error)
+		// DeleteOutboxMessages - удаление опубликованных сообщений
+		//
+		// DELETE FROM orders_outbox_messages WHERE id IN (...);
+		DeleteOutboxMessages(ctx context.Context, ids []int64) error
+	}
+
+	// Publisher - то что доставляет сообщения потребителям (kafka, ...)
+	Publisher interface {
+		// Publish - публикация сообщения
+		Publish(ctx context.Context, message models.OutboxMessage) error
+	}
+)
+
+// Config - настройки relay
+type Config struct {
+	BatchSize    uint64        // Сколько сообщений захватывать за одну транзакцию
+	PollInterval time.Duration // Пауза между опросами, если outbox пуст
+}
+
+// Deps - зависимости relay
+type Deps struct {
+	transaction_manager.TransactionManager
+	OutboxStorage
+	Publisher
+}
+
+// Relay - фоновый процесс, перекладывающий сообщения из outbox таблицы в Publisher
+type Relay struct {
+	Deps
+	cfg Config
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New - returns *Relay
+func New(cfg Config, d Deps) *Relay {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+
+	return &Relay{
+		Deps: d,
+		cfg:  cfg,
+	}
+}
+
+// Start - запускает relay в фоне. Остановка через Stop (например из closer)
+func (r *Relay) Start(ctx context.Context) {
+	ctx, r.cancel = context.WithCancel(ctx)
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		r.run(ctx)
+	}()
+}
+
+// Stop - останавливает relay и дожидается завершения текущей пачки
+func (r *Relay) Stop(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		r.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return pkgerrors.Wrap("outbox_relay.Stop", ctx.Err())
+	}
+}
+
+func (r *Relay) run(ctx context.Context) {
+	logger.Info(ctx, "outbox relay started")
+	defer logger.Info(ctx, "outbox relay stopped")
+
+	for {
+		published, err := r.ProcessBatch(ctx)
+		if err != nil {
+			logger.ErrorKV(ctx, "outbox relay: process batch", "error", err.Error())
+		}
+
+		// Пачка была полной - скорее всего есть еще сообщения, не ждем
+		if err == nil && uint64(published) == r.cfg.BatchSize {
+			if ctx.Err() != nil {
+				return
+			}
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.cfg.PollInterval):
+		}
+	}
+}
+
+// ProcessBatch - публикует одну пачку сообщений и возвращает количество опубликованных.
+// Опубликованные сообщения удаляются в той же транзакции, в которой были захвачены:
+// если публикация падает на середине - удаляем только успешно опубликованные (at-least-once).
+func (r *Relay) ProcessBatch(ctx context.Context) (int, error) {
+	const api = "outbox_relay.ProcessBatch"
+
+	var (
+		published  []int64
+		publishErr error
+	)
+	err := r.TransactionManager.RunTransaction(ctx, func(txCtx context.Context) error { // TRANSANCTION SCOPE
+		messages, err := r.OutboxStorage.ClaimOutboxMessages(txCtx, r.cfg.BatchSize)
+		if err != nil {
+			return err
+		}
+
+		published = make([]int64, 0, len(messages))
+		for _, message := range messages {
+			if publishErr = r.Publisher.Publish(txCtx, message); publishErr != nil {
+				break
+			}
+			published = append(published, message.ID)
+		}
+
+		return r.OutboxStorage.DeleteOutboxMessages(txCtx, published)
+	},
+		postgres_transaction_manager.WithAccessMode(pgx.ReadWrite),
+		postgres_transaction_manager.WithIsoLevel(pgx.ReadCommitted),
+		postgres_transaction_manager.WithDeferrableMode(pgx.NotDeferrable),
+	)
+	if err != nil {
+		return 0, pkgerrors.Wrap(api, err)
+	}
+	if publishErr != nil {
+		return len(published), pkgerrors.Wrap(api, publishErr)
+	}
+
+	return len(published), nil
+}
@@ -0,0 +1,101 @@
+//go:build test
+
+package outbox_relay
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/transaction_manager"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/workers/outbox_relay/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRelay_ProcessBatch(t *testing.T) {
+	var (
+		ctx      = context.Background() // dummy
+		messages = []models.OutboxMessage{
+			{ID: 1, OrderID: models.OrderID(uuid.New())},
+			{ID: 2, OrderID: models.OrderID(uuid.New())},
+			{ID: 3, OrderID: models.OrderID(uuid.New())},
+		}
+	)
+	type fields struct {
+		TransactionManager *mocks.TransactionManager
+		OutboxStorage      *mocks.OutboxStorage
+		Publisher          *mocks.Publisher
+	}
+
+	// runTransaction - выполняет функцию транзакции без БД
+	runTransaction := func(ctx context.Context, f func(txCtx context.Context) error, _ ...transaction_manager.TransactionOption) error {
+		return f(ctx)
+	}
+
+	tests := []struct {
+		name    string
+		want    int
+		wantErr bool
+
+		on func(*fields)
+	}{
+		{
+			name: "Test 1. Positive. All messages published and deleted.",
+			want: 3,
+			on: func(f *fields) {
+				f.OutboxStorage.On("ClaimOutboxMessages", ctx, uint64(10)).Return(messages, nil)
+				f.Publisher.On("Publish", ctx, mock.Anything).Return(nil)
+				f.OutboxStorage.On("DeleteOutboxMessages", ctx, []int64{1, 2, 3}).Return(nil)
+			},
+		},
+		{
+			name:    "Test 2. Negative. Only messages published before failure are deleted.",
+			want:    1,
+			wantErr: true,
+			on: func(f *fields) {
+				f.OutboxStorage.On("ClaimOutboxMessages", ctx, uint64(10)).Return(messages, nil)
+				f.Publisher.On("Publish", ctx, messages[0]).Return(nil)
+				f.Publisher.On("Publish", ctx, messages[1]).Return(errors.New("broker is down"))
+				f.OutboxStorage.On("DeleteOutboxMessages", ctx, []int64{1}).Return(nil)
+			},
+		},
+		{
+			name:    "Test 3. Negative. Claim failed.",
+			want:    0,
+			wantErr: true,
+			on: func(f *fields) {
+				f.OutboxStorage.On("ClaimOutboxMessages", ctx, uint64(10)).Return(nil, errors.New("db is down"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			f := &fields{
+				TransactionManager: mocks.NewTransactionManager(t),
+				OutboxStorage:      mocks.NewOutboxStorage(t),
+				Publisher:          mocks.NewPublisher(t),
+			}
+			f.TransactionManager.On("RunTransaction", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+				Return(runTransaction)
+			if tt.on != nil {
+				tt.on(f)
+			}
+			r := New(Config{BatchSize: 10}, Deps{
+				TransactionManager: f.TransactionManager,
+				OutboxStorage:      f.OutboxStorage,
+				Publisher:          f.Publisher,
+			})
+
+			// act
+			got, err := r.ProcessBatch(ctx)
+
+			// assert
+			assert.Equal(t, tt.wantErr, err != nil, "error: %v", err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
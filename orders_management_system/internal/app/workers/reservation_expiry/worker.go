@@ -0,0 +1,70 @@
+package reservation_expiry
+
+import (
+	"context"
+	"time"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/worker"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = time.Minute
+	defaultTTL          = 30 * time.Minute
+)
+
+// ReservationExpirer - то, что умеет отменять неоплаченные вовремя заказы (usecase)
+type ReservationExpirer interface {
+	// ExpireReservations - отмена заказов, не оплаченных до expiredBefore, со снятием резерва
+	ExpireReservations(ctx context.Context, expiredBefore time.Time, limit uint64) (int, error)
+}
+
+// Config - настройки worker
+type Config struct {
+	BatchSize    uint64        // Сколько заказов обрабатывать за один проход
+	PollInterval time.Duration // Пауза между проходами
+	TTL          time.Duration // Сколько резерв живет без оплаты заказа (от создания заказа)
+}
+
+// Worker - фоновый процесс, снимающий резервы заказов, которые не оплатили за TTL. Start и Stop - от worker.Periodic
+type Worker struct {
+	*worker.Periodic
+
+	expirer ReservationExpirer
+	cfg     Config
+}
+
+// New - returns *Worker
+func New(cfg Config, expirer ReservationExpirer) *Worker {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTTL
+	}
+
+	w := &Worker{
+		expirer: expirer,
+		cfg:     cfg,
+	}
+	w.Periodic = worker.NewPeriodic("reservation expiry", cfg.PollInterval, w.expireReservations)
+
+	return w
+}
+
+// expireReservations - один проход: отмена заказов, не оплаченных за TTL
+func (w *Worker) expireReservations(ctx context.Context) error {
+	expired, err := w.expirer.ExpireReservations(ctx, time.Now().UTC().Add(-w.cfg.TTL), w.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+	if expired > 0 {
+		logger.InfoKV(ctx, "reservation expiry: orders cancelled", "count", expired)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package saga_recovery
+
+import (
+	"context"
+	"time"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/worker"
+)
+
+const (
+	defaultBatchSize    = 100
+	defaultPollInterval = 30 * time.Second
+	defaultStuckAfter   = 5 * time.Minute
+)
+
+// SagaRecoverer - то, что умеет компенсировать прерванные саги (usecase)
+type SagaRecoverer interface {
+	// RecoverSagas - компенсация саг, которые не обновлялись с updatedBefore
+	RecoverSagas(ctx context.Context, updatedBefore time.Time, limit uint64) (int, error)
+}
+
+// Config - настройки worker
+type Config struct {
+	BatchSize    uint64        // Сколько саг обрабатывать за один проход
+	PollInterval time.Duration // Пауза между проходами
+	StuckAfter   time.Duration // Сага без изменений дольше StuckAfter считается прерванной (должно быть больше таймаута запроса)
+}
+
+// Worker - фоновый процесс, компенсирующий саги, прерванные падением процесса. Start и Stop - от worker.Periodic
+type Worker struct {
+	*worker.Periodic
+
+	recoverer SagaRecoverer
+	cfg       Config
+}
+
+// New - returns *Worker
+func New(cfg Config, recoverer SagaRecoverer) *Worker {
+	if cfg.BatchSize == 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	if cfg.StuckAfter <= 0 {
+		cfg.StuckAfter = defaultStuckAfter
+	}
+
+	w := &Worker{
+		recoverer: recoverer,
+		cfg:       cfg,
+	}
+	w.Periodic = worker.NewPeriodic("saga recovery", cfg.PollInterval, w.recoverSagas)
+
+	return w
+}
+
+// recoverSagas - один проход: компенсация саг, которые не обновлялись дольше StuckAfter
+func (w *Worker) recoverSagas(ctx context.Context) error {
+	recovered, err := w.recoverer.RecoverSagas(ctx, time.Now().UTC().Add(-w.cfg.StuckAfter), w.cfg.BatchSize)
+	if err != nil {
+		return err
+	}
+	if recovered > 0 {
+		logger.InfoKV(ctx, "saga recovery: sagas compensated", "count", recovered)
+	}
+
+	return nil
+}
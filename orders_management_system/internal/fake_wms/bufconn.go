@@ -0,0 +1,46 @@
+package fake_wms
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/warehouses_management_system"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const bufconnSize = 1 << 20
+
+// StartBufconn - запускает srv в памяти процесса (для тестов) и возвращает соединение с ним.
+// opts добавляются к опциям соединения (например, DialOptions клиента WMS).
+// stop закрывает соединение и останавливает сервер
+func StartBufconn(srv *Server, opts ...grpc.DialOption) (conn *grpc.ClientConn, stop func(), err error) {
+	lis := bufconn.Listen(bufconnSize)
+
+	grpcServer := grpc.NewServer()
+	pb.RegisterWarehousesManagementSystemServiceServer(grpcServer, srv)
+	go func() {
+		_ = grpcServer.Serve(lis)
+	}()
+
+	opts = append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+	}, opts...)
+
+	conn, err = grpc.NewClient("passthrough:///fake_wms", opts...)
+	if err != nil {
+		grpcServer.Stop()
+		return nil, nil, fmt.Errorf("fake_wms: dial bufconn: %w", err)
+	}
+
+	stop = func() {
+		_ = conn.Close()
+		grpcServer.Stop()
+	}
+	return conn, stop, nil
+}
@@ -0,0 +1,288 @@
+package fake_wms
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+)
+
+// stockKey - сток SKU на складе
+type stockKey struct {
+	WarehouseID uint64
+	SKUID       uint64
+}
+
+// stock - остатки SKU на складе
+type stock struct {
+	Available uint64 // можно зарезервировать
+	Reserved  uint64 // зарезервировано
+}
+
+// Item - сток SKU на складе
+type Item struct {
+	SKUID       uint64
+	Quantity    uint32
+	WarehouseID uint64
+}
+
+// OutOfStockError - на складе не хватает стока
+type OutOfStockError struct {
+	SKUID       uint64
+	WarehouseID uint64
+}
+
+func (e *OutOfStockError) Error() string {
+	return fmt.Sprintf("sku %d on warehouse %d: out of stock", e.SKUID, e.WarehouseID)
+}
+
+// UnknownWarehouseError - склад не существует
+type UnknownWarehouseError struct {
+	WarehouseID uint64
+}
+
+func (e *UnknownWarehouseError) Error() string {
+	return fmt.Sprintf("warehouse %d: unknown warehouse", e.WarehouseID)
+}
+
+// UnknownReservationError - резерв не существует
+type UnknownReservationError struct {
+	ReservationID string
+}
+
+func (e *UnknownReservationError) Error() string {
+	return fmt.Sprintf("reservation %s: unknown reservation", e.ReservationID)
+}
+
+// ReservationIncreaseError - уменьшение резерва просит больше, чем зарезервировано
+type ReservationIncreaseError struct {
+	SKUID       uint64
+	WarehouseID uint64
+}
+
+func (e *ReservationIncreaseError) Error() string {
+	return fmt.Sprintf("sku %d on warehouse %d: reservation can not be increased", e.SKUID, e.WarehouseID)
+}
+
+// reservation - резерв стоков
+type reservation struct {
+	Stocks    map[stockKey]uint64 // сколько зарезервировано
+	Confirmed bool                // подтвержден - сток списан под заказ
+}
+
+// WarehouseStocks - доступные стоки склада
+type WarehouseStocks struct {
+	WarehouseID  uint64
+	Stocks       []Item // доступное количество SKU
+	DistanceKm   uint32 // расстояние до способа доставки, 0 - неизвестно
+	ShippingCost uint64 // стоимость отправки, копейки
+}
+
+// Ledger - учет стоков в памяти
+type Ledger struct {
+	mu           sync.Mutex
+	warehouses   map[uint64]SeedWarehouse
+	stocks       map[stockKey]*stock
+	reservations map[string]*reservation
+}
+
+// NewLedger - учет стоков, заполненный из seed
+func NewLedger(seed Seed) *Ledger {
+	l := &Ledger{
+		warehouses:   make(map[uint64]SeedWarehouse, len(seed.Warehouses)),
+		stocks:       make(map[stockKey]*stock),
+		reservations: make(map[string]*reservation),
+	}
+	for _, w := range seed.Warehouses {
+		l.warehouses[w.ID] = w
+		for _, s := range w.Stocks {
+			key := stockKey{WarehouseID: w.ID, SKUID: s.SKUID}
+			if _, ok := l.stocks[key]; !ok {
+				l.stocks[key] = &stock{}
+			}
+			l.stocks[key].Available += s.Quantity
+		}
+	}
+	return l
+}
+
+// Reserve - резервирование стоков под reservationID: все или ничего.
+// Повтор с тем же reservationID ничего не меняет.
+// Возвращает *UnknownWarehouseError или *OutOfStockError
+func (l *Ledger) Reserve(reservationID string, items []Item) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.reservations[reservationID]; ok {
+		return nil
+	}
+
+	need, err := l.aggregate(items)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		key := stockKey{WarehouseID: item.WarehouseID, SKUID: item.SKUID}
+		if s := l.stocks[key]; s == nil || s.Available < need[key] {
+			return &OutOfStockError{SKUID: item.SKUID, WarehouseID: item.WarehouseID}
+		}
+	}
+
+	for key, quantity := range need {
+		s := l.stocks[key]
+		s.Available -= quantity
+		s.Reserved += quantity
+	}
+	l.reservations[reservationID] = &reservation{Stocks: need}
+	return nil
+}
+
+// Confirm - подтверждение резерва: сток списывается со склада.
+// Возвращает *UnknownReservationError
+func (l *Ledger) Confirm(reservationID string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r, ok := l.reservations[reservationID]
+	if !ok {
+		return &UnknownReservationError{ReservationID: reservationID}
+	}
+	if r.Confirmed {
+		return nil
+	}
+
+	for key, quantity := range r.Stocks {
+		l.stocks[key].Reserved -= quantity
+	}
+	r.Confirmed = true
+	return nil
+}
+
+// Release - снятие резерва: сток возвращается на склад (в том числе списанный подтверждением).
+// Снятие несуществующего резерва ничего не меняет (повторная компенсация не создает стоки из воздуха)
+func (l *Ledger) Release(reservationID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r, ok := l.reservations[reservationID]
+	if !ok {
+		return
+	}
+
+	for key, quantity := range r.Stocks {
+		s := l.stocks[key]
+		if !r.Confirmed {
+			s.Reserved -= quantity
+		}
+		s.Available += quantity
+	}
+	delete(l.reservations, reservationID)
+}
+
+// Shrink - уменьшение резерва до items: сток сверх items возвращается на склад
+// (в том числе списанный подтверждением). Повтор с теми же items ничего не меняет.
+// Возвращает *UnknownReservationError, *UnknownWarehouseError или *ReservationIncreaseError
+func (l *Ledger) Shrink(reservationID string, items []Item) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	r, ok := l.reservations[reservationID]
+	if !ok {
+		return &UnknownReservationError{ReservationID: reservationID}
+	}
+
+	keep, err := l.aggregate(items)
+	if err != nil {
+		return err
+	}
+	for key, quantity := range keep {
+		if quantity > r.Stocks[key] {
+			return &ReservationIncreaseError{SKUID: key.SKUID, WarehouseID: key.WarehouseID}
+		}
+	}
+
+	for key, quantity := range r.Stocks {
+		released := quantity - keep[key]
+		if released == 0 {
+			continue
+		}
+		s := l.stocks[key]
+		if !r.Confirmed {
+			s.Reserved -= released
+		}
+		s.Available += released
+
+		if keep[key] == 0 {
+			delete(r.Stocks, key)
+		} else {
+			r.Stocks[key] = keep[key]
+		}
+	}
+	return nil
+}
+
+// Stocks - доступные стоки skuIDs по складам (склады без доступных стоков пропускаются),
+// расстояние считается до способа доставки deliveryVariantID
+func (l *Ledger) Stocks(skuIDs []uint64, deliveryVariantID uint64) []WarehouseStocks {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	warehouseIDs := make([]uint64, 0, len(l.warehouses))
+	for id := range l.warehouses {
+		warehouseIDs = append(warehouseIDs, id)
+	}
+	slices.Sort(warehouseIDs)
+
+	var res []WarehouseStocks
+	for _, id := range warehouseIDs {
+		w := l.warehouses[id]
+
+		var stocks []Item
+		for _, skuID := range skuIDs {
+			s := l.stocks[stockKey{WarehouseID: id, SKUID: skuID}]
+			if s == nil || s.Available == 0 {
+				continue
+			}
+			stocks = append(stocks, Item{
+				SKUID:       skuID,
+				Quantity:    uint32(min(s.Available, math.MaxUint32)),
+				WarehouseID: id,
+			})
+		}
+		if len(stocks) == 0 {
+			continue
+		}
+
+		res = append(res, WarehouseStocks{
+			WarehouseID:  id,
+			Stocks:       stocks,
+			DistanceKm:   w.Distances[deliveryVariantID],
+			ShippingCost: w.ShippingCost,
+		})
+	}
+	return res
+}
+
+// Stock - остатки SKU на складе: (доступно, зарезервировано)
+func (l *Ledger) Stock(warehouseID, skuID uint64) (available, reserved uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if s := l.stocks[stockKey{WarehouseID: warehouseID, SKUID: skuID}]; s != nil {
+		return s.Available, s.Reserved
+	}
+	return 0, 0
+}
+
+// aggregate - суммирует количество одного SKU на одном складе. Вызывается под mu
+func (l *Ledger) aggregate(items []Item) (map[stockKey]uint64, error) {
+	need := make(map[stockKey]uint64, len(items))
+	for _, item := range items {
+		if _, ok := l.warehouses[item.WarehouseID]; !ok {
+			return nil, &UnknownWarehouseError{WarehouseID: item.WarehouseID}
+		}
+		need[stockKey{WarehouseID: item.WarehouseID, SKUID: item.SKUID}] += uint64(item.Quantity)
+	}
+	return need, nil
+}
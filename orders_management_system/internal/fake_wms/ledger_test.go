@@ -0,0 +1,113 @@
+//go:build test
+
+package fake_wms
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLedger(t *testing.T) {
+	seed := Seed{
+		Warehouses: []SeedWarehouse{
+			{ID: 1, Stocks: []SeedStock{{SKUID: 10, Quantity: 5}, {SKUID: 20, Quantity: 1}}},
+		},
+	}
+
+	t.Run("Test 1. Reserve and release.", func(t *testing.T) {
+		l := NewLedger(seed)
+
+		assert.NoError(t, l.Reserve("r1", []Item{{SKUID: 10, Quantity: 2, WarehouseID: 1}, {SKUID: 10, Quantity: 1, WarehouseID: 1}}))
+		// повтор с тем же id не создает второй резерв
+		assert.NoError(t, l.Reserve("r1", []Item{{SKUID: 10, Quantity: 3, WarehouseID: 1}}))
+		available, reserved := l.Stock(1, 10)
+		assert.Equal(t, uint64(2), available)
+		assert.Equal(t, uint64(3), reserved)
+
+		// повторная компенсация не создает стоки из воздуха
+		l.Release("r1")
+		l.Release("r1")
+		available, reserved = l.Stock(1, 10)
+		assert.Equal(t, uint64(5), available)
+		assert.Equal(t, uint64(0), reserved)
+	})
+
+	t.Run("Test 2. Confirm and release confirmed.", func(t *testing.T) {
+		l := NewLedger(seed)
+
+		assert.NoError(t, l.Reserve("r1", []Item{{SKUID: 10, Quantity: 2, WarehouseID: 1}}))
+		assert.NoError(t, l.Confirm("r1"))
+		available, reserved := l.Stock(1, 10)
+		assert.Equal(t, uint64(3), available)
+		assert.Equal(t, uint64(0), reserved)
+
+		l.Release("r1")
+		available, _ = l.Stock(1, 10)
+		assert.Equal(t, uint64(5), available)
+
+		assert.Equal(t, &UnknownReservationError{ReservationID: "r1"}, l.Confirm("r1"))
+	})
+
+	t.Run("Test 3. Out of stock reserves nothing.", func(t *testing.T) {
+		l := NewLedger(seed)
+
+		err := l.Reserve("r1", []Item{{SKUID: 10, Quantity: 1, WarehouseID: 1}, {SKUID: 20, Quantity: 2, WarehouseID: 1}})
+		assert.Equal(t, &OutOfStockError{SKUID: 20, WarehouseID: 1}, err)
+
+		available, reserved := l.Stock(1, 10)
+		assert.Equal(t, uint64(5), available)
+		assert.Equal(t, uint64(0), reserved)
+	})
+
+	t.Run("Test 4. Unknown warehouse.", func(t *testing.T) {
+		l := NewLedger(seed)
+
+		err := l.Reserve("r1", []Item{{SKUID: 10, Quantity: 1, WarehouseID: 2}})
+		assert.Equal(t, &UnknownWarehouseError{WarehouseID: 2}, err)
+	})
+
+	t.Run("Test 5. Available stocks by warehouse.", func(t *testing.T) {
+		l := NewLedger(Seed{
+			Warehouses: []SeedWarehouse{
+				{ID: 2, ShippingCost: 100, Distances: map[uint64]uint32{7: 30}, Stocks: []SeedStock{{SKUID: 10, Quantity: 3}}},
+				{ID: 1, Stocks: []SeedStock{{SKUID: 10, Quantity: 5}, {SKUID: 20, Quantity: 1}}},
+				{ID: 3, Stocks: []SeedStock{{SKUID: 30, Quantity: 1}}},
+			},
+		})
+		assert.NoError(t, l.Reserve("r1", []Item{{SKUID: 20, Quantity: 1, WarehouseID: 1}}))
+
+		assert.Equal(t, []WarehouseStocks{
+			{WarehouseID: 1, Stocks: []Item{{SKUID: 10, Quantity: 5, WarehouseID: 1}}},
+			{WarehouseID: 2, Stocks: []Item{{SKUID: 10, Quantity: 3, WarehouseID: 2}}, DistanceKm: 30, ShippingCost: 100},
+		}, l.Stocks([]uint64{10, 20}, 7))
+	})
+
+	t.Run("Test 6. Shrink reservation.", func(t *testing.T) {
+		l := NewLedger(seed)
+
+		assert.NoError(t, l.Reserve("r1", []Item{{SKUID: 10, Quantity: 3, WarehouseID: 1}, {SKUID: 20, Quantity: 1, WarehouseID: 1}}))
+		// повтор с теми же items ничего не меняет
+		assert.NoError(t, l.Shrink("r1", []Item{{SKUID: 10, Quantity: 1, WarehouseID: 1}}))
+		assert.NoError(t, l.Shrink("r1", []Item{{SKUID: 10, Quantity: 1, WarehouseID: 1}}))
+
+		available, reserved := l.Stock(1, 10)
+		assert.Equal(t, uint64(4), available)
+		assert.Equal(t, uint64(1), reserved)
+		available, reserved = l.Stock(1, 20)
+		assert.Equal(t, uint64(1), available)
+		assert.Equal(t, uint64(0), reserved)
+
+		err := l.Shrink("r1", []Item{{SKUID: 10, Quantity: 2, WarehouseID: 1}})
+		assert.Equal(t, &ReservationIncreaseError{SKUID: 10, WarehouseID: 1}, err)
+
+		// подтвержденный резерв: списанный сток возвращается в доступный
+		assert.NoError(t, l.Confirm("r1"))
+		assert.NoError(t, l.Shrink("r1", nil))
+		available, reserved = l.Stock(1, 10)
+		assert.Equal(t, uint64(5), available)
+		assert.Equal(t, uint64(0), reserved)
+
+		assert.Equal(t, &UnknownReservationError{ReservationID: "r2"}, l.Shrink("r2", nil))
+	})
+}
@@ -0,0 +1,53 @@
+package fake_wms
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Seed - начальные стоки складов
+type Seed struct {
+	Warehouses []SeedWarehouse `json:"warehouses" yaml:"warehouses"`
+}
+
+// SeedWarehouse - склад и его стоки
+type SeedWarehouse struct {
+	ID           uint64            `json:"id" yaml:"id"`
+	ShippingCost uint64            `json:"shipping_cost" yaml:"shipping_cost"` // стоимость отправки, копейки
+	Distances    map[uint64]uint32 `json:"distances" yaml:"distances"`         // расстояние (км) по id способа доставки
+	Stocks       []SeedStock       `json:"stocks" yaml:"stocks"`
+}
+
+// SeedStock - сток SKU на складе
+type SeedStock struct {
+	SKUID    uint64 `json:"sku_id" yaml:"sku_id"`
+	Quantity uint64 `json:"quantity" yaml:"quantity"`
+}
+
+// LoadSeed - читает стоки из YAML (.yaml, .yml) или JSON (.json) файла
+func LoadSeed(path string) (Seed, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Seed{}, fmt.Errorf("fake_wms: read seed: %w", err)
+	}
+
+	var seed Seed
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &seed)
+	case ".json":
+		err = json.Unmarshal(data, &seed)
+	default:
+		return Seed{}, fmt.Errorf("fake_wms: unsupported seed format %q", ext)
+	}
+	if err != nil {
+		return Seed{}, fmt.Errorf("fake_wms: parse seed %s: %w", path, err)
+	}
+
+	return seed, nil
+}
@@ -0,0 +1,204 @@
+package fake_wms
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"time"
+
+	pb "github.com/moguchev/microservices_courcse/orders_management_system/pkg/api/warehouses_management_system"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Config - поведение фейкового WMS
+type Config struct {
+	Latency     time.Duration // задержка каждого ответа
+	Jitter      time.Duration // случайная добавка к задержке [0, Jitter)
+	FailureRate float64       // доля запросов [0, 1], на которые отвечаем FailureCode
+	FailureCode codes.Code    // код инъецированной ошибки, по умолчанию codes.Unavailable
+}
+
+// Server - фейковый WMS: реализует контракт WMS поверх Ledger
+type Server struct {
+	pb.UnimplementedWarehousesManagementSystemServiceServer
+
+	cfg    Config
+	ledger *Ledger
+}
+
+// NewServer - returns *Server
+func NewServer(cfg Config, ledger *Ledger) *Server {
+	if cfg.FailureCode == codes.OK {
+		cfg.FailureCode = codes.Unavailable
+	}
+	return &Server{
+		cfg:    cfg,
+		ledger: ledger,
+	}
+}
+
+// Ledger - учет стоков сервера
+func (s *Server) Ledger() *Ledger {
+	return s.ledger
+}
+
+func (s *Server) ReserveStocks(ctx context.Context, req *pb.ReserveStocksRequest) (*pb.ReserveStocksResponse, error) {
+	if req.GetReservationId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "reservation_id is required")
+	}
+	if err := s.inject(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.ledger.Reserve(req.GetReservationId(), newItemsFromPbItems(req.GetItems())); err != nil {
+		return nil, newStatusError(err)
+	}
+
+	return &pb.ReserveStocksResponse{}, nil
+}
+
+func (s *Server) ConfirmReservation(ctx context.Context, req *pb.ConfirmReservationRequest) (*pb.ConfirmReservationResponse, error) {
+	if err := s.inject(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.ledger.Confirm(req.GetReservationId()); err != nil {
+		return nil, newStatusError(err)
+	}
+
+	return &pb.ConfirmReservationResponse{}, nil
+}
+
+func (s *Server) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
+	if err := s.inject(ctx); err != nil {
+		return nil, err
+	}
+
+	s.ledger.Release(req.GetReservationId())
+
+	return &pb.ReleaseReservationResponse{}, nil
+}
+
+func (s *Server) ShrinkReservation(ctx context.Context, req *pb.ShrinkReservationRequest) (*pb.ShrinkReservationResponse, error) {
+	if err := s.inject(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := s.ledger.Shrink(req.GetReservationId(), newItemsFromPbItems(req.GetItems())); err != nil {
+		return nil, newStatusError(err)
+	}
+
+	return &pb.ShrinkReservationResponse{}, nil
+}
+
+func (s *Server) GetStocks(ctx context.Context, req *pb.GetStocksRequest) (*pb.GetStocksResponse, error) {
+	if err := s.inject(ctx); err != nil {
+		return nil, err
+	}
+
+	warehouses := s.ledger.Stocks(req.GetSkuIds(), req.GetDeliveryVariantId())
+
+	resp := &pb.GetStocksResponse{
+		Warehouses: make([]*pb.WarehouseStocks, 0, len(warehouses)),
+	}
+	for _, w := range warehouses {
+		resp.Warehouses = append(resp.Warehouses, &pb.WarehouseStocks{
+			WarehouseId:  w.WarehouseID,
+			Stocks:       newPbItemsFromItems(w.Stocks),
+			DistanceKm:   w.DistanceKm,
+			ShippingCost: w.ShippingCost,
+		})
+	}
+	return resp, nil
+}
+
+// inject - задержка и инъекция ошибок
+func (s *Server) inject(ctx context.Context) error {
+	latency := s.cfg.Latency
+	if s.cfg.Jitter > 0 {
+		latency += time.Duration(rand.Int63n(int64(s.cfg.Jitter)))
+	}
+	if latency > 0 {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		}
+	}
+
+	if s.cfg.FailureRate > 0 && rand.Float64() < s.cfg.FailureRate {
+		return status.Error(s.cfg.FailureCode, "fake_wms: injected failure")
+	}
+	return nil
+}
+
+// newStatusError - ошибка Ledger в статус с ErrorDetails по контракту WMS
+func newStatusError(err error) error {
+	var (
+		outOfStock         *OutOfStockError
+		unknownWarehouse   *UnknownWarehouseError
+		unknownReservation *UnknownReservationError
+		increase           *ReservationIncreaseError
+
+		code    codes.Code
+		details *pb.ErrorDetails
+	)
+	switch {
+	case errors.As(err, &outOfStock):
+		code = codes.FailedPrecondition
+		details = &pb.ErrorDetails{
+			Reason:      pb.ErrorReason_ERROR_REASON_OUT_OF_STOCK,
+			SkuId:       outOfStock.SKUID,
+			WarehouseId: outOfStock.WarehouseID,
+		}
+	case errors.As(err, &unknownWarehouse):
+		code = codes.NotFound
+		details = &pb.ErrorDetails{
+			Reason:      pb.ErrorReason_ERROR_REASON_UNKNOWN_WAREHOUSE,
+			WarehouseId: unknownWarehouse.WarehouseID,
+		}
+	case errors.As(err, &unknownReservation):
+		code = codes.NotFound
+		details = &pb.ErrorDetails{
+			Reason: pb.ErrorReason_ERROR_REASON_UNKNOWN_RESERVATION,
+		}
+	case errors.As(err, &increase):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	st, stErr := status.New(code, err.Error()).WithDetails(details)
+	if stErr != nil {
+		return status.Error(code, err.Error())
+	}
+	return st.Err()
+}
+
+func newItemsFromPbItems(items []*pb.Item) []Item {
+	res := make([]Item, 0, len(items))
+	for _, item := range items {
+		res = append(res, Item{
+			SKUID:       item.GetSkuId(),
+			Quantity:    item.GetQuantity(),
+			WarehouseID: item.GetWarehouseId(),
+		})
+	}
+	return res
+}
+
+func newPbItemsFromItems(items []Item) []*pb.Item {
+	res := make([]*pb.Item, 0, len(items))
+	for _, item := range items {
+		res = append(res, &pb.Item{
+			SkuId:       item.SKUID,
+			Quantity:    item.Quantity,
+			WarehouseId: item.WarehouseID,
+		})
+	}
+	return res
+}
@@ -0,0 +1,48 @@
+package actor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/usecases/orders_management_system"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// TypeMetadata - gRPC metadata с типом инициатора запроса (customer, support, ...)
+	TypeMetadata = "x-actor-type"
+	// IDMetadata - gRPC metadata с ID инициатора запроса
+	IDMetadata = "x-actor-id"
+)
+
+// ActorUnaryInterceptor - кладет в контекст инициатора запроса для журнала событий заказа.
+// Заголовки проставляет API gateway после аутентификации; без них запрос считается запросом покупателя
+func ActorUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler,
+	) (interface{}, error) {
+		actor := models.Actor{Type: models.ActorCustomer}
+
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(TypeMetadata); len(values) > 0 && values[0] != "" {
+				actor.Type = models.ActorType(values[0])
+			}
+			if values := md.Get(IDMetadata); len(values) > 0 {
+				actor.ID = values[0]
+			}
+		}
+
+		if !actor.Type.IsValid() {
+			return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("unknown %s %q", TypeMetadata, actor.Type))
+		}
+
+		return handler(orders_management_system.WithActor(ctx, actor), req)
+	}
+}
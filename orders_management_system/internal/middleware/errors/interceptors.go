@@ -3,13 +3,20 @@ package errors
 import (
 	"context"
 	stderrors "errors"
+	"strconv"
+	"strings"
 
 	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
 )
 
+// reservationErrorDomain - ErrorInfo.Domain ошибок резерва стоков
+const reservationErrorDomain = "orders-management-system"
+
 // ErrorsUnaryInterceptor - convert any arror to rpc error
 func ErrorsUnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(
@@ -24,15 +31,97 @@ func ErrorsUnaryInterceptor() grpc.UnaryServerInterceptor {
 			return
 		}
 
+		var reservationErr *models.ReservationError
+		isReservationErr := stderrors.As(err, &reservationErr)
+
 		switch {
 		case stderrors.Is(err, models.ErrAlreadyExists):
 			err = status.Error(codes.AlreadyExists, err.Error())
+		case stderrors.Is(err, models.ErrNotFound):
+			err = status.Error(codes.NotFound, err.Error())
+		case stderrors.Is(err, models.ErrInvalidArgument):
+			err = status.Error(codes.InvalidArgument, err.Error())
+		case stderrors.Is(err, models.ErrInvalidStatusTransition):
+			err = status.Error(codes.FailedPrecondition, err.Error())
+		case stderrors.Is(err, models.ErrIdempotencyKeyReused):
+			err = status.Error(codes.AlreadyExists, err.Error())
+		case stderrors.Is(err, models.ErrBasketChanged):
+			err = status.Error(codes.Aborted, err.Error())
+		case stderrors.Is(err, models.ErrConcurrentModification):
+			err = status.Error(codes.Aborted, err.Error())
+		case stderrors.Is(err, models.ErrOutOfStock):
+			err = status.Error(codes.FailedPrecondition, err.Error())
+		case stderrors.Is(err, models.ErrUnknownWarehouse):
+			err = status.Error(codes.InvalidArgument, err.Error())
+		case stderrors.Is(err, models.ErrUnknownReservation):
+			err = status.Error(codes.FailedPrecondition, err.Error())
+		case stderrors.Is(err, models.ErrPriceNotFound):
+			err = status.Error(codes.FailedPrecondition, err.Error())
+		case stderrors.Is(err, models.ErrCurrencyMismatch):
+			err = status.Error(codes.FailedPrecondition, err.Error())
+		case stderrors.Is(err, models.ErrInvalidPromoCode):
+			err = status.Error(codes.FailedPrecondition, err.Error())
+		case stderrors.Is(err, models.ErrPromoCodeExhausted):
+			err = status.Error(codes.FailedPrecondition, err.Error())
+		case stderrors.Is(err, models.ErrInvalidReturn):
+			err = status.Error(codes.FailedPrecondition, err.Error())
+		case stderrors.Is(err, models.ErrInvalidItemsCancellation):
+			err = status.Error(codes.FailedPrecondition, err.Error())
+		case stderrors.Is(err, models.ErrUnauthenticated):
+			err = status.Error(codes.Unauthenticated, err.Error())
+		case stderrors.Is(err, models.ErrServiceUnavailable):
+			err = status.Error(codes.Unavailable, err.Error())
 		case stderrors.Is(err, models.ErrUnimplemented):
 			err = status.Error(codes.Unimplemented, err.Error())
 		default:
 			err = status.Error(codes.Internal, err.Error())
 		}
 
+		if isReservationErr {
+			// какие склады и SKU не удалось зарезервировать
+			err = withReservationDetails(status.Convert(err), reservationErr)
+		}
+
 		return
 	}
 }
+
+// withReservationDetails - добавляет в статус по ErrorInfo на каждый склад, где не удался резерв
+func withReservationDetails(st *status.Status, reservationErr *models.ReservationError) error {
+	details := make([]protoadapt.MessageV1, 0, len(reservationErr.Failures))
+	for _, f := range reservationErr.Failures {
+		skus := make([]string, 0, len(f.SKUs))
+		for _, sku := range f.SKUs {
+			skus = append(skus, strconv.FormatUint(uint64(sku), 10))
+		}
+
+		details = append(details, &errdetails.ErrorInfo{
+			Reason: reservationFailureReason(f.Err),
+			Domain: reservationErrorDomain,
+			Metadata: map[string]string{
+				"warehouse_id": strconv.FormatUint(uint64(f.WarehouseID), 10),
+				"sku_ids":      strings.Join(skus, ","),
+			},
+		})
+	}
+
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// reservationFailureReason - причина неудачного резерва на складе для ErrorInfo.Reason
+func reservationFailureReason(err error) string {
+	switch {
+	case stderrors.Is(err, models.ErrOutOfStock):
+		return "OUT_OF_STOCK"
+	case stderrors.Is(err, models.ErrUnknownWarehouse):
+		return "UNKNOWN_WAREHOUSE"
+	case stderrors.Is(err, models.ErrServiceUnavailable):
+		return "WAREHOUSE_UNAVAILABLE"
+	default:
+		return "RESERVATION_FAILED"
+	}
+}
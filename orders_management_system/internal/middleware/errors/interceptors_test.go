@@ -0,0 +1,103 @@
+//go:build test
+
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+
+	"github.com/moguchev/microservices_courcse/orders_management_system/internal/app/models"
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestErrorsUnaryInterceptor_ReservationError(t *testing.T) {
+	ctx := context.Background() // dummy
+
+	// errorInfo - ErrorInfo по складу, как его увидит клиент
+	errorInfo := func(reason, warehouseID, skuIDs string) *errdetails.ErrorInfo {
+		return &errdetails.ErrorInfo{
+			Reason: reason,
+			Domain: reservationErrorDomain,
+			Metadata: map[string]string{
+				"warehouse_id": warehouseID,
+				"sku_ids":      skuIDs,
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		err         error
+		wantCode    codes.Code
+		wantDetails []*errdetails.ErrorInfo
+	}{
+		{
+			name: "Test 1. Negative. Out of stock on one warehouse, other warehouse is down.",
+			err: pkgerrors.Wrap("orders_management_system.usecase.CreateOrder", &models.ReservationError{
+				Failures: []models.ReservationFailure{
+					{WarehouseID: 10, SKUs: []models.SKUID{1, 2}, Err: models.ErrOutOfStock},
+					{WarehouseID: 20, SKUs: []models.SKUID{3}, Err: models.ErrServiceUnavailable},
+				},
+			}),
+			wantCode: codes.FailedPrecondition,
+			wantDetails: []*errdetails.ErrorInfo{
+				errorInfo("OUT_OF_STOCK", "10", "1,2"),
+				errorInfo("WAREHOUSE_UNAVAILABLE", "20", "3"),
+			},
+		},
+		{
+			name: "Test 2. Negative. Unknown warehouse.",
+			err: &models.ReservationError{
+				Failures: []models.ReservationFailure{
+					{WarehouseID: 30, SKUs: []models.SKUID{4}, Err: models.ErrUnknownWarehouse},
+				},
+			},
+			wantCode:    codes.InvalidArgument,
+			wantDetails: []*errdetails.ErrorInfo{errorInfo("UNKNOWN_WAREHOUSE", "30", "4")},
+		},
+		{
+			name: "Test 3. Negative. Unexpected WMS error.",
+			err: &models.ReservationError{
+				Failures: []models.ReservationFailure{
+					{WarehouseID: 10, SKUs: []models.SKUID{1}, Err: stderrors.New("boom")},
+				},
+			},
+			wantCode:    codes.Internal,
+			wantDetails: []*errdetails.ErrorInfo{errorInfo("RESERVATION_FAILED", "10", "1")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			handler := func(context.Context, any) (any, error) { return nil, tt.err }
+
+			// act
+			_, err := ErrorsUnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+			// assert
+			st, ok := status.FromError(err)
+			require.True(t, ok, "not a status: %v", err)
+			assert.Equal(t, tt.wantCode, st.Code())
+
+			details := make([]*errdetails.ErrorInfo, 0, len(st.Details()))
+			for _, detail := range st.Details() {
+				info, ok := detail.(*errdetails.ErrorInfo)
+				require.True(t, ok, "unexpected detail: %T", detail)
+				details = append(details, info)
+			}
+			require.Len(t, details, len(tt.wantDetails))
+			for i := range tt.wantDetails {
+				assert.Equal(t, tt.wantDetails[i].GetReason(), details[i].GetReason())
+				assert.Equal(t, tt.wantDetails[i].GetDomain(), details[i].GetDomain())
+				assert.Equal(t, tt.wantDetails[i].GetMetadata(), details[i].GetMetadata())
+			}
+		})
+	}
+}
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS orders_user_id_created_at_id_idx;
+ALTER TABLE orders DROP COLUMN IF EXISTS created_at;
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS orders_status_history;
+DROP INDEX IF EXISTS orders_user_id_status_idx;
+ALTER TABLE orders DROP COLUMN IF EXISTS status_changed_at;
+ALTER TABLE orders DROP COLUMN IF EXISTS status;
\ No newline at end of file
@@ -0,0 +1,12 @@
+-- внешние действия отмены заказов: выполняются после коммита отмены
+-- и повторяются, пока не пройдут
+CREATE TABLE IF NOT EXISTS order_cancellations (
+    order_id uuid PRIMARY KEY,
+    payload jsonb NOT NULL,
+    attempts int4 NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error text NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS order_cancellations_next_attempt_at_idx ON order_cancellations (next_attempt_at);
\ No newline at end of file
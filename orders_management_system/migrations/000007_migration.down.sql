@@ -0,0 +1 @@
+DROP TABLE IF EXISTS orders_idempotency_keys;
\ No newline at end of file
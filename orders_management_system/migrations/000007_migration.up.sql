@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS orders_idempotency_keys (
+    user_id int8 NOT NULL,
+    idempotency_key text NOT NULL,
+    request_hash text NOT NULL,
+    order_id uuid NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, idempotency_key)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE orders_outbox_messages DROP COLUMN IF EXISTS created_at;
+ALTER TABLE orders_outbox_messages DROP COLUMN IF EXISTS payload;
+ALTER TABLE orders_outbox_messages DROP COLUMN IF EXISTS event_type;
+ALTER TABLE orders_outbox_messages DROP COLUMN IF EXISTS event_id;
\ No newline at end of file
@@ -0,0 +1,4 @@
+ALTER TABLE orders_outbox_messages ADD COLUMN IF NOT EXISTS event_id uuid;
+ALTER TABLE orders_outbox_messages ADD COLUMN IF NOT EXISTS event_type text NOT NULL DEFAULT '';
+ALTER TABLE orders_outbox_messages ADD COLUMN IF NOT EXISTS payload bytea;
+ALTER TABLE orders_outbox_messages ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now();
\ No newline at end of file
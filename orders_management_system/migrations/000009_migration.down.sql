@@ -0,0 +1 @@
+DROP TABLE IF EXISTS orders_sagas;
\ No newline at end of file
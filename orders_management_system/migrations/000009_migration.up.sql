@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS orders_sagas (
+    id uuid PRIMARY KEY,
+    name text NOT NULL,
+    payload bytea,
+    status text NOT NULL,
+    completed_steps text[] NOT NULL DEFAULT '{}',
+    error text NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS orders_sagas_unfinished_idx ON orders_sagas (updated_at) WHERE status IN ('running', 'compensating');
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS basket;
\ No newline at end of file
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS basket (
+    user_id int8 NOT NULL,
+    sku_id int8 NOT NULL,
+    quantity int4 NOT NULL CHECK (quantity > 0),
+    warehouse_id int8 NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, sku_id)
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS orders_unpaid_created_at_idx;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS reservation_id;
\ No newline at end of file
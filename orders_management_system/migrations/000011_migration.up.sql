@@ -0,0 +1,5 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS reservation_id uuid;
+
+-- поиск неоплаченных заказов с истекшим резервом (ListUnpaidOrders)
+CREATE INDEX IF NOT EXISTS orders_unpaid_created_at_idx ON orders (created_at)
+    WHERE status IN ('reserved', 'awaiting_payment');
\ No newline at end of file
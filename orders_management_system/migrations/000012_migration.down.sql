@@ -0,0 +1 @@
+DROP TABLE IF EXISTS order_expiry_attempts;
\ No newline at end of file
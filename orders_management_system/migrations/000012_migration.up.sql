@@ -0,0 +1,8 @@
+-- неудачные попытки отменить неоплаченный заказ: заказ, который не удается отменить,
+-- откладывается и не занимает место в пачке ListUnpaidOrders перед остальными
+CREATE TABLE IF NOT EXISTS order_expiry_attempts (
+    order_id uuid PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+    attempts int4 NOT NULL DEFAULT 0,
+    last_attempt_at TIMESTAMPTZ NOT NULL,
+    last_error text NOT NULL DEFAULT ''
+);
\ No newline at end of file
@@ -0,0 +1,6 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS currency,
+    DROP COLUMN IF EXISTS subtotal_amount,
+    DROP COLUMN IF EXISTS discount_amount,
+    DROP COLUMN IF EXISTS delivery_amount,
+    DROP COLUMN IF EXISTS total_amount;
\ No newline at end of file
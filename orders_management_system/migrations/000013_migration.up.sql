@@ -0,0 +1,8 @@
+-- стоимость заказа в минорных единицах валюты currency (снимок цен на момент заказа).
+-- Цены за единицу хранятся в позициях items (price_amount, price_currency)
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS currency text NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS subtotal_amount int8 NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS discount_amount int8 NOT NULL DEFAULT 0 CHECK (discount_amount >= 0),
+    ADD COLUMN IF NOT EXISTS delivery_amount int8 NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS total_amount int8 NOT NULL DEFAULT 0;
\ No newline at end of file
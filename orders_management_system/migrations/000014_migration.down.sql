@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS promo_redemptions_code_user_id_idx;
+DROP TABLE IF EXISTS promo_redemptions;
+DROP TABLE IF EXISTS promo_codes;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS promo_code;
\ No newline at end of file
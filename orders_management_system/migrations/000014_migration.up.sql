@@ -0,0 +1,33 @@
+-- промокоды заводит маркетинг (INSERT INTO promo_codes ...), суммы в минорных единицах валюты currency
+CREATE TABLE IF NOT EXISTS promo_codes (
+    code text PRIMARY KEY,
+    rule_type text NOT NULL CHECK (rule_type IN ('percent_off', 'fixed_off', 'free_delivery', 'n_for_m')),
+    percent_off int4 NOT NULL DEFAULT 0 CHECK (percent_off BETWEEN 0 AND 100),
+    amount_off int8 NOT NULL DEFAULT 0 CHECK (amount_off >= 0),
+    currency text NOT NULL DEFAULT '',
+    sku_id int8 NOT NULL DEFAULT 0,
+    buy_quantity int4 NOT NULL DEFAULT 0,
+    pay_quantity int4 NOT NULL DEFAULT 0 CHECK (pay_quantity <= buy_quantity),
+    per_user_limit int4 NOT NULL DEFAULT 0, -- 0 - без ограничения
+    global_limit int4 NOT NULL DEFAULT 0,   -- 0 - без ограничения
+    redemptions int4 NOT NULL DEFAULT 0,
+    valid_from TIMESTAMPTZ,
+    valid_to TIMESTAMPTZ,
+    active bool NOT NULL DEFAULT true,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- применения промокодов: не больше одного на заказ
+CREATE TABLE IF NOT EXISTS promo_redemptions (
+    order_id uuid PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+    code text NOT NULL REFERENCES promo_codes (code),
+    user_id int8 NOT NULL,
+    discount_amount int8 NOT NULL,
+    currency text NOT NULL,
+    redeemed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- лимит применений на пользователя (CountUserRedemptions)
+CREATE INDEX IF NOT EXISTS promo_redemptions_code_user_id_idx ON promo_redemptions (code, user_id);
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS promo_code text;
\ No newline at end of file
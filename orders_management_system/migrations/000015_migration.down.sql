@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS orders_unpaid_created_at_idx;
+CREATE INDEX IF NOT EXISTS orders_unpaid_created_at_idx ON orders (created_at)
+    WHERE status IN ('reserved', 'awaiting_payment');
+
+DROP INDEX IF EXISTS payments_provider_payment_id_uidx;
+DROP INDEX IF EXISTS payments_order_id_pending_uidx;
+DROP INDEX IF EXISTS payments_order_id_idx;
+DROP TABLE IF EXISTS payments;
\ No newline at end of file
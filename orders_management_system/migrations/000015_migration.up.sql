@@ -0,0 +1,27 @@
+-- платежи по заказам, суммы в минорных единицах валюты currency
+CREATE TABLE IF NOT EXISTS payments (
+    id uuid PRIMARY KEY,
+    order_id uuid NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    amount int8 NOT NULL CHECK (amount >= 0),
+    currency text NOT NULL,
+    method text NOT NULL,
+    status text NOT NULL CHECK (status IN ('pending', 'authorized', 'captured', 'failed', 'voided', 'refunded')),
+    provider_payment_id text,
+    failure_reason text NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- платежи заказа (ListOrderPayments)
+CREATE INDEX IF NOT EXISTS payments_order_id_idx ON payments (order_id, created_at);
+
+-- не больше одного незавершенного платежа на заказ (защита от двойной оплаты)
+CREATE UNIQUE INDEX IF NOT EXISTS payments_order_id_pending_uidx ON payments (order_id)
+    WHERE status IN ('pending', 'authorized');
+
+CREATE UNIQUE INDEX IF NOT EXISTS payments_provider_payment_id_uidx ON payments (provider_payment_id);
+
+-- заказы после неудачной оплаты тоже держат резерв (ListUnpaidOrders)
+DROP INDEX IF EXISTS orders_unpaid_created_at_idx;
+CREATE INDEX IF NOT EXISTS orders_unpaid_created_at_idx ON orders (created_at)
+    WHERE status IN ('reserved', 'awaiting_payment', 'payment_failed');
\ No newline at end of file
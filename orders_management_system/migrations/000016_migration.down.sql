@@ -0,0 +1,4 @@
+ALTER TABLE payments DROP COLUMN IF EXISTS refunded_amount;
+
+DROP INDEX IF EXISTS returns_order_id_idx;
+DROP TABLE IF EXISTS returns;
\ No newline at end of file
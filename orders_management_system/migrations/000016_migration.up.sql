@@ -0,0 +1,20 @@
+-- заявки на возврат товаров доставленных заказов.
+-- Позиции (items) - как в orders.items: склад и цена за единицу на момент заказа
+CREATE TABLE IF NOT EXISTS returns (
+    id uuid PRIMARY KEY,
+    order_id uuid NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    user_id int8 NOT NULL,
+    items jsonb NOT NULL,
+    reason text NOT NULL DEFAULT '',
+    refund_amount int8 NOT NULL CHECK (refund_amount >= 0),
+    currency text NOT NULL,
+    status text NOT NULL CHECK (status IN ('requested', 'approved', 'rejected')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- заявки по заказу (ListOrderReturns)
+CREATE INDEX IF NOT EXISTS returns_order_id_idx ON returns (order_id, created_at);
+
+-- частичные возвраты денег по платежу
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS refunded_amount int8 NOT NULL DEFAULT 0 CHECK (refunded_amount >= 0);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS orders_items_history_immutable ON orders_items_history;
+DROP FUNCTION IF EXISTS orders_items_history_immutable();
+DROP INDEX IF EXISTS orders_items_history_order_id_idx;
+DROP TABLE IF EXISTS orders_items_history;
\ No newline at end of file
@@ -0,0 +1,34 @@
+-- история позиций заказов (SKU на складе): записи только добавляются.
+-- Исходный состав заказа - записи с reason = 'created', дальше - изменения количества
+CREATE TABLE IF NOT EXISTS orders_items_history (
+    id bigserial PRIMARY KEY,
+    order_id uuid NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    sku_id int8 NOT NULL,
+    warehouse_id int8 NOT NULL,
+    price_amount int8 NOT NULL DEFAULT 0,
+    price_currency text NOT NULL DEFAULT '',
+    from_quantity int4 NOT NULL CHECK (from_quantity >= 0),
+    to_quantity int4 NOT NULL CHECK (to_quantity >= 0),
+    reason text NOT NULL CHECK (reason IN ('created', 'cancelled')),
+    changed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS orders_items_history_order_id_idx ON orders_items_history (order_id, id);
+
+-- записи истории не меняются
+CREATE OR REPLACE FUNCTION orders_items_history_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'orders_items_history is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER orders_items_history_immutable
+    BEFORE UPDATE ON orders_items_history
+    FOR EACH ROW EXECUTE FUNCTION orders_items_history_immutable();
+
+-- исходный состав уже созданных заказов
+INSERT INTO orders_items_history (order_id, sku_id, warehouse_id, price_amount, price_currency, from_quantity, to_quantity, reason, changed_at)
+SELECT o.id, i.sku_id, i.warehouse_id, coalesce(i.price_amount, 0), coalesce(i.price_currency, ''), 0, i.quantity, 'created', o.created_at
+FROM orders o
+CROSS JOIN LATERAL json_to_recordset(o.items) AS i(sku_id int8, quantity int4, warehouse_id int8, price_amount int8, price_currency text)
+WHERE o.items IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,17 @@
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS items json;
+
+UPDATE orders o SET items = (
+    SELECT json_agg(json_build_object(
+        'sku_id', i.sku_id,
+        'quantity', i.quantity,
+        'warehouse_id', i.warehouse_id,
+        'price_amount', i.price_amount,
+        'price_currency', i.price_currency
+    ) ORDER BY i.line_no)
+    FROM order_items i
+    WHERE i.order_id = o.id
+);
+
+DROP INDEX IF EXISTS order_items_warehouse_id_sku_id_idx;
+DROP INDEX IF EXISTS order_items_sku_id_idx;
+DROP TABLE IF EXISTS order_items;
\ No newline at end of file
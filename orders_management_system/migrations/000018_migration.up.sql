@@ -0,0 +1,33 @@
+-- позиции заказов: раньше хранились json массивом в orders.items.
+-- line_no (с 1) - порядок позиции в заказе, цена за единицу - на момент заказа
+CREATE TABLE IF NOT EXISTS order_items (
+    order_id uuid NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    line_no int4 NOT NULL CHECK (line_no > 0),
+    sku_id int8 NOT NULL,
+    warehouse_id int8 NOT NULL,
+    quantity int4 NOT NULL CHECK (quantity > 0),
+    price_amount int8 NOT NULL DEFAULT 0 CHECK (price_amount >= 0),
+    price_currency text NOT NULL DEFAULT '',
+    PRIMARY KEY (order_id, line_no)
+);
+
+-- отчеты и поиск заказов по SKU и складу
+CREATE INDEX IF NOT EXISTS order_items_sku_id_idx ON order_items (sku_id);
+CREATE INDEX IF NOT EXISTS order_items_warehouse_id_sku_id_idx ON order_items (warehouse_id, sku_id);
+
+-- переносим позиции существующих заказов (пустые позиции не переносим)
+INSERT INTO order_items (order_id, line_no, sku_id, warehouse_id, quantity, price_amount, price_currency)
+SELECT
+    o.id,
+    i.line_no,
+    (i.item ->> 'sku_id')::int8,
+    (i.item ->> 'warehouse_id')::int8,
+    (i.item ->> 'quantity')::int4,
+    coalesce((i.item ->> 'price_amount')::int8, 0),
+    coalesce(i.item ->> 'price_currency', '')
+FROM orders o
+CROSS JOIN LATERAL json_array_elements(o.items) WITH ORDINALITY AS i(item, line_no)
+WHERE o.items IS NOT NULL AND (i.item ->> 'quantity')::int4 > 0
+ON CONFLICT (order_id, line_no) DO NOTHING;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS items;
\ No newline at end of file
@@ -0,0 +1,16 @@
+ALTER TABLE orders_outbox_messages ADD COLUMN IF NOT EXISTS event_type text NOT NULL DEFAULT '';
+ALTER TABLE orders_outbox_messages ADD COLUMN IF NOT EXISTS payload bytea;
+
+-- возвращаем тип и конверт неопубликованным сообщениям
+UPDATE orders_outbox_messages m
+SET event_type = e.type, payload = e.payload
+FROM order_events e
+WHERE e.id = m.event_id;
+
+ALTER TABLE orders_outbox_messages DROP CONSTRAINT IF EXISTS orders_outbox_messages_event_id_fkey;
+ALTER TABLE orders_outbox_messages ALTER COLUMN event_id DROP NOT NULL;
+
+DROP TRIGGER IF EXISTS order_events_immutable ON order_events;
+DROP FUNCTION IF EXISTS order_events_immutable();
+DROP INDEX IF EXISTS order_events_order_id_idx;
+DROP TABLE IF EXISTS order_events;
\ No newline at end of file
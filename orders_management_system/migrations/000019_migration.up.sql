@@ -0,0 +1,95 @@
+-- журнал доменных событий заказа: единый источник для outbox, аудита и ленты заказа (GetOrderTimeline).
+-- Записи только добавляются, seq - порядок записи событий
+CREATE TABLE IF NOT EXISTS order_events (
+    id uuid PRIMARY KEY,
+    seq bigserial NOT NULL UNIQUE,
+    order_id uuid NOT NULL,
+    type text NOT NULL,
+    version int4 NOT NULL DEFAULT 0,
+    order_status text NOT NULL DEFAULT '',
+    actor_type text NOT NULL DEFAULT 'system' CHECK (actor_type IN ('system', 'customer', 'support', 'payment_provider')),
+    actor_id text NOT NULL DEFAULT '',
+    reason text NOT NULL DEFAULT '',
+    payload bytea, -- pb.EventEnvelope (NULL у событий, восстановленных из истории статусов)
+    occurred_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_events_order_id_idx ON order_events (order_id, seq);
+
+-- события не меняются и не удаляются
+CREATE OR REPLACE FUNCTION order_events_immutable() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'order_events is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER order_events_immutable
+    BEFORE UPDATE OR DELETE ON order_events
+    FOR EACH ROW EXECUTE FUNCTION order_events_immutable();
+
+-- сообщения outbox до 000008 без типа и конверта: их писали только создание и отмена заказа
+UPDATE orders_outbox_messages m
+SET event_type = CASE WHEN l.n = 1 THEN 'OrderCreated' ELSE 'OrderCancelled' END
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY order_id ORDER BY id) AS n
+    FROM orders_outbox_messages
+    WHERE event_type = ''
+) l
+WHERE l.id = m.id;
+
+UPDATE orders_outbox_messages SET event_id = gen_random_uuid() WHERE event_id IS NULL;
+
+-- ленты уже созданных заказов восстанавливаем из истории статусов. Неопубликованное сообщение outbox
+-- - это одно из последних событий своего типа: такое событие получает ID и конверт сообщения.
+-- Сообщения без записи в истории (отмена позиций, возвраты) переносим в журнал отдельными событиями
+WITH history AS (
+    SELECT
+        h.id,
+        h.order_id,
+        CASE
+            WHEN h.from_status IS NULL THEN 'OrderCreated'
+            WHEN h.to_status = 'cancelled' THEN 'OrderCancelled'
+            WHEN h.to_status = 'paid' THEN 'OrderPaid'
+            WHEN h.to_status = 'payment_failed' THEN 'OrderPaymentFailed'
+            ELSE 'OrderStatusChanged'
+        END AS type,
+        h.to_status,
+        h.changed_at
+    FROM orders_status_history h
+), numbered_history AS (
+    SELECT h.*, row_number() OVER (PARTITION BY h.order_id, h.type ORDER BY h.changed_at DESC, h.id DESC) AS n
+    FROM history h
+), numbered_outbox AS (
+    SELECT m.*, row_number() OVER (PARTITION BY m.order_id, m.event_type ORDER BY m.id DESC) AS n
+    FROM orders_outbox_messages m
+)
+INSERT INTO order_events (id, order_id, type, order_status, payload, occurred_at)
+SELECT e.id, e.order_id, e.type, e.order_status, e.payload, e.occurred_at
+FROM (
+    SELECT
+        coalesce(m.event_id, gen_random_uuid()) AS id,
+        h.order_id,
+        h.type,
+        h.to_status AS order_status,
+        m.payload,
+        h.changed_at AS occurred_at,
+        h.id AS history_id,
+        NULL::int4 AS outbox_id
+    FROM numbered_history h
+    LEFT JOIN numbered_outbox m ON m.order_id = h.order_id AND m.event_type = h.type AND m.n = h.n
+    UNION ALL
+    SELECT m.event_id, m.order_id, m.event_type, '', m.payload, m.created_at, NULL, m.id
+    FROM numbered_outbox m
+    WHERE NOT EXISTS (
+        SELECT 1 FROM numbered_history h
+        WHERE h.order_id = m.order_id AND h.type = m.event_type AND h.n = m.n
+    )
+) e
+ORDER BY e.occurred_at, e.history_id, e.outbox_id;
+
+-- outbox хранит только ссылку на событие журнала
+ALTER TABLE orders_outbox_messages ALTER COLUMN event_id SET NOT NULL;
+ALTER TABLE orders_outbox_messages
+    ADD CONSTRAINT orders_outbox_messages_event_id_fkey FOREIGN KEY (event_id) REFERENCES order_events (id);
+ALTER TABLE orders_outbox_messages DROP COLUMN IF EXISTS payload;
+ALTER TABLE orders_outbox_messages DROP COLUMN IF EXISTS event_type;
\ No newline at end of file
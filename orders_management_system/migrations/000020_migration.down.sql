@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS order_projections;
+DROP TABLE IF EXISTS order_snapshots;
+ALTER TABLE orders DROP COLUMN IF EXISTS version;
+DROP INDEX IF EXISTS order_events_order_id_stream_version_idx;
+ALTER TABLE order_events DROP COLUMN IF EXISTS data;
+ALTER TABLE order_events DROP COLUMN IF EXISTS stream_version;
\ No newline at end of file
@@ -0,0 +1,88 @@
+-- event sourcing заказов: номер события в потоке заказа (optimistic concurrency),
+-- изменение состояния в событии (data) и периодические снимки состояния (order_snapshots)
+ALTER TABLE order_events ADD COLUMN IF NOT EXISTS stream_version int8;
+ALTER TABLE order_events ADD COLUMN IF NOT EXISTS data jsonb; -- NULL у событий, записанных до event sourcing
+
+-- нумеруем потоки уже записанных событий (журнал неизменяемый - триггер на время миграции выключаем)
+ALTER TABLE order_events DISABLE TRIGGER order_events_immutable;
+
+UPDATE order_events e
+SET stream_version = v.stream_version
+FROM (
+    SELECT id, row_number() OVER (PARTITION BY order_id ORDER BY seq) AS stream_version
+    FROM order_events
+) v
+WHERE v.id = e.id;
+
+ALTER TABLE order_events ENABLE TRIGGER order_events_immutable;
+
+ALTER TABLE order_events ALTER COLUMN stream_version SET NOT NULL;
+
+-- два события с одной версией в потоке - параллельное изменение заказа
+CREATE UNIQUE INDEX IF NOT EXISTS order_events_order_id_stream_version_idx ON order_events (order_id, stream_version);
+
+-- версия заказа в read model - номер последнего примененного события
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS version int8 NOT NULL DEFAULT 0;
+
+UPDATE orders o
+SET version = v.version
+FROM (SELECT order_id, max(stream_version) AS version FROM order_events GROUP BY order_id) v
+WHERE v.order_id = o.id;
+
+-- снимки состояния заказа после события stream_version = version
+CREATE TABLE IF NOT EXISTS order_snapshots (
+    order_id uuid NOT NULL,
+    version int8 NOT NULL,
+    state jsonb NOT NULL,
+    occurred_at TIMESTAMPTZ NOT NULL, -- время события, после которого сделан снимок
+    PRIMARY KEY (order_id, version)
+);
+
+-- события до event sourcing не восстанавливаются: снимок текущего состояния уже созданных заказов
+INSERT INTO order_snapshots (order_id, version, state, occurred_at)
+SELECT
+    o.id,
+    o.version,
+    json_build_object(
+        'id', o.id,
+        'user_id', o.user_id,
+        'delivery_variant_id', coalesce(o.delivery_variant_id, 0),
+        'delivery_date', o.delivery_date,
+        'currency', o.currency,
+        'subtotal_amount', o.subtotal_amount,
+        'discount_amount', o.discount_amount,
+        'delivery_amount', o.delivery_amount,
+        'total_amount', o.total_amount,
+        'promo_code', coalesce(o.promo_code, ''),
+        'status', o.status,
+        'reservation_id', o.reservation_id,
+        'status_changed_at', o.status_changed_at,
+        'created_at', o.created_at,
+        'version', o.version,
+        'items', coalesce((
+            SELECT json_agg(json_build_object(
+                'sku_id', i.sku_id,
+                'quantity', i.quantity,
+                'warehouse_id', i.warehouse_id,
+                'price_amount', i.price_amount,
+                'price_currency', i.price_currency
+            ) ORDER BY i.line_no)
+            FROM order_items i
+            WHERE i.order_id = o.id
+        ), '[]'::json)
+    ),
+    coalesce((SELECT max(e.occurred_at) FROM order_events e WHERE e.order_id = o.id), o.status_changed_at)
+FROM orders o
+WHERE o.version > 0;
+
+-- позиция проекций журнала (read model orders обновляет воркер проекции)
+CREATE TABLE IF NOT EXISTS order_projections (
+    name text PRIMARY KEY,
+    last_seq int8 NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- текущая read model уже отражает весь журнал
+INSERT INTO order_projections (name, last_seq)
+SELECT 'orders', coalesce(max(seq), 0) FROM order_events
+ON CONFLICT (name) DO NOTHING;
\ No newline at end of file
@@ -19,7 +19,25 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	OrdersManagementSystemService_CreateOrder_FullMethodName = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/CreateOrder"
+	OrdersManagementSystemService_CreateOrder_FullMethodName           = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/CreateOrder"
+	OrdersManagementSystemService_GetOrder_FullMethodName              = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/GetOrder"
+	OrdersManagementSystemService_ListOrders_FullMethodName            = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/ListOrders"
+	OrdersManagementSystemService_CancelOrder_FullMethodName           = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/CancelOrder"
+	OrdersManagementSystemService_CancelOrderItems_FullMethodName      = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/CancelOrderItems"
+	OrdersManagementSystemService_GetOrderItemsHistory_FullMethodName  = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/GetOrderItemsHistory"
+	OrdersManagementSystemService_GetOrderTimeline_FullMethodName      = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/GetOrderTimeline"
+	OrdersManagementSystemService_GetOrderAt_FullMethodName            = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/GetOrderAt"
+	OrdersManagementSystemService_AddToBasket_FullMethodName           = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/AddToBasket"
+	OrdersManagementSystemService_UpdateBasketItem_FullMethodName      = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/UpdateBasketItem"
+	OrdersManagementSystemService_RemoveFromBasket_FullMethodName      = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/RemoveFromBasket"
+	OrdersManagementSystemService_GetBasket_FullMethodName             = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/GetBasket"
+	OrdersManagementSystemService_CreateOrderFromBasket_FullMethodName = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/CreateOrderFromBasket"
+	OrdersManagementSystemService_PreviewOrder_FullMethodName          = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/PreviewOrder"
+	OrdersManagementSystemService_ApplyPromoCode_FullMethodName        = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/ApplyPromoCode"
+	OrdersManagementSystemService_PayOrder_FullMethodName              = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/PayOrder"
+	OrdersManagementSystemService_HandlePaymentCallback_FullMethodName = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/HandlePaymentCallback"
+	OrdersManagementSystemService_RequestReturn_FullMethodName         = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/RequestReturn"
+	OrdersManagementSystemService_ApproveReturn_FullMethodName         = "/github.com.moguchev.microservices.orders_management_system.OrdersManagementSystemService/ApproveReturn"
 )
 
 // OrdersManagementSystemServiceClient is the client API for OrdersManagementSystemService service.
@@ -28,6 +46,49 @@ const (
 type OrdersManagementSystemServiceClient interface {
 	// CreateOrder - метод создания заказа
 	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*CreateOrderResponse, error)
+	// GetOrder - метод получения заказа
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error)
+	// ListOrders - метод получения списка заказов пользователя
+	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	// CancelOrder - метод отмены заказа
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error)
+	// CancelOrderItems - метод отмены части товаров заказа: резерв на складах уменьшается,
+	// стоимость заказа пересчитывается, за оплаченный заказ разница возвращается покупателю
+	CancelOrderItems(ctx context.Context, in *CancelOrderItemsRequest, opts ...grpc.CallOption) (*CancelOrderItemsResponse, error)
+	// GetOrderItemsHistory - метод получения истории позиций заказа: исходный состав и все изменения
+	GetOrderItemsHistory(ctx context.Context, in *GetOrderItemsHistoryRequest, opts ...grpc.CallOption) (*GetOrderItemsHistoryResponse, error)
+	// GetOrderTimeline - метод получения ленты событий заказа: кто, когда и почему менял заказ
+	GetOrderTimeline(ctx context.Context, in *GetOrderTimelineRequest, opts ...grpc.CallOption) (*GetOrderTimelineResponse, error)
+	// GetOrderAt - метод получения заказа в состоянии на момент времени (для разбора споров):
+	// заказ восстанавливается из журнала событий
+	GetOrderAt(ctx context.Context, in *GetOrderAtRequest, opts ...grpc.CallOption) (*GetOrderAtResponse, error)
+	// AddToBasket - метод добавления товара в корзину
+	AddToBasket(ctx context.Context, in *AddToBasketRequest, opts ...grpc.CallOption) (*AddToBasketResponse, error)
+	// UpdateBasketItem - метод изменения позиции корзины
+	UpdateBasketItem(ctx context.Context, in *UpdateBasketItemRequest, opts ...grpc.CallOption) (*UpdateBasketItemResponse, error)
+	// RemoveFromBasket - метод удаления товара из корзины
+	RemoveFromBasket(ctx context.Context, in *RemoveFromBasketRequest, opts ...grpc.CallOption) (*RemoveFromBasketResponse, error)
+	// GetBasket - метод получения корзины
+	GetBasket(ctx context.Context, in *GetBasketRequest, opts ...grpc.CallOption) (*GetBasketResponse, error)
+	// CreateOrderFromBasket - метод создания заказа из корзины
+	CreateOrderFromBasket(ctx context.Context, in *CreateOrderFromBasketRequest, opts ...grpc.CallOption) (*CreateOrderFromBasketResponse, error)
+	// PreviewOrder - метод расчета стоимости заказа до его создания
+	PreviewOrder(ctx context.Context, in *PreviewOrderRequest, opts ...grpc.CallOption) (*PreviewOrderResponse, error)
+	// ApplyPromoCode - метод проверки промокода: скидка и стоимость заказа с ней.
+	// Промокод не погашается - это происходит при создании заказа с promo_code
+	ApplyPromoCode(ctx context.Context, in *ApplyPromoCodeRequest, opts ...grpc.CallOption) (*ApplyPromoCodeResponse, error)
+	// PayOrder - метод оплаты заказа. Результат авторизации приходит от провайдера в HandlePaymentCallback:
+	// заказ переходит в ORDER_STATUS_PAID или ORDER_STATUS_PAYMENT_FAILED
+	PayOrder(ctx context.Context, in *PayOrderRequest, opts ...grpc.CallOption) (*PayOrderResponse, error)
+	// HandlePaymentCallback - уведомление платежного провайдера о результате авторизации (webhook).
+	// Уведомление без подписи провайдера отклоняется (UNAUTHENTICATED).
+	// Повторное уведомление по уже обработанному платежу ничего не меняет
+	HandlePaymentCallback(ctx context.Context, in *HandlePaymentCallbackRequest, opts ...grpc.CallOption) (*HandlePaymentCallbackResponse, error)
+	// RequestReturn - метод оформления возврата части товаров доставленного заказа.
+	// Сумма к возврату считается по ценам на момент заказа
+	RequestReturn(ctx context.Context, in *RequestReturnRequest, opts ...grpc.CallOption) (*RequestReturnResponse, error)
+	// ApproveReturn - метод одобрения возврата: деньги возвращаются покупателю, товары - на склад
+	ApproveReturn(ctx context.Context, in *ApproveReturnRequest, opts ...grpc.CallOption) (*ApproveReturnResponse, error)
 }
 
 type ordersManagementSystemServiceClient struct {
@@ -47,12 +108,217 @@ func (c *ordersManagementSystemServiceClient) CreateOrder(ctx context.Context, i
 	return out, nil
 }
 
+func (c *ordersManagementSystemServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*GetOrderResponse, error) {
+	out := new(GetOrderResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_GetOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_ListOrders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*CancelOrderResponse, error) {
+	out := new(CancelOrderResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_CancelOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) CancelOrderItems(ctx context.Context, in *CancelOrderItemsRequest, opts ...grpc.CallOption) (*CancelOrderItemsResponse, error) {
+	out := new(CancelOrderItemsResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_CancelOrderItems_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) GetOrderItemsHistory(ctx context.Context, in *GetOrderItemsHistoryRequest, opts ...grpc.CallOption) (*GetOrderItemsHistoryResponse, error) {
+	out := new(GetOrderItemsHistoryResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_GetOrderItemsHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) GetOrderTimeline(ctx context.Context, in *GetOrderTimelineRequest, opts ...grpc.CallOption) (*GetOrderTimelineResponse, error) {
+	out := new(GetOrderTimelineResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_GetOrderTimeline_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) GetOrderAt(ctx context.Context, in *GetOrderAtRequest, opts ...grpc.CallOption) (*GetOrderAtResponse, error) {
+	out := new(GetOrderAtResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_GetOrderAt_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) AddToBasket(ctx context.Context, in *AddToBasketRequest, opts ...grpc.CallOption) (*AddToBasketResponse, error) {
+	out := new(AddToBasketResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_AddToBasket_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) UpdateBasketItem(ctx context.Context, in *UpdateBasketItemRequest, opts ...grpc.CallOption) (*UpdateBasketItemResponse, error) {
+	out := new(UpdateBasketItemResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_UpdateBasketItem_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) RemoveFromBasket(ctx context.Context, in *RemoveFromBasketRequest, opts ...grpc.CallOption) (*RemoveFromBasketResponse, error) {
+	out := new(RemoveFromBasketResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_RemoveFromBasket_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) GetBasket(ctx context.Context, in *GetBasketRequest, opts ...grpc.CallOption) (*GetBasketResponse, error) {
+	out := new(GetBasketResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_GetBasket_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) CreateOrderFromBasket(ctx context.Context, in *CreateOrderFromBasketRequest, opts ...grpc.CallOption) (*CreateOrderFromBasketResponse, error) {
+	out := new(CreateOrderFromBasketResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_CreateOrderFromBasket_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) PreviewOrder(ctx context.Context, in *PreviewOrderRequest, opts ...grpc.CallOption) (*PreviewOrderResponse, error) {
+	out := new(PreviewOrderResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_PreviewOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) ApplyPromoCode(ctx context.Context, in *ApplyPromoCodeRequest, opts ...grpc.CallOption) (*ApplyPromoCodeResponse, error) {
+	out := new(ApplyPromoCodeResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_ApplyPromoCode_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) PayOrder(ctx context.Context, in *PayOrderRequest, opts ...grpc.CallOption) (*PayOrderResponse, error) {
+	out := new(PayOrderResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_PayOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) HandlePaymentCallback(ctx context.Context, in *HandlePaymentCallbackRequest, opts ...grpc.CallOption) (*HandlePaymentCallbackResponse, error) {
+	out := new(HandlePaymentCallbackResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_HandlePaymentCallback_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) RequestReturn(ctx context.Context, in *RequestReturnRequest, opts ...grpc.CallOption) (*RequestReturnResponse, error) {
+	out := new(RequestReturnResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_RequestReturn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ordersManagementSystemServiceClient) ApproveReturn(ctx context.Context, in *ApproveReturnRequest, opts ...grpc.CallOption) (*ApproveReturnResponse, error) {
+	out := new(ApproveReturnResponse)
+	err := c.cc.Invoke(ctx, OrdersManagementSystemService_ApproveReturn_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OrdersManagementSystemServiceServer is the server API for OrdersManagementSystemService service.
 // All implementations must embed UnimplementedOrdersManagementSystemServiceServer
 // for forward compatibility
 type OrdersManagementSystemServiceServer interface {
 	// CreateOrder - метод создания заказа
 	CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
+	// GetOrder - метод получения заказа
+	GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error)
+	// ListOrders - метод получения списка заказов пользователя
+	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	// CancelOrder - метод отмены заказа
+	CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error)
+	// CancelOrderItems - метод отмены части товаров заказа: резерв на складах уменьшается,
+	// стоимость заказа пересчитывается, за оплаченный заказ разница возвращается покупателю
+	CancelOrderItems(context.Context, *CancelOrderItemsRequest) (*CancelOrderItemsResponse, error)
+	// GetOrderItemsHistory - метод получения истории позиций заказа: исходный состав и все изменения
+	GetOrderItemsHistory(context.Context, *GetOrderItemsHistoryRequest) (*GetOrderItemsHistoryResponse, error)
+	// GetOrderTimeline - метод получения ленты событий заказа: кто, когда и почему менял заказ
+	GetOrderTimeline(context.Context, *GetOrderTimelineRequest) (*GetOrderTimelineResponse, error)
+	// GetOrderAt - метод получения заказа в состоянии на момент времени (для разбора споров):
+	// заказ восстанавливается из журнала событий
+	GetOrderAt(context.Context, *GetOrderAtRequest) (*GetOrderAtResponse, error)
+	// AddToBasket - метод добавления товара в корзину
+	AddToBasket(context.Context, *AddToBasketRequest) (*AddToBasketResponse, error)
+	// UpdateBasketItem - метод изменения позиции корзины
+	UpdateBasketItem(context.Context, *UpdateBasketItemRequest) (*UpdateBasketItemResponse, error)
+	// RemoveFromBasket - метод удаления товара из корзины
+	RemoveFromBasket(context.Context, *RemoveFromBasketRequest) (*RemoveFromBasketResponse, error)
+	// GetBasket - метод получения корзины
+	GetBasket(context.Context, *GetBasketRequest) (*GetBasketResponse, error)
+	// CreateOrderFromBasket - метод создания заказа из корзины
+	CreateOrderFromBasket(context.Context, *CreateOrderFromBasketRequest) (*CreateOrderFromBasketResponse, error)
+	// PreviewOrder - метод расчета стоимости заказа до его создания
+	PreviewOrder(context.Context, *PreviewOrderRequest) (*PreviewOrderResponse, error)
+	// ApplyPromoCode - метод проверки промокода: скидка и стоимость заказа с ней.
+	// Промокод не погашается - это происходит при создании заказа с promo_code
+	ApplyPromoCode(context.Context, *ApplyPromoCodeRequest) (*ApplyPromoCodeResponse, error)
+	// PayOrder - метод оплаты заказа. Результат авторизации приходит от провайдера в HandlePaymentCallback:
+	// заказ переходит в ORDER_STATUS_PAID или ORDER_STATUS_PAYMENT_FAILED
+	PayOrder(context.Context, *PayOrderRequest) (*PayOrderResponse, error)
+	// HandlePaymentCallback - уведомление платежного провайдера о результате авторизации (webhook).
+	// Уведомление без подписи провайдера отклоняется (UNAUTHENTICATED).
+	// Повторное уведомление по уже обработанному платежу ничего не меняет
+	HandlePaymentCallback(context.Context, *HandlePaymentCallbackRequest) (*HandlePaymentCallbackResponse, error)
+	// RequestReturn - метод оформления возврата части товаров доставленного заказа.
+	// Сумма к возврату считается по ценам на момент заказа
+	RequestReturn(context.Context, *RequestReturnRequest) (*RequestReturnResponse, error)
+	// ApproveReturn - метод одобрения возврата: деньги возвращаются покупателю, товары - на склад
+	ApproveReturn(context.Context, *ApproveReturnRequest) (*ApproveReturnResponse, error)
 	mustEmbedUnimplementedOrdersManagementSystemServiceServer()
 }
 
@@ -63,6 +329,60 @@ type UnimplementedOrdersManagementSystemServiceServer struct {
 func (UnimplementedOrdersManagementSystemServiceServer) CreateOrder(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
 }
+func (UnimplementedOrdersManagementSystemServiceServer) GetOrder(context.Context, *GetOrderRequest) (*GetOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*CancelOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) CancelOrderItems(context.Context, *CancelOrderItemsRequest) (*CancelOrderItemsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrderItems not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) GetOrderItemsHistory(context.Context, *GetOrderItemsHistoryRequest) (*GetOrderItemsHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderItemsHistory not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) GetOrderTimeline(context.Context, *GetOrderTimelineRequest) (*GetOrderTimelineResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderTimeline not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) GetOrderAt(context.Context, *GetOrderAtRequest) (*GetOrderAtResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrderAt not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) AddToBasket(context.Context, *AddToBasketRequest) (*AddToBasketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddToBasket not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) UpdateBasketItem(context.Context, *UpdateBasketItemRequest) (*UpdateBasketItemResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBasketItem not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) RemoveFromBasket(context.Context, *RemoveFromBasketRequest) (*RemoveFromBasketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromBasket not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) GetBasket(context.Context, *GetBasketRequest) (*GetBasketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBasket not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) CreateOrderFromBasket(context.Context, *CreateOrderFromBasketRequest) (*CreateOrderFromBasketResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrderFromBasket not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) PreviewOrder(context.Context, *PreviewOrderRequest) (*PreviewOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewOrder not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) ApplyPromoCode(context.Context, *ApplyPromoCodeRequest) (*ApplyPromoCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApplyPromoCode not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) PayOrder(context.Context, *PayOrderRequest) (*PayOrderResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PayOrder not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) HandlePaymentCallback(context.Context, *HandlePaymentCallbackRequest) (*HandlePaymentCallbackResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandlePaymentCallback not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) RequestReturn(context.Context, *RequestReturnRequest) (*RequestReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestReturn not implemented")
+}
+func (UnimplementedOrdersManagementSystemServiceServer) ApproveReturn(context.Context, *ApproveReturnRequest) (*ApproveReturnResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveReturn not implemented")
+}
 func (UnimplementedOrdersManagementSystemServiceServer) mustEmbedUnimplementedOrdersManagementSystemServiceServer() {
 }
 
@@ -95,6 +415,330 @@ func _OrdersManagementSystemService_CreateOrder_Handler(srv interface{}, ctx con
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrdersManagementSystemService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).ListOrders(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_ListOrders_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).ListOrders(ctx, req.(*ListOrdersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_CancelOrderItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).CancelOrderItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_CancelOrderItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).CancelOrderItems(ctx, req.(*CancelOrderItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_GetOrderItemsHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderItemsHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).GetOrderItemsHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_GetOrderItemsHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).GetOrderItemsHistory(ctx, req.(*GetOrderItemsHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_GetOrderTimeline_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderTimelineRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).GetOrderTimeline(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_GetOrderTimeline_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).GetOrderTimeline(ctx, req.(*GetOrderTimelineRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_GetOrderAt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderAtRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).GetOrderAt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_GetOrderAt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).GetOrderAt(ctx, req.(*GetOrderAtRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_AddToBasket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddToBasketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).AddToBasket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_AddToBasket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).AddToBasket(ctx, req.(*AddToBasketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_UpdateBasketItem_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBasketItemRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).UpdateBasketItem(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_UpdateBasketItem_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).UpdateBasketItem(ctx, req.(*UpdateBasketItemRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_RemoveFromBasket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFromBasketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).RemoveFromBasket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_RemoveFromBasket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).RemoveFromBasket(ctx, req.(*RemoveFromBasketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_GetBasket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBasketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).GetBasket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_GetBasket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).GetBasket(ctx, req.(*GetBasketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_CreateOrderFromBasket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderFromBasketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).CreateOrderFromBasket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_CreateOrderFromBasket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).CreateOrderFromBasket(ctx, req.(*CreateOrderFromBasketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_PreviewOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).PreviewOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_PreviewOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).PreviewOrder(ctx, req.(*PreviewOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_ApplyPromoCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyPromoCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).ApplyPromoCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_ApplyPromoCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).ApplyPromoCode(ctx, req.(*ApplyPromoCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_PayOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PayOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).PayOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_PayOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).PayOrder(ctx, req.(*PayOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_HandlePaymentCallback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HandlePaymentCallbackRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).HandlePaymentCallback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_HandlePaymentCallback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).HandlePaymentCallback(ctx, req.(*HandlePaymentCallbackRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_RequestReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).RequestReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_RequestReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).RequestReturn(ctx, req.(*RequestReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrdersManagementSystemService_ApproveReturn_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApproveReturnRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrdersManagementSystemServiceServer).ApproveReturn(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrdersManagementSystemService_ApproveReturn_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrdersManagementSystemServiceServer).ApproveReturn(ctx, req.(*ApproveReturnRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OrdersManagementSystemService_ServiceDesc is the grpc.ServiceDesc for OrdersManagementSystemService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -106,6 +750,78 @@ var OrdersManagementSystemService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CreateOrder",
 			Handler:    _OrdersManagementSystemService_CreateOrder_Handler,
 		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _OrdersManagementSystemService_GetOrder_Handler,
+		},
+		{
+			MethodName: "ListOrders",
+			Handler:    _OrdersManagementSystemService_ListOrders_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrdersManagementSystemService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrderItems",
+			Handler:    _OrdersManagementSystemService_CancelOrderItems_Handler,
+		},
+		{
+			MethodName: "GetOrderItemsHistory",
+			Handler:    _OrdersManagementSystemService_GetOrderItemsHistory_Handler,
+		},
+		{
+			MethodName: "GetOrderTimeline",
+			Handler:    _OrdersManagementSystemService_GetOrderTimeline_Handler,
+		},
+		{
+			MethodName: "GetOrderAt",
+			Handler:    _OrdersManagementSystemService_GetOrderAt_Handler,
+		},
+		{
+			MethodName: "AddToBasket",
+			Handler:    _OrdersManagementSystemService_AddToBasket_Handler,
+		},
+		{
+			MethodName: "UpdateBasketItem",
+			Handler:    _OrdersManagementSystemService_UpdateBasketItem_Handler,
+		},
+		{
+			MethodName: "RemoveFromBasket",
+			Handler:    _OrdersManagementSystemService_RemoveFromBasket_Handler,
+		},
+		{
+			MethodName: "GetBasket",
+			Handler:    _OrdersManagementSystemService_GetBasket_Handler,
+		},
+		{
+			MethodName: "CreateOrderFromBasket",
+			Handler:    _OrdersManagementSystemService_CreateOrderFromBasket_Handler,
+		},
+		{
+			MethodName: "PreviewOrder",
+			Handler:    _OrdersManagementSystemService_PreviewOrder_Handler,
+		},
+		{
+			MethodName: "ApplyPromoCode",
+			Handler:    _OrdersManagementSystemService_ApplyPromoCode_Handler,
+		},
+		{
+			MethodName: "PayOrder",
+			Handler:    _OrdersManagementSystemService_PayOrder_Handler,
+		},
+		{
+			MethodName: "HandlePaymentCallback",
+			Handler:    _OrdersManagementSystemService_HandlePaymentCallback_Handler,
+		},
+		{
+			MethodName: "RequestReturn",
+			Handler:    _OrdersManagementSystemService_RequestReturn_Handler,
+		},
+		{
+			MethodName: "ApproveReturn",
+			Handler:    _OrdersManagementSystemService_ApproveReturn_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/orders_management_system/service.proto",
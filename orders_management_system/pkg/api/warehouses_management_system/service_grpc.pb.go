@@ -0,0 +1,272 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             v5.26.1
+// source: api/warehouses_management_system/service.proto
+
+package warehouses_management_system
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	WarehousesManagementSystemService_ReserveStocks_FullMethodName      = "/github.com.moguchev.microservices.warehouses_management_system.WarehousesManagementSystemService/ReserveStocks"
+	WarehousesManagementSystemService_ConfirmReservation_FullMethodName = "/github.com.moguchev.microservices.warehouses_management_system.WarehousesManagementSystemService/ConfirmReservation"
+	WarehousesManagementSystemService_ReleaseReservation_FullMethodName = "/github.com.moguchev.microservices.warehouses_management_system.WarehousesManagementSystemService/ReleaseReservation"
+	WarehousesManagementSystemService_ShrinkReservation_FullMethodName  = "/github.com.moguchev.microservices.warehouses_management_system.WarehousesManagementSystemService/ShrinkReservation"
+	WarehousesManagementSystemService_GetStocks_FullMethodName          = "/github.com.moguchev.microservices.warehouses_management_system.WarehousesManagementSystemService/GetStocks"
+)
+
+// WarehousesManagementSystemServiceClient is the client API for WarehousesManagementSystemService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type WarehousesManagementSystemServiceClient interface {
+	// ReserveStocks - резервирование стоков на складах (все или ничего). Идемпотентен по reservation_id
+	ReserveStocks(ctx context.Context, in *ReserveStocksRequest, opts ...grpc.CallOption) (*ReserveStocksResponse, error)
+	// ConfirmReservation - подтверждение резерва: сток списывается под заказ
+	ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error)
+	// ReleaseReservation - снятие резерва (в том числе подтвержденного): сток возвращается на склад.
+	// Снятие несуществующего резерва - не ошибка
+	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error)
+	// ShrinkReservation - уменьшение резерва (в том числе подтвержденного) до items: сток сверх items
+	// возвращается на склад. Идемпотентен: повтор с теми же items ничего не меняет. Увеличить резерв нельзя
+	ShrinkReservation(ctx context.Context, in *ShrinkReservationRequest, opts ...grpc.CallOption) (*ShrinkReservationResponse, error)
+	// GetStocks - доступные (не зарезервированные) стоки SKU по складам
+	GetStocks(ctx context.Context, in *GetStocksRequest, opts ...grpc.CallOption) (*GetStocksResponse, error)
+}
+
+type warehousesManagementSystemServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewWarehousesManagementSystemServiceClient(cc grpc.ClientConnInterface) WarehousesManagementSystemServiceClient {
+	return &warehousesManagementSystemServiceClient{cc}
+}
+
+func (c *warehousesManagementSystemServiceClient) ReserveStocks(ctx context.Context, in *ReserveStocksRequest, opts ...grpc.CallOption) (*ReserveStocksResponse, error) {
+	out := new(ReserveStocksResponse)
+	err := c.cc.Invoke(ctx, WarehousesManagementSystemService_ReserveStocks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehousesManagementSystemServiceClient) ConfirmReservation(ctx context.Context, in *ConfirmReservationRequest, opts ...grpc.CallOption) (*ConfirmReservationResponse, error) {
+	out := new(ConfirmReservationResponse)
+	err := c.cc.Invoke(ctx, WarehousesManagementSystemService_ConfirmReservation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehousesManagementSystemServiceClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error) {
+	out := new(ReleaseReservationResponse)
+	err := c.cc.Invoke(ctx, WarehousesManagementSystemService_ReleaseReservation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehousesManagementSystemServiceClient) ShrinkReservation(ctx context.Context, in *ShrinkReservationRequest, opts ...grpc.CallOption) (*ShrinkReservationResponse, error) {
+	out := new(ShrinkReservationResponse)
+	err := c.cc.Invoke(ctx, WarehousesManagementSystemService_ShrinkReservation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *warehousesManagementSystemServiceClient) GetStocks(ctx context.Context, in *GetStocksRequest, opts ...grpc.CallOption) (*GetStocksResponse, error) {
+	out := new(GetStocksResponse)
+	err := c.cc.Invoke(ctx, WarehousesManagementSystemService_GetStocks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// WarehousesManagementSystemServiceServer is the server API for WarehousesManagementSystemService service.
+// All implementations must embed UnimplementedWarehousesManagementSystemServiceServer
+// for forward compatibility
+type WarehousesManagementSystemServiceServer interface {
+	// ReserveStocks - резервирование стоков на складах (все или ничего). Идемпотентен по reservation_id
+	ReserveStocks(context.Context, *ReserveStocksRequest) (*ReserveStocksResponse, error)
+	// ConfirmReservation - подтверждение резерва: сток списывается под заказ
+	ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error)
+	// ReleaseReservation - снятие резерва (в том числе подтвержденного): сток возвращается на склад.
+	// Снятие несуществующего резерва - не ошибка
+	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
+	// ShrinkReservation - уменьшение резерва (в том числе подтвержденного) до items: сток сверх items
+	// возвращается на склад. Идемпотентен: повтор с теми же items ничего не меняет. Увеличить резерв нельзя
+	ShrinkReservation(context.Context, *ShrinkReservationRequest) (*ShrinkReservationResponse, error)
+	// GetStocks - доступные (не зарезервированные) стоки SKU по складам
+	GetStocks(context.Context, *GetStocksRequest) (*GetStocksResponse, error)
+	mustEmbedUnimplementedWarehousesManagementSystemServiceServer()
+}
+
+// UnimplementedWarehousesManagementSystemServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedWarehousesManagementSystemServiceServer struct {
+}
+
+func (UnimplementedWarehousesManagementSystemServiceServer) ReserveStocks(context.Context, *ReserveStocksRequest) (*ReserveStocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReserveStocks not implemented")
+}
+func (UnimplementedWarehousesManagementSystemServiceServer) ConfirmReservation(context.Context, *ConfirmReservationRequest) (*ConfirmReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmReservation not implemented")
+}
+func (UnimplementedWarehousesManagementSystemServiceServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedWarehousesManagementSystemServiceServer) ShrinkReservation(context.Context, *ShrinkReservationRequest) (*ShrinkReservationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ShrinkReservation not implemented")
+}
+func (UnimplementedWarehousesManagementSystemServiceServer) GetStocks(context.Context, *GetStocksRequest) (*GetStocksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetStocks not implemented")
+}
+func (UnimplementedWarehousesManagementSystemServiceServer) mustEmbedUnimplementedWarehousesManagementSystemServiceServer() {
+}
+
+// UnsafeWarehousesManagementSystemServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to WarehousesManagementSystemServiceServer will
+// result in compilation errors.
+type UnsafeWarehousesManagementSystemServiceServer interface {
+	mustEmbedUnimplementedWarehousesManagementSystemServiceServer()
+}
+
+func RegisterWarehousesManagementSystemServiceServer(s grpc.ServiceRegistrar, srv WarehousesManagementSystemServiceServer) {
+	s.RegisterService(&WarehousesManagementSystemService_ServiceDesc, srv)
+}
+
+func _WarehousesManagementSystemService_ReserveStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveStocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehousesManagementSystemServiceServer).ReserveStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehousesManagementSystemService_ReserveStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehousesManagementSystemServiceServer).ReserveStocks(ctx, req.(*ReserveStocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehousesManagementSystemService_ConfirmReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehousesManagementSystemServiceServer).ConfirmReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehousesManagementSystemService_ConfirmReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehousesManagementSystemServiceServer).ConfirmReservation(ctx, req.(*ConfirmReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehousesManagementSystemService_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehousesManagementSystemServiceServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehousesManagementSystemService_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehousesManagementSystemServiceServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehousesManagementSystemService_ShrinkReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShrinkReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehousesManagementSystemServiceServer).ShrinkReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehousesManagementSystemService_ShrinkReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehousesManagementSystemServiceServer).ShrinkReservation(ctx, req.(*ShrinkReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _WarehousesManagementSystemService_GetStocks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStocksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(WarehousesManagementSystemServiceServer).GetStocks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: WarehousesManagementSystemService_GetStocks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(WarehousesManagementSystemServiceServer).GetStocks(ctx, req.(*GetStocksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// WarehousesManagementSystemService_ServiceDesc is the grpc.ServiceDesc for WarehousesManagementSystemService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var WarehousesManagementSystemService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "github.com.moguchev.microservices.warehouses_management_system.WarehousesManagementSystemService",
+	HandlerType: (*WarehousesManagementSystemServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReserveStocks",
+			Handler:    _WarehousesManagementSystemService_ReserveStocks_Handler,
+		},
+		{
+			MethodName: "ConfirmReservation",
+			Handler:    _WarehousesManagementSystemService_ConfirmReservation_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _WarehousesManagementSystemService_ReleaseReservation_Handler,
+		},
+		{
+			MethodName: "ShrinkReservation",
+			Handler:    _WarehousesManagementSystemService_ShrinkReservation_Handler,
+		},
+		{
+			MethodName: "GetStocks",
+			Handler:    _WarehousesManagementSystemService_GetStocks_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/warehouses_management_system/service.proto",
+}
@@ -69,7 +69,7 @@ func (c *closer) CloseAll(ctx context.Context) error {
 		}
 
 		go func() {
-			wg.Done()
+			wg.Wait()
 			close(errs)
 		}()
 
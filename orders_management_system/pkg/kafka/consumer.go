@@ -0,0 +1,139 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/IBM/sarama"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+type consumerOptions struct {
+	clientID      string
+	initialOffset int64
+}
+
+// ConsumerOption - опция consumer
+type ConsumerOption func(options *consumerOptions)
+
+// WithConsumerClientID ...
+func WithConsumerClientID(id string) ConsumerOption {
+	return func(opts *consumerOptions) {
+		opts.clientID = id
+	}
+}
+
+// WithConsumerFromNewest - новая consumer group начинает чтение с конца топика (по умолчанию - с начала)
+func WithConsumerFromNewest() ConsumerOption {
+	return func(opts *consumerOptions) {
+		opts.initialOffset = sarama.OffsetNewest
+	}
+}
+
+// GroupConsumer - Consumer поверх sarama.ConsumerGroup
+type GroupConsumer struct {
+	group sarama.ConsumerGroup
+}
+
+var _ Consumer = (*GroupConsumer)(nil)
+
+// NewGroupConsumer - returns new GroupConsumer
+func NewGroupConsumer(brokers []string, groupID string, opts ...ConsumerOption) (*GroupConsumer, error) {
+	options := &consumerOptions{
+		initialOffset: sarama.OffsetOldest,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	cfg := sarama.NewConfig()
+	if options.clientID != "" {
+		cfg.ClientID = options.clientID
+	}
+	cfg.Version = sarama.V2_1_0_0
+	cfg.Consumer.Offsets.Initial = options.initialOffset
+	cfg.Consumer.Return.Errors = false
+
+	group, err := sarama.NewConsumerGroup(brokers, groupID, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("can't create kafka consumer group: %w", err)
+	}
+
+	return &GroupConsumer{
+		group: group,
+	}, nil
+}
+
+// Consume - чтение топиков до отмены ctx. При ребалансировке сессия пересоздается
+func (c *GroupConsumer) Consume(ctx context.Context, topics []string, handler Handler) error {
+	for {
+		if err := c.group.Consume(ctx, topics, groupHandler{handler: handler}); err != nil {
+			if errors.Is(err, sarama.ErrClosedConsumerGroup) {
+				return ErrClosed
+			}
+			return fmt.Errorf("consume %v: %w", topics, err)
+		}
+		if ctx.Err() != nil {
+			return nil
+		}
+	}
+}
+
+// Close - закрытие consumer
+func (c *GroupConsumer) Close() error {
+	return c.group.Close()
+}
+
+// groupHandler - реализация sarama.ConsumerGroupHandler
+type groupHandler struct {
+	handler Handler
+}
+
+func (groupHandler) Setup(sarama.ConsumerGroupSession) error   { return nil }
+func (groupHandler) Cleanup(sarama.ConsumerGroupSession) error { return nil }
+
+func (h groupHandler) ConsumeClaim(session sarama.ConsumerGroupSession, claim sarama.ConsumerGroupClaim) error {
+	for {
+		select {
+		case <-session.Context().Done():
+			return nil
+		case msg, ok := <-claim.Messages():
+			if !ok {
+				return nil
+			}
+
+			message := newMessageFromConsumerMessage(msg)
+			span, ctx := startConsumerSpan(session.Context(), message)
+			err := h.handler(ctx, message)
+			if err != nil {
+				ext.Error.Set(span, true)
+				span.LogKV("error", err.Error())
+			}
+			span.Finish()
+
+			// сообщение не обработано - выходим из сессии, чтобы перечитать его после пересоздания
+			if err != nil {
+				return err
+			}
+			session.MarkMessage(msg, "")
+		}
+	}
+}
+
+func newMessageFromConsumerMessage(msg *sarama.ConsumerMessage) Message {
+	headers := make(map[string]string, len(msg.Headers))
+	for _, h := range msg.Headers {
+		headers[string(h.Key)] = string(h.Value)
+	}
+
+	return Message{
+		Topic:     msg.Topic,
+		Key:       msg.Key,
+		Value:     msg.Value,
+		Headers:   headers,
+		Partition: msg.Partition,
+		Offset:    msg.Offset,
+		Timestamp: msg.Timestamp,
+	}
+}
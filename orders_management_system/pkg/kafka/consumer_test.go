@@ -0,0 +1,161 @@
+//go:build test
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSession - сессия consumer group: запоминает отмеченные сообщения
+type fakeSession struct {
+	sarama.ConsumerGroupSession
+	ctx    context.Context
+	marked []int64
+}
+
+func (s *fakeSession) Context() context.Context { return s.ctx }
+
+func (s *fakeSession) MarkMessage(msg *sarama.ConsumerMessage, _ string) {
+	s.marked = append(s.marked, msg.Offset)
+}
+
+// fakeClaim - партиция, выданная сессии
+type fakeClaim struct {
+	sarama.ConsumerGroupClaim
+	messages chan *sarama.ConsumerMessage
+}
+
+func (c fakeClaim) Messages() <-chan *sarama.ConsumerMessage { return c.messages }
+
+// fakeGroup - consumer group, Consume которой возвращает err
+type fakeGroup struct {
+	sarama.ConsumerGroup
+	err error
+}
+
+func (g fakeGroup) Consume(context.Context, []string, sarama.ConsumerGroupHandler) error {
+	return g.err
+}
+
+func TestGroupHandler_ConsumeClaim(t *testing.T) {
+	errHandler := errors.New("handler failed")
+
+	tests := []struct {
+		name       string
+		failOffset int64 // на каком offset'е обработчик вернет ошибку (-1 - без ошибок)
+		wantMarked []int64
+		wantErr    error
+	}{
+		{
+			name:       "Test 1. Positive. Handled messages are marked.",
+			failOffset: -1,
+			wantMarked: []int64{0, 1, 2},
+		},
+		{
+			name:       "Test 2. Negative. Failed message is not marked and ends the session.",
+			failOffset: 1,
+			wantMarked: []int64{0},
+			wantErr:    errHandler,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			claim := fakeClaim{messages: make(chan *sarama.ConsumerMessage, 3)}
+			for offset := int64(0); offset < 3; offset++ {
+				claim.messages <- &sarama.ConsumerMessage{
+					Topic:     testTopic,
+					Partition: 2,
+					Offset:    offset,
+					Key:       []byte("order-1"),
+					Value:     []byte{byte(offset)},
+					Headers:   []*sarama.RecordHeader{{Key: []byte("event_type"), Value: []byte("OrderCreated")}},
+					Timestamp: time.Unix(100, 0),
+				}
+			}
+			close(claim.messages)
+			session := &fakeSession{ctx: context.Background()}
+
+			var handled []Message
+			handler := groupHandler{handler: func(_ context.Context, message Message) error {
+				handled = append(handled, message)
+				if message.Offset == tt.failOffset {
+					return errHandler
+				}
+				return nil
+			}}
+
+			// act
+			err := handler.ConsumeClaim(session, claim)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantMarked, session.marked)
+			require.NotEmpty(t, handled)
+			assert.Equal(t, Message{
+				Topic:     testTopic,
+				Key:       []byte("order-1"),
+				Value:     []byte{0},
+				Headers:   map[string]string{"event_type": "OrderCreated"},
+				Partition: 2,
+				Offset:    0,
+				Timestamp: time.Unix(100, 0),
+			}, handled[0])
+		})
+	}
+}
+
+func TestGroupConsumer_Consume(t *testing.T) {
+	errBroker := errors.New("broker is down")
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		groupErr error
+		wantErr  error
+	}{
+		{
+			name: "Test 1. Positive. Cancelled context stops consuming.",
+			ctx:  cancelled,
+		},
+		{
+			name:     "Test 2. Negative. Closed group returns ErrClosed.",
+			ctx:      context.Background(),
+			groupErr: sarama.ErrClosedConsumerGroup,
+			wantErr:  ErrClosed,
+		},
+		{
+			name:     "Test 3. Negative. Group error is returned.",
+			ctx:      context.Background(),
+			groupErr: errBroker,
+			wantErr:  errBroker,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			consumer := &GroupConsumer{group: fakeGroup{err: tt.groupErr}}
+
+			err := consumer.Consume(tt.ctx, []string{testTopic}, func(context.Context, Message) error { return nil })
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
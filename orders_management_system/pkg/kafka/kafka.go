@@ -0,0 +1,42 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrClosed - producer/consumer уже закрыт
+var ErrClosed = errors.New("kafka: closed")
+
+// Message - сообщение kafka
+type Message struct {
+	Topic     string
+	Key       []byte            // ключ партиционирования (например ID заказа)
+	Value     []byte            // payload
+	Headers   map[string]string // заголовки (в том числе контекст трассировки)
+	Partition int32             // заполняется при чтении
+	Offset    int64             // заполняется при чтении
+	Timestamp time.Time         // заполняется при чтении
+}
+
+type (
+	// Producer - отправка сообщений в топик
+	Producer interface {
+		// SendMessage - синхронная отправка сообщения (дожидается подтверждения брокера)
+		SendMessage(ctx context.Context, message Message) error
+		// Close - закрытие producer
+		Close() error
+	}
+
+	// Handler - обработчик сообщений. Если вернул ошибку - offset не коммитится
+	Handler func(ctx context.Context, message Message) error
+
+	// Consumer - чтение сообщений из топиков в рамках consumer group
+	Consumer interface {
+		// Consume - блокирующее чтение топиков, пока не отменен ctx
+		Consume(ctx context.Context, topics []string, handler Handler) error
+		// Close - закрытие consumer
+		Close() error
+	}
+)
@@ -0,0 +1,224 @@
+package kafka
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+	"time"
+)
+
+const memoryBrokerPartitionsDefault = 3
+
+// MemoryBroker - in-process брокер с семантикой kafka: партиционирование по ключу,
+// порядок внутри партиции, offset'ы consumer group. Нужен, чтобы тестировать цепочку
+// outbox -> producer -> consumer без кластера
+type MemoryBroker struct {
+	mu         sync.Mutex
+	partitions int32
+	topics     map[string][][]Message        // topic -> partition -> log
+	offsets    map[string]map[string][]int64 // group -> topic -> partition -> следующий offset
+	groups     map[string]*sync.Mutex        // одна активная сессия на группу
+	notify     chan struct{}                 // закрывается при каждом новом сообщении
+	closed     bool
+}
+
+// NewMemoryBroker - returns new MemoryBroker
+func NewMemoryBroker(partitions int32) *MemoryBroker {
+	if partitions <= 0 {
+		partitions = memoryBrokerPartitionsDefault
+	}
+
+	return &MemoryBroker{
+		partitions: partitions,
+		topics:     make(map[string][][]Message),
+		offsets:    make(map[string]map[string][]int64),
+		groups:     make(map[string]*sync.Mutex),
+		notify:     make(chan struct{}),
+	}
+}
+
+// Producer - producer, пишущий в брокер
+func (b *MemoryBroker) Producer() Producer {
+	return memoryProducer{broker: b}
+}
+
+// Consumer - consumer в рамках consumer group groupID
+func (b *MemoryBroker) Consumer(groupID string) Consumer {
+	return memoryConsumer{broker: b, groupID: groupID}
+}
+
+// Messages - все сообщения топика (по партициям подряд)
+func (b *MemoryBroker) Messages(topic string) []Message {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var messages []Message
+	for _, log := range b.topics[topic] {
+		messages = append(messages, log...)
+	}
+	return messages
+}
+
+// Close - закрывает брокер, все Consume завершаются
+func (b *MemoryBroker) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.closed {
+		b.closed = true
+		close(b.notify)
+	}
+	return nil
+}
+
+func (b *MemoryBroker) append(message Message) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return ErrClosed
+	}
+
+	log := b.topicLocked(message.Topic)
+	partition := b.partition(message.Key)
+
+	message.Partition = partition
+	message.Offset = int64(len(log[partition]))
+	message.Timestamp = time.Now()
+	log[partition] = append(log[partition], message)
+
+	// будим всех ожидающих consumer'ов
+	close(b.notify)
+	b.notify = make(chan struct{})
+
+	return nil
+}
+
+// next - следующее необработанное группой сообщение
+func (b *MemoryBroker) next(groupID string, topics []string) (message Message, found bool, wait <-chan struct{}, err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.closed {
+		return Message{}, false, nil, ErrClosed
+	}
+
+	for _, topic := range topics {
+		log := b.topicLocked(topic)
+		offsets := b.offsetsLocked(groupID, topic)
+		for partition := range log {
+			if offsets[partition] < int64(len(log[partition])) {
+				return log[partition][offsets[partition]], true, nil, nil
+			}
+		}
+	}
+
+	return Message{}, false, b.notify, nil
+}
+
+func (b *MemoryBroker) commit(groupID string, message Message) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.offsetsLocked(groupID, message.Topic)[message.Partition] = message.Offset + 1
+}
+
+func (b *MemoryBroker) group(groupID string) *sync.Mutex {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	m, ok := b.groups[groupID]
+	if !ok {
+		m = &sync.Mutex{}
+		b.groups[groupID] = m
+	}
+	return m
+}
+
+func (b *MemoryBroker) topicLocked(topic string) [][]Message {
+	log, ok := b.topics[topic]
+	if !ok {
+		log = make([][]Message, b.partitions)
+		b.topics[topic] = log
+	}
+	return log
+}
+
+func (b *MemoryBroker) offsetsLocked(groupID, topic string) []int64 {
+	group, ok := b.offsets[groupID]
+	if !ok {
+		group = make(map[string][]int64)
+		b.offsets[groupID] = group
+	}
+	offsets, ok := group[topic]
+	if !ok {
+		offsets = make([]int64, b.partitions)
+		group[topic] = offsets
+	}
+	return offsets
+}
+
+// partition - как sarama.NewHashPartitioner: fnv-1a от ключа по модулю количества партиций
+func (b *MemoryBroker) partition(key []byte) int32 {
+	if len(key) == 0 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write(key)
+	p := int32(h.Sum32()) % b.partitions
+	if p < 0 {
+		p = -p
+	}
+	return p
+}
+
+type memoryProducer struct {
+	broker *MemoryBroker
+}
+
+func (p memoryProducer) SendMessage(ctx context.Context, message Message) error {
+	span := startProducerSpan(ctx, &message)
+	defer span.Finish()
+
+	return p.broker.append(message)
+}
+
+func (p memoryProducer) Close() error { return nil }
+
+type memoryConsumer struct {
+	broker  *MemoryBroker
+	groupID string
+}
+
+func (c memoryConsumer) Consume(ctx context.Context, topics []string, handler Handler) error {
+	group := c.broker.group(c.groupID)
+	group.Lock()
+	defer group.Unlock()
+
+	for {
+		message, found, wait, err := c.broker.next(c.groupID, topics)
+		if err != nil {
+			return err
+		}
+
+		if !found {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-wait:
+			}
+			continue
+		}
+
+		span, spanCtx := startConsumerSpan(ctx, message)
+		err = handler(spanCtx, message)
+		span.Finish()
+		if err != nil {
+			return err
+		}
+
+		c.broker.commit(c.groupID, message)
+	}
+}
+
+func (c memoryConsumer) Close() error { return nil }
@@ -0,0 +1,145 @@
+//go:build test
+
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testTopic = "orders.events"
+
+// consume - читает сообщения группы, пока не прочитает n
+func consume(t *testing.T, consumer Consumer, n int) []Message {
+	t.Helper()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	var got []Message
+	err := consumer.Consume(ctx, []string{testTopic}, func(_ context.Context, message Message) error {
+		got = append(got, message)
+		if len(got) == n {
+			cancel()
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, got, n)
+	return got
+}
+
+// send - отправка сообщений с ключами keys
+func send(t *testing.T, producer Producer, keys ...string) {
+	t.Helper()
+
+	for i, key := range keys {
+		err := producer.SendMessage(context.Background(), Message{
+			Topic: testTopic,
+			Key:   []byte(key),
+			Value: []byte{byte(i)},
+		})
+		require.NoError(t, err)
+	}
+}
+
+func TestMemoryBroker_Partitioning(t *testing.T) {
+	const partitions = 3
+
+	broker := NewMemoryBroker(partitions)
+	send(t, broker.Producer(), "order-1", "order-2", "order-1", "order-3", "order-2", "order-1", "")
+
+	// партиция по ключу - как у sarama.NewHashPartitioner, offset'ы партиции идут подряд
+	var (
+		hash    = sarama.NewHashPartitioner(testTopic)
+		offsets = make(map[int32]int64)
+		byKey   = make(map[string][]byte)
+	)
+	for _, message := range broker.Messages(testTopic) {
+		if len(message.Key) == 0 {
+			assert.Equal(t, int32(0), message.Partition, "message without key")
+		} else {
+			want, err := hash.Partition(&sarama.ProducerMessage{Key: sarama.ByteEncoder(message.Key)}, partitions)
+			require.NoError(t, err)
+			assert.Equal(t, want, message.Partition, "key %s", message.Key)
+		}
+		assert.Equal(t, offsets[message.Partition], message.Offset)
+		offsets[message.Partition]++
+		byKey[string(message.Key)] = append(byKey[string(message.Key)], message.Value...)
+	}
+
+	// сообщения одного ключа - в порядке отправки
+	assert.Equal(t, []byte{0, 2, 5}, byKey["order-1"])
+	assert.Equal(t, []byte{1, 4}, byKey["order-2"])
+	assert.Equal(t, []byte{3}, byKey["order-3"])
+}
+
+func TestMemoryBroker_ConsumerGroupOffsets(t *testing.T) {
+	broker := NewMemoryBroker(1)
+	send(t, broker.Producer(), "order-1", "order-2", "order-3")
+
+	// у каждой группы свои offset'ы: обе читают топик целиком
+	first := consume(t, broker.Consumer("first"), 3)
+	second := consume(t, broker.Consumer("second"), 3)
+	assert.Equal(t, first, second)
+
+	// группа продолжает с закоммиченного offset'а
+	send(t, broker.Producer(), "order-4")
+	got := consume(t, broker.Consumer("first"), 1)
+	assert.Equal(t, []byte("order-4"), got[0].Key)
+	assert.Equal(t, int64(3), got[0].Offset)
+}
+
+func TestMemoryBroker_RedeliveryAfterHandlerError(t *testing.T) {
+	broker := NewMemoryBroker(1)
+	send(t, broker.Producer(), "order-1", "order-2")
+
+	// обработчик упал на втором сообщении - Consume возвращает ошибку, offset не коммитится
+	errHandler := errors.New("handler failed")
+	var handled []string
+	err := broker.Consumer("group").Consume(context.Background(), []string{testTopic}, func(_ context.Context, message Message) error {
+		handled = append(handled, string(message.Key))
+		if len(handled) == 2 {
+			return errHandler
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errHandler)
+	assert.Equal(t, []string{"order-1", "order-2"}, handled)
+
+	// следующая сессия группы получает необработанное сообщение повторно
+	got := consume(t, broker.Consumer("group"), 1)
+	assert.Equal(t, []byte("order-2"), got[0].Key)
+	assert.Equal(t, int64(1), got[0].Offset)
+}
+
+func TestMemoryBroker_Close(t *testing.T) {
+	broker := NewMemoryBroker(1)
+
+	// ожидающий сообщений Consume завершается при закрытии брокера
+	done := make(chan error, 1)
+	go func() {
+		done <- broker.Consumer("group").Consume(context.Background(), []string{testTopic}, func(context.Context, Message) error {
+			return nil
+		})
+	}()
+
+	require.NoError(t, broker.Close())
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, ErrClosed)
+	case <-time.After(time.Second):
+		t.Fatal("Consume is not stopped by Close")
+	}
+
+	// закрытый брокер не принимает сообщения, повторный Close - не ошибка
+	err := broker.Producer().SendMessage(context.Background(), Message{Topic: testTopic})
+	assert.ErrorIs(t, err, ErrClosed)
+	assert.NoError(t, broker.Close())
+}
@@ -0,0 +1,123 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+const (
+	producerMaxRetriesDefault = 5
+	producerTimeoutDefault    = 10 * time.Second
+)
+
+type producerOptions struct {
+	clientID   string
+	maxRetries int
+	timeout    time.Duration
+}
+
+// ProducerOption - опция producer
+type ProducerOption func(options *producerOptions)
+
+// WithProducerClientID ...
+func WithProducerClientID(id string) ProducerOption {
+	return func(opts *producerOptions) {
+		opts.clientID = id
+	}
+}
+
+// WithProducerMaxRetries ...
+func WithProducerMaxRetries(n int) ProducerOption {
+	return func(opts *producerOptions) {
+		opts.maxRetries = n
+	}
+}
+
+// WithProducerTimeout ...
+func WithProducerTimeout(d time.Duration) ProducerOption {
+	return func(opts *producerOptions) {
+		opts.timeout = d
+	}
+}
+
+// SyncProducer - Producer поверх sarama.SyncProducer
+type SyncProducer struct {
+	producer sarama.SyncProducer
+}
+
+var _ Producer = (*SyncProducer)(nil)
+
+// NewSyncProducer - returns new SyncProducer
+func NewSyncProducer(brokers []string, opts ...ProducerOption) (*SyncProducer, error) {
+	options := &producerOptions{
+		maxRetries: producerMaxRetriesDefault,
+		timeout:    producerTimeoutDefault,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	cfg := sarama.NewConfig()
+	if options.clientID != "" {
+		cfg.ClientID = options.clientID
+	}
+	cfg.Producer.RequiredAcks = sarama.WaitForAll        // ждем подтверждения от всех in-sync реплик
+	cfg.Producer.Partitioner = sarama.NewHashPartitioner // один ключ - одна партиция - порядок сообщений
+	cfg.Producer.Idempotent = true                       // без дублей при ретраях producer'а
+	cfg.Net.MaxOpenRequests = 1                          // требование идемпотентного producer'а
+	cfg.Version = sarama.V2_1_0_0                        // минимальная версия для идемпотентного producer'а
+	cfg.Producer.Retry.Max = options.maxRetries
+	cfg.Producer.Timeout = options.timeout
+	cfg.Producer.Return.Successes = true // обязательно для SyncProducer
+	cfg.Producer.Return.Errors = true
+
+	producer, err := sarama.NewSyncProducer(brokers, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("can't create kafka producer: %w", err)
+	}
+
+	return &SyncProducer{
+		producer: producer,
+	}, nil
+}
+
+// SendMessage - синхронная отправка сообщения
+func (p *SyncProducer) SendMessage(ctx context.Context, message Message) error {
+	span := startProducerSpan(ctx, &message)
+	defer span.Finish()
+
+	partition, offset, err := p.producer.SendMessage(newProducerMessage(message))
+	if err != nil {
+		ext.Error.Set(span, true)
+		span.LogKV("error", err.Error())
+		return fmt.Errorf("can't send message to %q: %w", message.Topic, err)
+	}
+
+	span.SetTag("message_bus.partition", partition)
+	span.SetTag("message_bus.offset", offset)
+
+	return nil
+}
+
+// Close - закрытие producer (дожидается отправки буферизованных сообщений)
+func (p *SyncProducer) Close() error {
+	return p.producer.Close()
+}
+
+func newProducerMessage(message Message) *sarama.ProducerMessage {
+	headers := make([]sarama.RecordHeader, 0, len(message.Headers))
+	for k, v := range message.Headers {
+		headers = append(headers, sarama.RecordHeader{Key: []byte(k), Value: []byte(v)})
+	}
+
+	return &sarama.ProducerMessage{
+		Topic:   message.Topic,
+		Key:     sarama.ByteEncoder(message.Key),
+		Value:   sarama.ByteEncoder(message.Value),
+		Headers: headers,
+	}
+}
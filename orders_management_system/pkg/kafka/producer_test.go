@@ -0,0 +1,68 @@
+//go:build test
+
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IBM/sarama"
+	"github.com/IBM/sarama/mocks"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncProducer_SendMessage(t *testing.T) {
+	message := Message{
+		Topic:   testTopic,
+		Key:     []byte("order-1"),
+		Value:   []byte("payload"),
+		Headers: map[string]string{"event_type": "OrderCreated"},
+	}
+
+	tests := []struct {
+		name    string
+		on      func(*mocks.SyncProducer)
+		wantErr error
+	}{
+		{
+			name: "Test 1. Positive. Message is sent with key and headers.",
+			on: func(p *mocks.SyncProducer) {
+				p.ExpectSendMessageWithMessageCheckerFunctionAndSucceed(func(msg *sarama.ProducerMessage) error {
+					key, _ := msg.Key.Encode()
+					value, _ := msg.Value.Encode()
+					assert.Equal(t, testTopic, msg.Topic)
+					assert.Equal(t, []byte("order-1"), key)
+					assert.Equal(t, []byte("payload"), value)
+					assert.Contains(t, msg.Headers, sarama.RecordHeader{Key: []byte("event_type"), Value: []byte("OrderCreated")})
+					return nil
+				})
+			},
+		},
+		{
+			name: "Test 2. Negative. Broker error is returned.",
+			on: func(p *mocks.SyncProducer) {
+				p.ExpectSendMessageAndFail(sarama.ErrOutOfBrokers)
+			},
+			wantErr: sarama.ErrOutOfBrokers,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			mock := mocks.NewSyncProducer(t, nil)
+			tt.on(mock)
+			producer := &SyncProducer{producer: mock}
+
+			// act
+			err := producer.SendMessage(context.Background(), message)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, producer.Close()) // все ожидания mock выполнены
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/opentracing/opentracing-go"
+	"github.com/opentracing/opentracing-go/ext"
+)
+
+const messageBusDestinationTag = "message_bus.destination"
+
+// startProducerSpan - начинает span отправки и кладет его контекст в заголовки сообщения,
+// чтобы consumer мог продолжить трассу
+func startProducerSpan(ctx context.Context, message *Message) opentracing.Span {
+	span, _ := opentracing.StartSpanFromContext(ctx, "kafka.produce "+message.Topic,
+		ext.SpanKindProducer,
+		opentracing.Tag{Key: messageBusDestinationTag, Value: message.Topic},
+	)
+
+	if message.Headers == nil {
+		message.Headers = make(map[string]string)
+	}
+	_ = span.Tracer().Inject(span.Context(), opentracing.TextMap, opentracing.TextMapCarrier(message.Headers))
+
+	return span
+}
+
+// startConsumerSpan - начинает span обработки сообщения, продолжая трассу producer'а
+func startConsumerSpan(ctx context.Context, message Message) (opentracing.Span, context.Context) {
+	opts := []opentracing.StartSpanOption{
+		ext.SpanKindConsumer,
+		opentracing.Tag{Key: messageBusDestinationTag, Value: message.Topic},
+	}
+	if spanContext, err := opentracing.GlobalTracer().Extract(opentracing.TextMap, opentracing.TextMapCarrier(message.Headers)); err == nil {
+		opts = append(opts, opentracing.FollowsFrom(spanContext))
+	}
+
+	span := opentracing.StartSpan("kafka.consume "+message.Topic, opts...)
+	return span, opentracing.ContextWithSpan(ctx, span)
+}
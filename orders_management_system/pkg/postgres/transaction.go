@@ -49,7 +49,7 @@ func (t *Transaction) Selectx(ctx context.Context, dest interface{}, sqlizer Sql
 	ext.DBStatement.Set(span, query)
 	ext.DBType.Set(span, "postgresql")
 
-	err = pgxscan.Select(ctx, t.Tx, dest, query, args)
+	err = pgxscan.Select(ctx, t.Tx, dest, query, args...)
 	if err != nil {
 		ext.Error.Set(span, true)
 	}
@@ -0,0 +1,47 @@
+package resilience
+
+import (
+	"math"
+	"math/rand/v2"
+	"time"
+)
+
+// Backoff - экспоненциальная задержка между попытками со случайным разбросом (jitter),
+// чтобы клиенты, упавшие одновременно, не повторяли запросы синхронно
+type Backoff struct {
+	Initial    time.Duration // задержка перед первым повтором
+	Max        time.Duration // верхняя граница задержки
+	Multiplier float64       // во сколько раз растет задержка с каждым повтором
+	Jitter     float64       // доля задержки [0, 1], на которую она случайно уменьшается
+}
+
+// DefaultBackoff - 50ms, 100ms, 200ms ... но не больше секунды; разброс до 20%
+var DefaultBackoff = Backoff{
+	Initial:    50 * time.Millisecond,
+	Max:        time.Second,
+	Multiplier: 2,
+	Jitter:     0.2,
+}
+
+// Delay - задержка перед повтором номер retry (с нуля)
+func (b Backoff) Delay(retry int) time.Duration {
+	if b.Initial <= 0 {
+		return 0
+	}
+
+	multiplier := b.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	delay := float64(b.Initial) * math.Pow(multiplier, float64(retry))
+	if b.Max > 0 && delay > float64(b.Max) {
+		delay = float64(b.Max)
+	}
+
+	if jitter := math.Min(math.Max(b.Jitter, 0), 1); jitter > 0 {
+		delay -= delay * jitter * rand.Float64()
+	}
+
+	return time.Duration(delay)
+}
@@ -0,0 +1,164 @@
+package resilience
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen - circuit breaker разомкнут, вызов не выполнялся
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// State - состояние circuit breaker
+type State int
+
+const (
+	StateClosed   State = iota // вызовы проходят
+	StateOpen                  // вызовы отклоняются без обращения к зависимости
+	StateHalfOpen              // пропускаются пробные вызовы
+)
+
+func (s State) String() string {
+	switch s {
+	case StateClosed:
+		return "closed"
+	case StateOpen:
+		return "open"
+	case StateHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+// BreakerConfig - настройки circuit breaker
+type BreakerConfig struct {
+	FailureThreshold int           // отказов подряд, после которых breaker размыкается
+	OpenTimeout      time.Duration // сколько breaker разомкнут до пробных вызовов
+	HalfOpenProbes   int           // успешных пробных вызовов, после которых breaker замыкается
+}
+
+// DefaultBreakerConfig - настройки по умолчанию
+var DefaultBreakerConfig = BreakerConfig{
+	FailureThreshold: 5,
+	OpenTimeout:      5 * time.Second,
+	HalfOpenProbes:   1,
+}
+
+// CircuitBreaker - размыкает цепь после FailureThreshold отказов подряд,
+// через OpenTimeout пропускает HalfOpenProbes пробных вызовов (half-open):
+// если они успешны - цепь замыкается, если нет - снова размыкается
+type CircuitBreaker struct {
+	name string
+	cfg  BreakerConfig
+	now  func() time.Time
+
+	mu         sync.Mutex
+	state      State
+	generation uint64 // растет при каждой смене состояния: результаты вызовов прошлых состояний не учитываются
+	failures   int
+	openedAt   time.Time
+	probes     int // пробных вызовов в полете
+	successes  int // успешных пробных вызовов
+}
+
+// NewCircuitBreaker - circuit breaker, name - метка в метриках
+func NewCircuitBreaker(name string, cfg BreakerConfig) *CircuitBreaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = DefaultBreakerConfig.FailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = DefaultBreakerConfig.OpenTimeout
+	}
+	if cfg.HalfOpenProbes <= 0 {
+		cfg.HalfOpenProbes = DefaultBreakerConfig.HalfOpenProbes
+	}
+
+	cb := &CircuitBreaker{
+		name: name,
+		cfg:  cfg,
+		now:  time.Now,
+	}
+	observeBreakerState(name, StateClosed)
+	return cb
+}
+
+// State - текущее состояние
+func (cb *CircuitBreaker) State() State {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.refresh()
+	return cb.state
+}
+
+// Allow - можно ли выполнить вызов. Если можно - по завершении вызова
+// нужно вызвать done с результатом: failure - отказ зависимости
+func (cb *CircuitBreaker) Allow() (done func(failure bool), err error) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.refresh()
+	switch cb.state {
+	case StateOpen:
+		return nil, ErrCircuitOpen
+	case StateHalfOpen:
+		if cb.probes+cb.successes >= cb.cfg.HalfOpenProbes {
+			return nil, ErrCircuitOpen
+		}
+		cb.probes++
+	}
+
+	generation := cb.generation
+	return func(failure bool) {
+		cb.done(generation, failure)
+	}, nil
+}
+
+func (cb *CircuitBreaker) done(generation uint64, failure bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	if generation != cb.generation {
+		return
+	}
+
+	switch cb.state {
+	case StateClosed:
+		if !failure {
+			cb.failures = 0
+			return
+		}
+		if cb.failures++; cb.failures >= cb.cfg.FailureThreshold {
+			cb.setState(StateOpen)
+		}
+	case StateHalfOpen:
+		cb.probes--
+		if failure {
+			cb.setState(StateOpen)
+			return
+		}
+		if cb.successes++; cb.successes >= cb.cfg.HalfOpenProbes {
+			cb.setState(StateClosed)
+		}
+	}
+}
+
+// refresh - переход open -> half-open по истечении OpenTimeout
+func (cb *CircuitBreaker) refresh() {
+	if cb.state == StateOpen && cb.now().Sub(cb.openedAt) >= cb.cfg.OpenTimeout {
+		cb.setState(StateHalfOpen)
+	}
+}
+
+func (cb *CircuitBreaker) setState(state State) {
+	cb.state = state
+	cb.generation++
+	cb.failures = 0
+	cb.probes = 0
+	cb.successes = 0
+	if state == StateOpen {
+		cb.openedAt = cb.now()
+	}
+	observeBreakerState(cb.name, state)
+}
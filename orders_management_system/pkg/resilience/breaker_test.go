@@ -0,0 +1,74 @@
+//go:build test
+
+package resilience
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker(t.Name(), BreakerConfig{
+		FailureThreshold: 2,
+		OpenTimeout:      time.Second,
+		HalfOpenProbes:   1,
+	})
+	cb.now = func() time.Time { return now }
+
+	call := func(failure bool) error {
+		done, err := cb.Allow()
+		if err != nil {
+			return err
+		}
+		done(failure)
+		return nil
+	}
+
+	// успех сбрасывает счетчик отказов подряд
+	require.NoError(t, call(true))
+	require.NoError(t, call(false))
+	require.NoError(t, call(true))
+	assert.Equal(t, StateClosed, cb.State())
+
+	// второй отказ подряд размыкает цепь
+	require.NoError(t, call(true))
+	assert.Equal(t, StateOpen, cb.State())
+	assert.ErrorIs(t, call(false), ErrCircuitOpen)
+
+	// по таймауту - пробный вызов, неуспешный снова размыкает цепь
+	now = now.Add(time.Second)
+	assert.Equal(t, StateHalfOpen, cb.State())
+	require.NoError(t, call(true))
+	assert.Equal(t, StateOpen, cb.State())
+
+	// пока пробный вызов в полете, остальные отклоняются
+	now = now.Add(time.Second)
+	done, err := cb.Allow()
+	require.NoError(t, err)
+	assert.ErrorIs(t, call(false), ErrCircuitOpen)
+
+	// успешный пробный вызов замыкает цепь
+	done(false)
+	assert.Equal(t, StateClosed, cb.State())
+	require.NoError(t, call(false))
+}
+
+func TestCircuitBreaker_StaleResult(t *testing.T) {
+	cb := NewCircuitBreaker(t.Name(), BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Hour})
+
+	slow, err := cb.Allow()
+	require.NoError(t, err)
+
+	done, err := cb.Allow()
+	require.NoError(t, err)
+	done(true)
+	require.Equal(t, StateOpen, cb.State())
+
+	// результат вызова, начатого до размыкания, не влияет на новое состояние
+	slow(false)
+	assert.Equal(t, StateOpen, cb.State())
+}
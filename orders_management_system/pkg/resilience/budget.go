@@ -0,0 +1,55 @@
+package resilience
+
+import "sync"
+
+// RetryBudget - бюджет повторов (как retryThrottling в gRPC).
+// Каждый отказ зависимости тратит токен, каждый ответ возвращает ratio токена.
+// Повторы разрешены, пока токенов больше половины: при массовых отказах
+// повторы прекращаются и не добивают и так лежащую зависимость
+type RetryBudget struct {
+	mu        sync.Mutex
+	tokens    float64
+	maxTokens float64
+	ratio     float64
+}
+
+// NewRetryBudget - бюджет на maxTokens токенов, ratio - доля токена за каждый ответ
+func NewRetryBudget(maxTokens, ratio float64) *RetryBudget {
+	return &RetryBudget{
+		tokens:    maxTokens,
+		maxTokens: maxTokens,
+		ratio:     ratio,
+	}
+}
+
+// OnSuccess - зависимость ответила
+func (b *RetryBudget) OnSuccess() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = min(b.tokens+b.ratio, b.maxTokens)
+}
+
+// OnFailure - отказ зависимости
+func (b *RetryBudget) OnFailure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = max(b.tokens-1, 0)
+}
+
+// AllowRetry - можно ли повторить запрос
+func (b *RetryBudget) AllowRetry() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.tokens > b.maxTokens/2
+}
+
+// Tokens - остаток токенов
+func (b *RetryBudget) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.tokens
+}
@@ -0,0 +1,32 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryClientInterceptor - выполняет исходящие unary вызовы по политике p.
+// Повтор отправляет тот же запрос, поэтому подходит только для идемпотентных методов.
+// Разомкнутый breaker возвращается как codes.Unavailable
+func UnaryClientInterceptor(p *Policy) grpc.UnaryClientInterceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		req, reply any,
+		cc *grpc.ClientConn,
+		invoker grpc.UnaryInvoker,
+		opts ...grpc.CallOption,
+	) error {
+		err := p.Do(ctx, func(ctx context.Context) error {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		})
+		if errors.Is(err, ErrCircuitOpen) {
+			return status.Error(codes.Unavailable, err.Error())
+		}
+		return err
+	}
+}
@@ -0,0 +1,101 @@
+package resilience
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	resultSuccess  = "success"  // зависимость ответила
+	resultFailure  = "failure"  // отказ зависимости
+	resultRejected = "rejected" // отклонен разомкнутым circuit breaker
+)
+
+var ms struct {
+	breakerState      *prometheus.GaugeVec
+	calls             *prometheus.CounterVec
+	retries           *prometheus.CounterVec
+	budgetExhausted   *prometheus.CounterVec
+	retryBudgetTokens *prometheus.GaugeVec
+	callDuration      *prometheus.HistogramVec
+}
+
+func init() {
+	ms.breakerState = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "balun_courses",
+			Subsystem: "resilience",
+			Name:      "circuit_breaker_state",
+			Help:      "Состояние circuit breaker: 0 - closed, 1 - open, 2 - half-open",
+		},
+		[]string{"name"},
+	)
+	ms.calls = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "balun_courses",
+			Subsystem: "resilience",
+			Name:      "calls_total",
+			Help:      "Попытки вызова зависимости по результату",
+		},
+		[]string{"name", "result"},
+	)
+	ms.retries = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "balun_courses",
+			Subsystem: "resilience",
+			Name:      "retries_total",
+			Help:      "Повторные вызовы зависимости",
+		},
+		[]string{"name"},
+	)
+	ms.budgetExhausted = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "balun_courses",
+			Subsystem: "resilience",
+			Name:      "retry_budget_exhausted_total",
+			Help:      "Повторы, не выполненные из-за исчерпания бюджета",
+		},
+		[]string{"name"},
+	)
+	ms.retryBudgetTokens = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "balun_courses",
+			Subsystem: "resilience",
+			Name:      "retry_budget_tokens",
+			Help:      "Остаток токенов бюджета повторов",
+		},
+		[]string{"name"},
+	)
+	ms.callDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "balun_courses",
+			Subsystem: "resilience",
+			Name:      "call_duration_seconds",
+			Help:      "Время одной попытки вызова зависимости",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+		},
+		[]string{"name", "result"},
+	)
+}
+
+func observeBreakerState(name string, state State) {
+	ms.breakerState.WithLabelValues(name).Set(float64(state))
+}
+
+func observeCall(name, result string, seconds float64) {
+	ms.calls.WithLabelValues(name, result).Inc()
+	if result != resultRejected {
+		ms.callDuration.WithLabelValues(name, result).Observe(seconds)
+	}
+}
+
+func observeRetry(name string) {
+	ms.retries.WithLabelValues(name).Inc()
+}
+
+func observeBudget(name string, budget *RetryBudget, exhausted bool) {
+	ms.retryBudgetTokens.WithLabelValues(name).Set(budget.Tokens())
+	if exhausted {
+		ms.budgetExhausted.WithLabelValues(name).Inc()
+	}
+}
@@ -0,0 +1,191 @@
+package resilience
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Policy - политика вызова внешней зависимости: таймаут на попытку,
+// повторы с backoff в рамках бюджета и circuit breaker.
+//
+//	policy := resilience.NewPolicy("wms",
+//		resilience.WithMaxAttempts(3),
+//		resilience.WithTimeout(time.Second),
+//		resilience.WithCircuitBreaker(resilience.DefaultBreakerConfig),
+//	)
+//	err := policy.Do(ctx, func(ctx context.Context) error { ... })
+type Policy struct {
+	name        string
+	maxAttempts int
+	timeout     time.Duration
+	backoff     Backoff
+	budget      *RetryBudget
+	breaker     *CircuitBreaker
+	retryable   func(err error) bool
+}
+
+// Option - опция Policy
+type Option func(p *Policy)
+
+// WithMaxAttempts - максимальное число попыток (вместе с первой)
+func WithMaxAttempts(attempts int) Option {
+	return func(p *Policy) {
+		p.maxAttempts = max(attempts, 1)
+	}
+}
+
+// WithTimeout - дедлайн одной попытки (дедлайн из ctx, если он раньше, сохраняется)
+func WithTimeout(timeout time.Duration) Option {
+	return func(p *Policy) {
+		p.timeout = timeout
+	}
+}
+
+// WithBackoff - задержка между попытками
+func WithBackoff(backoff Backoff) Option {
+	return func(p *Policy) {
+		p.backoff = backoff
+	}
+}
+
+// WithRetryBudget - бюджет повторов, общий для всех вызовов политики
+func WithRetryBudget(maxTokens, ratio float64) Option {
+	return func(p *Policy) {
+		p.budget = NewRetryBudget(maxTokens, ratio)
+	}
+}
+
+// WithCircuitBreaker - circuit breaker, общий для всех вызовов политики
+func WithCircuitBreaker(cfg BreakerConfig) Option {
+	return func(p *Policy) {
+		p.breaker = NewCircuitBreaker(p.name, cfg)
+	}
+}
+
+// WithRetryable - какие ошибки считаются отказом зависимости: только они повторяются,
+// тратят бюджет и размыкают breaker. Остальные ошибки - это ответ зависимости
+// (например, товара нет в наличии), для breaker такой вызов успешный
+func WithRetryable(retryable func(err error) bool) Option {
+	return func(p *Policy) {
+		p.retryable = retryable
+	}
+}
+
+// NewPolicy - политика по умолчанию: 3 попытки с DefaultBackoff, бюджет 10 токенов
+// с возвратом 0.1 токена за ответ, повторяются ошибки IsTransient, без breaker и таймаута.
+// name - метка в метриках
+func NewPolicy(name string, opts ...Option) *Policy {
+	p := &Policy{
+		name:        name,
+		maxAttempts: 3,
+		backoff:     DefaultBackoff,
+		budget:      NewRetryBudget(10, 0.1),
+		retryable:   IsTransient,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Name - имя политики
+func (p *Policy) Name() string {
+	return p.name
+}
+
+// Breaker - circuit breaker политики (nil, если не задан)
+func (p *Policy) Breaker() *CircuitBreaker {
+	return p.breaker
+}
+
+// Do - выполнение fn по политике. Возвращает ошибку последней попытки
+// или ErrCircuitOpen, если breaker разомкнут
+func (p *Policy) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	for attempt := 0; ; attempt++ {
+		err := p.call(ctx, fn)
+		if errors.Is(err, ErrCircuitOpen) {
+			// breaker разомкнут: повторять бесполезно, бюджет не тратим
+			return err
+		}
+		if err == nil || !p.retryable(err) {
+			p.budget.OnSuccess()
+			observeBudget(p.name, p.budget, false)
+			return err
+		}
+
+		p.budget.OnFailure()
+
+		// нет смысла повторять: попытки кончились или вызывающий уже не ждет
+		if attempt+1 >= p.maxAttempts || ctx.Err() != nil {
+			observeBudget(p.name, p.budget, false)
+			return err
+		}
+		if !p.budget.AllowRetry() {
+			observeBudget(p.name, p.budget, true)
+			return err
+		}
+		observeBudget(p.name, p.budget, false)
+
+		timer := time.NewTimer(p.backoff.Delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		observeRetry(p.name)
+	}
+}
+
+// call - одна попытка
+func (p *Policy) call(ctx context.Context, fn func(ctx context.Context) error) error {
+	done := func(bool) {}
+	if p.breaker != nil {
+		var err error
+		if done, err = p.breaker.Allow(); err != nil {
+			observeCall(p.name, resultRejected, 0)
+			return fmt.Errorf("%s: %w", p.name, err)
+		}
+	}
+
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := fn(ctx)
+
+	// отмена вызывающим - не отказ зависимости
+	failure := err != nil && p.retryable(err) && !errors.Is(ctx.Err(), context.Canceled)
+	done(failure)
+
+	result := resultSuccess
+	if failure {
+		result = resultFailure
+	}
+	observeCall(p.name, result, time.Since(start).Seconds())
+
+	return err
+}
+
+// IsTransient - временная ошибка, которую имеет смысл повторить:
+// истек дедлайн попытки или gRPC статус Unavailable, DeadlineExceeded, ResourceExhausted, Aborted
+func IsTransient(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,127 @@
+//go:build test
+
+package resilience
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestPolicy_Do(t *testing.T) {
+	var (
+		unavailable = status.Error(codes.Unavailable, "unavailable")
+		notFound    = status.Error(codes.NotFound, "not found")
+		noBackoff   = WithBackoff(Backoff{})
+	)
+
+	tests := []struct {
+		name      string
+		opts      []Option
+		results   []error // результаты попыток по порядку
+		wantCalls int
+		wantErr   error
+	}{
+		{
+			name:      "Test 1. Positive. Transient error is retried.",
+			opts:      []Option{noBackoff},
+			results:   []error{unavailable, nil},
+			wantCalls: 2,
+		},
+		{
+			name:      "Test 2. Negative. Attempts are exhausted.",
+			opts:      []Option{noBackoff, WithMaxAttempts(3)},
+			results:   []error{unavailable, unavailable, unavailable},
+			wantCalls: 3,
+			wantErr:   unavailable,
+		},
+		{
+			name:      "Test 3. Negative. Non transient error is not retried.",
+			opts:      []Option{noBackoff},
+			results:   []error{notFound},
+			wantCalls: 1,
+			wantErr:   notFound,
+		},
+		{
+			name:      "Test 4. Negative. Retry budget is exhausted.",
+			opts:      []Option{noBackoff, WithMaxAttempts(5), WithRetryBudget(4, 0.1)},
+			results:   []error{unavailable, unavailable, unavailable},
+			wantCalls: 2, // 4 -> 3 токена: повтор можно; 3 -> 2: бюджет исчерпан
+			wantErr:   unavailable,
+		},
+		{
+			name:      "Test 5. Negative. Open circuit breaker stops retries.",
+			opts:      []Option{noBackoff, WithMaxAttempts(5), WithCircuitBreaker(BreakerConfig{FailureThreshold: 2})},
+			results:   []error{unavailable, unavailable},
+			wantCalls: 2,
+			wantErr:   ErrCircuitOpen,
+		},
+		{
+			name: "Test 6. Positive. Attempt timeout is retried.",
+			opts: []Option{noBackoff, WithTimeout(time.Millisecond)},
+			// первая попытка ждет дедлайна
+			results:   []error{context.DeadlineExceeded, nil},
+			wantCalls: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// arrange
+			p := NewPolicy(t.Name(), tt.opts...)
+
+			var calls int
+			fn := func(ctx context.Context) error {
+				err := tt.results[calls]
+				calls++
+				if errors.Is(err, context.DeadlineExceeded) {
+					<-ctx.Done()
+					return ctx.Err()
+				}
+				return err
+			}
+
+			// act
+			err := p.Do(context.Background(), fn)
+
+			// assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestPolicy_Do_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := NewPolicy(t.Name(), WithBackoff(Backoff{Initial: time.Hour}))
+
+	var calls int
+	err := p.Do(ctx, func(context.Context) error {
+		calls++
+		cancel()
+		return status.Error(codes.Unavailable, "unavailable")
+	})
+
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 1, calls)
+}
+
+func TestBackoff_Delay(t *testing.T) {
+	b := Backoff{Initial: 10 * time.Millisecond, Max: 50 * time.Millisecond, Multiplier: 2, Jitter: 0.5}
+
+	for retry, want := range []time.Duration{10, 20, 40, 50, 50} {
+		want *= time.Millisecond
+
+		got := b.Delay(retry)
+		assert.LessOrEqual(t, got, want)
+		assert.GreaterOrEqual(t, got, want/2)
+	}
+}
@@ -0,0 +1,83 @@
+package worker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	pkgerrors "github.com/moguchev/microservices_courcse/orders_management_system/pkg/errors"
+	"github.com/moguchev/microservices_courcse/orders_management_system/pkg/logger"
+)
+
+// Func - один проход фонового процесса. Ошибка прохода логируется, следующий проход - по расписанию
+type Func func(ctx context.Context) error
+
+// Periodic - фоновый процесс, выполняющий проход сразу после запуска и дальше через interval после предыдущего
+type Periodic struct {
+	name     string
+	interval time.Duration
+	fn       Func
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewPeriodic - returns *Periodic. name - для логов
+func NewPeriodic(name string, interval time.Duration, fn Func) *Periodic {
+	return &Periodic{
+		name:     name,
+		interval: interval,
+		fn:       fn,
+	}
+}
+
+// Start - запускает процесс в фоне. Остановка через Stop (например из closer)
+func (p *Periodic) Start(ctx context.Context) {
+	ctx, p.cancel = context.WithCancel(ctx)
+
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		p.run(ctx)
+	}()
+}
+
+// Stop - останавливает процесс и дожидается завершения текущего прохода
+func (p *Periodic) Stop(ctx context.Context) error {
+	const api = "worker.Periodic.Stop"
+
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return pkgerrors.Wrap(api, ctx.Err())
+	}
+}
+
+func (p *Periodic) run(ctx context.Context) {
+	logger.Info(ctx, p.name+" started")
+	defer logger.Info(ctx, p.name+" stopped")
+
+	for {
+		if err := p.fn(ctx); err != nil {
+			logger.ErrorKV(ctx, p.name+": pass failed", "error", err.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(p.interval):
+		}
+	}
+}
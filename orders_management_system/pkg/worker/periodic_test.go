@@ -0,0 +1,76 @@
+//go:build test
+
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPeriodic(t *testing.T) {
+	t.Run("Test 1. Positive. Passes repeat after failures until Stop.", func(t *testing.T) {
+		var passes atomic.Int32
+		p := NewPeriodic(t.Name(), time.Millisecond, func(ctx context.Context) error {
+			passes.Add(1)
+			return errors.New("db is down")
+		})
+
+		p.Start(context.Background())
+		require.Eventually(t, func() bool { return passes.Load() >= 3 }, time.Second, time.Millisecond)
+		require.NoError(t, p.Stop(context.Background()))
+
+		// после Stop проходов больше нет
+		stopped := passes.Load()
+		time.Sleep(10 * time.Millisecond)
+		assert.Equal(t, stopped, passes.Load())
+	})
+
+	t.Run("Test 2. Positive. Stop waits for current pass.", func(t *testing.T) {
+		var (
+			started  = make(chan struct{})
+			finished atomic.Bool
+		)
+		p := NewPeriodic(t.Name(), time.Hour, func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			time.Sleep(10 * time.Millisecond)
+			finished.Store(true)
+			return ctx.Err()
+		})
+
+		p.Start(context.Background())
+		<-started
+		require.NoError(t, p.Stop(context.Background()))
+		assert.True(t, finished.Load())
+	})
+
+	t.Run("Test 3. Negative. Pass does not finish before Stop deadline.", func(t *testing.T) {
+		var (
+			started = make(chan struct{})
+			release = make(chan struct{})
+		)
+		p := NewPeriodic(t.Name(), time.Hour, func(context.Context) error {
+			close(started)
+			<-release // проход не смотрит на ctx
+			return nil
+		})
+		t.Cleanup(func() { close(release) })
+
+		p.Start(context.Background())
+		<-started
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		assert.ErrorIs(t, p.Stop(ctx), context.DeadlineExceeded)
+	})
+
+	t.Run("Test 4. Positive. Stop before Start.", func(t *testing.T) {
+		p := NewPeriodic(t.Name(), time.Second, func(context.Context) error { return nil })
+		assert.NoError(t, p.Stop(context.Background()))
+	})
+}
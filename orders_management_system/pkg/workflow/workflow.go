@@ -0,0 +1,268 @@
+package workflow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Status - статус выполнения workflow (саги)
+type Status string
+
+const (
+	StatusRunning      Status = "running"      // шаги выполняются
+	StatusCompensating Status = "compensating" // шаг упал, откатываем выполненные шаги
+	StatusCompleted    Status = "completed"    // все шаги выполнены
+	StatusCompensated  Status = "compensated"  // все выполненные шаги откачены
+)
+
+// State - прогресс выполнения workflow. Сохраняется в Log после каждого шага,
+// чтобы после падения процесса можно было откатить выполненные шаги (Resume)
+type State struct {
+	ID             uuid.UUID // ID экземпляра workflow
+	Name           string    // Тип workflow (по нему восстанавливаются шаги)
+	Payload        []byte    // Данные, из которых восстанавливаются шаги
+	Status         Status    // Статус
+	CompletedSteps []string  // Выполненные и еще не откаченные шаги (по порядку)
+	Error          string    // Ошибка, из-за которой началась компенсация
+	UpdatedAt      time.Time // Время последнего сохранения
+}
+
+// Log - журнал прогресса workflow
+type Log interface {
+	// SaveWorkflowState - сохранение (upsert) состояния по State.ID
+	SaveWorkflowState(ctx context.Context, state State) error
+}
+
+// Func - действие шага или его компенсация
+type Func func(ctx context.Context) error
+
+// AtomicFunc - действие шага, который сохраняет прогресс workflow сам: commit вызывается
+// внутри транзакции шага с ее контекстом
+type AtomicFunc func(ctx context.Context, commit Func) error
+
+type step struct {
+	name       string
+	action     Func
+	atomic     AtomicFunc
+	compensate Func
+}
+
+// Workflow - последовательность шагов с компенсирующими действиями (сага).
+//
+//	err := workflow.NewWorkflow(name, payload).
+//		Add("reserve", reserve, release).
+//		Add("create", create, nil).
+//		Do(ctx)
+type Workflow struct {
+	state State
+	steps []step
+	log   Log
+}
+
+type options struct {
+	id  uuid.UUID
+	log Log
+}
+
+// Option - опция workflow
+type Option func(opts *options)
+
+// WithID - ID экземпляра workflow (по умолчанию - случайный)
+func WithID(id uuid.UUID) Option {
+	return func(opts *options) {
+		opts.id = id
+	}
+}
+
+// WithLog - журнал, в который сохраняется прогресс (по умолчанию прогресс не сохраняется)
+func WithLog(log Log) Option {
+	return func(opts *options) {
+		opts.log = log
+	}
+}
+
+// NewWorkflow - returns *Workflow
+func NewWorkflow(name string, payload []byte, opts ...Option) *Workflow {
+	options := &options{
+		id: uuid.New(),
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return &Workflow{
+		state: State{
+			ID:      options.id,
+			Name:    name,
+			Payload: payload,
+		},
+		log: options.log,
+	}
+}
+
+// Add - добавляет шаг. compensate может быть nil, если откатывать нечего
+// (например шаг - это транзакция в БД)
+func (w *Workflow) Add(name string, action, compensate Func) *Workflow {
+	w.steps = append(w.steps, step{
+		name:       name,
+		action:     action,
+		compensate: compensate,
+	})
+	return w
+}
+
+// AddAtomic - добавляет шаг, выполненность которого сохраняется в его же транзакции (action вызывает commit).
+// Последний такой шаг завершает workflow вместе со своей транзакцией: после коммита ни ошибка сохранения,
+// ни падение процесса не запустят компенсацию. Если action вернул ошибку - прогресс откачен вместе с транзакцией
+func (w *Workflow) AddAtomic(name string, action AtomicFunc, compensate Func) *Workflow {
+	w.steps = append(w.steps, step{
+		name:       name,
+		atomic:     action,
+		compensate: compensate,
+	})
+	return w
+}
+
+// State - текущее состояние
+func (w *Workflow) State() State {
+	return w.state
+}
+
+// Do - выполняет шаги по порядку. Если шаг упал - откатывает уже выполненные шаги
+// в обратном порядке и возвращает ошибку шага (вместе с ошибкой компенсации, если была)
+func (w *Workflow) Do(ctx context.Context) error {
+	w.state.Status = StatusRunning
+	w.state.CompletedSteps = make([]string, 0, len(w.steps))
+	if err := w.save(ctx); err != nil {
+		return err
+	}
+
+	for i, s := range w.steps {
+		if s.atomic != nil {
+			last := i == len(w.steps)-1
+			if err := w.doAtomic(ctx, s, last); err != nil {
+				stepErr := fmt.Errorf("step %q: %w", s.name, err)
+				return errors.Join(stepErr, w.compensate(ctx, stepErr))
+			}
+			if last {
+				// статус completed сохранен в транзакции шага
+				return nil
+			}
+			continue
+		}
+
+		err := s.action(ctx)
+		if err == nil {
+			w.state.CompletedSteps = append(w.state.CompletedSteps, s.name)
+			// Не смогли запомнить прогресс - после падения про шаг никто не узнает, откатываем сразу
+			err = w.save(ctx)
+		}
+		if err != nil {
+			stepErr := fmt.Errorf("step %q: %w", s.name, err)
+			return errors.Join(stepErr, w.compensate(ctx, stepErr))
+		}
+	}
+
+	w.state.Status = StatusCompleted
+	return w.save(ctx)
+}
+
+// doAtomic - выполняет шаг, сохраняющий прогресс в своей транзакции. Состояние в памяти меняется
+// только после успешного шага: закоммиченный прогресс не расходится с сохраненным
+func (w *Workflow) doAtomic(ctx context.Context, s step, last bool) error {
+	next := w.state
+	next.CompletedSteps = append(append([]string(nil), w.state.CompletedSteps...), s.name)
+	if last {
+		next.Status = StatusCompleted
+	}
+
+	commit := func(txCtx context.Context) error {
+		return w.saveState(txCtx, &next)
+	}
+	if err := s.atomic(ctx, commit); err != nil {
+		return err
+	}
+
+	w.state = next
+	return nil
+}
+
+// Resume - продолжает компенсацию прерванного workflow (например после падения процесса).
+// Шаги workflow должны быть добавлены так же, как при исходном запуске
+func (w *Workflow) Resume(ctx context.Context, state State) error {
+	if state.Status != StatusRunning && state.Status != StatusCompensating {
+		return nil
+	}
+
+	w.state = state
+	cause := errors.New("interrupted")
+	if state.Error != "" {
+		cause = errors.New(state.Error)
+	}
+
+	return w.compensate(ctx, cause)
+}
+
+// compensate - откатывает выполненные шаги в обратном порядке. Откаченный шаг
+// сразу убирается из State.CompletedSteps, поэтому повторная компенсация продолжит с места остановки
+func (w *Workflow) compensate(ctx context.Context, cause error) error {
+	w.state.Status = StatusCompensating
+	w.state.Error = cause.Error()
+	if err := w.save(ctx); err != nil {
+		return err
+	}
+
+	for i := len(w.state.CompletedSteps) - 1; i >= 0; i-- {
+		name := w.state.CompletedSteps[i]
+
+		s, ok := w.step(name)
+		if !ok {
+			return fmt.Errorf("compensate: unknown step %q", name)
+		}
+		if s.compensate != nil {
+			if err := s.compensate(ctx); err != nil {
+				return fmt.Errorf("compensate step %q: %w", name, err)
+			}
+		}
+
+		w.state.CompletedSteps = w.state.CompletedSteps[:i]
+		if err := w.save(ctx); err != nil {
+			return err
+		}
+	}
+
+	w.state.Status = StatusCompensated
+	return w.save(ctx)
+}
+
+func (w *Workflow) step(name string) (step, bool) {
+	for _, s := range w.steps {
+		if s.name == name {
+			return s, true
+		}
+	}
+	return step{}, false
+}
+
+func (w *Workflow) save(ctx context.Context) error {
+	return w.saveState(ctx, &w.state)
+}
+
+func (w *Workflow) saveState(ctx context.Context, state *State) error {
+	if w.log == nil {
+		return nil
+	}
+
+	state.UpdatedAt = time.Now().UTC()
+
+	saved := *state
+	saved.CompletedSteps = append([]string(nil), state.CompletedSteps...)
+	if err := w.log.SaveWorkflowState(ctx, saved); err != nil {
+		return fmt.Errorf("save workflow state: %w", err)
+	}
+	return nil
+}
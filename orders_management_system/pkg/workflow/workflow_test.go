@@ -0,0 +1,153 @@
+//go:build test
+
+package workflow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryLog - журнал в памяти
+type memoryLog struct {
+	states []State
+	err    error // ошибка сохранения (например БД недоступна)
+}
+
+func (l *memoryLog) SaveWorkflowState(_ context.Context, state State) error {
+	if l.err != nil {
+		return l.err
+	}
+	l.states = append(l.states, state)
+	return nil
+}
+
+func (l *memoryLog) last() State {
+	return l.states[len(l.states)-1]
+}
+
+func TestWorkflow_Do(t *testing.T) {
+	var (
+		ctx     = context.Background()
+		errBoom = errors.New("boom")
+	)
+
+	t.Run("Test 1. Positive. All steps completed.", func(t *testing.T) {
+		var (
+			log   = &memoryLog{}
+			calls []string
+		)
+		err := NewWorkflow("test", nil, WithLog(log)).
+			Add("a", record(&calls, "a", nil), record(&calls, "-a", nil)).
+			Add("b", record(&calls, "b", nil), record(&calls, "-b", nil)).
+			Do(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, calls)
+		assert.Equal(t, StatusCompleted, log.last().Status)
+		assert.Equal(t, []string{"a", "b"}, log.last().CompletedSteps)
+	})
+
+	t.Run("Test 2. Negative. Failed step compensates completed steps in reverse order.", func(t *testing.T) {
+		var (
+			log   = &memoryLog{}
+			calls []string
+		)
+		err := NewWorkflow("test", nil, WithLog(log)).
+			Add("a", record(&calls, "a", nil), record(&calls, "-a", nil)).
+			Add("b", record(&calls, "b", nil), nil).
+			Add("c", record(&calls, "c", errBoom), record(&calls, "-c", nil)).
+			Do(ctx)
+
+		require.ErrorIs(t, err, errBoom)
+		assert.Equal(t, []string{"a", "b", "c", "-a"}, calls)
+		assert.Equal(t, StatusCompensated, log.last().Status)
+		assert.Empty(t, log.last().CompletedSteps)
+	})
+
+	t.Run("Test 3. Negative. Failed compensation is resumed from where it stopped.", func(t *testing.T) {
+		var (
+			log   = &memoryLog{}
+			calls []string
+		)
+		err := NewWorkflow("test", nil, WithLog(log)).
+			Add("a", record(&calls, "a", nil), record(&calls, "-a", nil)).
+			Add("b", record(&calls, "b", nil), record(&calls, "-b", errBoom)).
+			Add("c", record(&calls, "c", errBoom), nil).
+			Do(ctx)
+
+		require.ErrorIs(t, err, errBoom)
+		state := log.last()
+		assert.Equal(t, StatusCompensating, state.Status)
+		assert.Equal(t, []string{"a", "b"}, state.CompletedSteps)
+
+		// после "рестарта" восстанавливаем шаги и продолжаем компенсацию
+		calls = nil
+		err = NewWorkflow("test", nil, WithLog(log)).
+			Add("a", nil, record(&calls, "-a", nil)).
+			Add("b", nil, record(&calls, "-b", nil)).
+			Add("c", nil, nil).
+			Resume(ctx, state)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"-b", "-a"}, calls)
+		assert.Equal(t, StatusCompensated, log.last().Status)
+		assert.Equal(t, state.ID, log.last().ID)
+	})
+
+	t.Run("Test 4. Positive. Atomic last step completes workflow in its transaction, later save failures do not compensate.", func(t *testing.T) {
+		var (
+			log   = &memoryLog{}
+			calls []string
+		)
+		err := NewWorkflow("test", nil, WithLog(log)).
+			Add("a", record(&calls, "a", nil), record(&calls, "-a", nil)).
+			AddAtomic("b", func(ctx context.Context, commit Func) error {
+				calls = append(calls, "b")
+				if err := commit(ctx); err != nil {
+					return err
+				}
+				// транзакция закоммичена, дальше БД недоступна
+				log.err = errBoom
+				return nil
+			}, nil).
+			Do(ctx)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b"}, calls)
+		assert.Equal(t, StatusCompleted, log.last().Status)
+		assert.Equal(t, []string{"a", "b"}, log.last().CompletedSteps)
+	})
+
+	t.Run("Test 5. Negative. Atomic step rolled back after commit call is compensated as not done.", func(t *testing.T) {
+		var (
+			log   = &memoryLog{}
+			calls []string
+		)
+		err := NewWorkflow("test", nil, WithLog(log)).
+			Add("a", record(&calls, "a", nil), record(&calls, "-a", nil)).
+			AddAtomic("b", func(ctx context.Context, commit Func) error {
+				calls = append(calls, "b")
+				if err := commit(ctx); err != nil {
+					return err
+				}
+				return errBoom // коммит транзакции не удался
+			}, record(&calls, "-b", nil)).
+			Do(ctx)
+
+		require.ErrorIs(t, err, errBoom)
+		assert.Equal(t, []string{"a", "b", "-a"}, calls)
+		assert.Equal(t, StatusCompensated, log.last().Status)
+		assert.Empty(t, log.last().CompletedSteps)
+	})
+}
+
+func record(calls *[]string, name string, err error) Func {
+	return func(context.Context) error {
+		*calls = append(*calls, name)
+		return err
+	}
+}